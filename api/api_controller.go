@@ -19,11 +19,13 @@ import (
 	authop "github.com/treeverse/lakefs/api/gen/restapi/operations/auth"
 	"github.com/treeverse/lakefs/api/gen/restapi/operations/branches"
 	"github.com/treeverse/lakefs/api/gen/restapi/operations/commits"
+	"github.com/treeverse/lakefs/api/gen/restapi/operations/hooks"
 	metadataop "github.com/treeverse/lakefs/api/gen/restapi/operations/metadata"
 	"github.com/treeverse/lakefs/api/gen/restapi/operations/objects"
 	"github.com/treeverse/lakefs/api/gen/restapi/operations/refs"
 	"github.com/treeverse/lakefs/api/gen/restapi/operations/repositories"
 	retentionop "github.com/treeverse/lakefs/api/gen/restapi/operations/retention"
+	"github.com/treeverse/lakefs/api/gen/restapi/operations/tags"
 	"github.com/treeverse/lakefs/auth"
 	"github.com/treeverse/lakefs/auth/model"
 	"github.com/treeverse/lakefs/block"
@@ -145,6 +147,7 @@ func (c *Controller) Configure(api *operations.LakefsAPI) {
 	api.RepositoriesListRepositoriesHandler = c.ListRepositoriesHandler()
 	api.RepositoriesGetRepositoryHandler = c.GetRepoHandler()
 	api.RepositoriesCreateRepositoryHandler = c.CreateRepositoryHandler()
+	api.RepositoriesUpdateRepositoryHandler = c.UpdateRepositoryHandler()
 	api.RepositoriesDeleteRepositoryHandler = c.DeleteRepositoryHandler()
 	api.RepositoriesImportFromS3InventoryHandler = c.ImportFromS3InventoryHandler()
 
@@ -152,7 +155,21 @@ func (c *Controller) Configure(api *operations.LakefsAPI) {
 	api.BranchesGetBranchHandler = c.GetBranchHandler()
 	api.BranchesCreateBranchHandler = c.CreateBranchHandler()
 	api.BranchesDeleteBranchHandler = c.DeleteBranchHandler()
+	api.BranchesRenameBranchHandler = c.RenameBranchHandler()
 	api.BranchesRevertBranchHandler = c.RevertBranchHandler()
+	api.BranchesCherryPickHandler = c.CherryPickHandler()
+	api.BranchesListBranchProtectionRulesHandler = c.ListBranchProtectionRulesHandler()
+	api.BranchesCreateBranchProtectionRuleHandler = c.CreateBranchProtectionRuleHandler()
+	api.BranchesDeleteBranchProtectionRuleHandler = c.DeleteBranchProtectionRuleHandler()
+
+	api.HooksListHooksHandler = c.ListHooksHandler()
+	api.HooksCreateHookHandler = c.CreateHookHandler()
+	api.HooksDeleteHookHandler = c.DeleteHookHandler()
+
+	api.TagsListTagsHandler = c.ListTagsHandler()
+	api.TagsGetTagHandler = c.GetTagHandler()
+	api.TagsCreateTagHandler = c.CreateTagHandler()
+	api.TagsDeleteTagHandler = c.DeleteTagHandler()
 
 	api.CommitsCommitHandler = c.CommitHandler()
 	api.CommitsGetCommitHandler = c.GetCommitHandler()
@@ -165,9 +182,11 @@ func (c *Controller) Configure(api *operations.LakefsAPI) {
 	api.ObjectsStatObjectHandler = c.ObjectsStatObjectHandler()
 	api.ObjectsGetUnderlyingPropertiesHandler = c.ObjectsGetUnderlyingPropertiesHandler()
 	api.ObjectsListObjectsHandler = c.ObjectsListObjectsHandler()
+	api.ObjectsGetObjectHistoryHandler = c.ObjectsGetObjectHistoryHandler()
 	api.ObjectsGetObjectHandler = c.ObjectsGetObjectHandler()
 	api.ObjectsUploadObjectHandler = c.ObjectsUploadObjectHandler()
 	api.ObjectsDeleteObjectHandler = c.ObjectsDeleteObjectHandler()
+	api.ObjectsMoveObjectsHandler = c.ObjectsMoveObjectsHandler()
 
 	api.RetentionGetRetentionPolicyHandler = c.RetentionGetRetentionPolicyHandler()
 	api.RetentionUpdateRetentionPolicyHandler = c.RetentionUpdateRetentionPolicyHandler()
@@ -235,12 +254,7 @@ func (c *Controller) ListRepositoriesHandler() repositories.ListRepositoriesHand
 		repoList := make([]*models.Repository, len(repos))
 		var lastID string
 		for i, repo := range repos {
-			repoList[i] = &models.Repository{
-				StorageNamespace: repo.StorageNamespace,
-				CreationDate:     repo.CreationDate.Unix(),
-				DefaultBranch:    repo.DefaultBranch,
-				ID:               repo.Name,
-			}
+			repoList[i] = transformRepository(repo)
 			lastID = repo.Name
 		}
 		returnValue := repositories.NewListRepositoriesOK().WithPayload(&repositories.ListRepositoriesOKBody{
@@ -274,6 +288,21 @@ func getPaginationParams(swagAfter *string, swagAmount *int64) (string, int) {
 	return after, amount
 }
 
+func getDiffParams(swagAfter *string, swagAmount *int64, swagPrefix *string, types []string) catalog.DiffParams {
+	after, amount := getPaginationParams(swagAfter, swagAmount)
+	diffParams := catalog.DiffParams{
+		Limit:  amount,
+		After:  after,
+		Prefix: swag.StringValue(swagPrefix),
+		// the summary is returned with the first page
+		WithSummary: after == "",
+	}
+	for _, typ := range types {
+		diffParams.DifferenceTypes = append(diffParams.DifferenceTypes, transformDiffTypeToDifferenceType(typ))
+	}
+	return diffParams
+}
+
 func (c *Controller) GetRepoHandler() repositories.GetRepositoryHandler {
 	return repositories.GetRepositoryHandlerFunc(func(params repositories.GetRepositoryParams, user *models.User) middleware.Responder {
 		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
@@ -296,13 +325,38 @@ func (c *Controller) GetRepoHandler() repositories.GetRepositoryHandler {
 				WithPayload(responseError("error fetching repository: %s", err))
 		}
 
-		return repositories.NewGetRepositoryOK().
-			WithPayload(&models.Repository{
-				StorageNamespace: repo.StorageNamespace,
-				CreationDate:     repo.CreationDate.Unix(),
-				DefaultBranch:    repo.DefaultBranch,
-				ID:               repo.Name,
-			})
+		return repositories.NewGetRepositoryOK().WithPayload(transformRepository(repo))
+	})
+}
+
+func (c *Controller) UpdateRepositoryHandler() repositories.UpdateRepositoryHandler {
+	return repositories.UpdateRepositoryHandlerFunc(func(params repositories.UpdateRepositoryParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.UpdateRepositoryAction,
+				Resource: permissions.RepoArn(params.Repository),
+			},
+		})
+		if err != nil {
+			return repositories.NewUpdateRepositoryUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("update_repo")
+		repo, err := deps.Cataloger.UpdateRepository(c.Context(), params.Repository, catalog.UpdateRepositoryParams{
+			DefaultBranch: params.Update.DefaultBranch,
+			Description:   params.Update.Description,
+			Metadata:      params.Update.Metadata,
+		})
+		switch {
+		case err == nil:
+			return repositories.NewUpdateRepositoryOK().WithPayload(transformRepository(repo))
+		case errors.Is(err, catalog.ErrInvalidValue):
+			return repositories.NewUpdateRepositoryBadRequest().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, db.ErrNotFound):
+			return repositories.NewUpdateRepositoryNotFound().WithPayload(responseErrorFrom(err))
+		default:
+			return repositories.NewUpdateRepositoryDefault(http.StatusInternalServerError).
+				WithPayload(responseError("error updating repository: %s", err))
+		}
 	})
 }
 
@@ -356,6 +410,15 @@ func (c *Controller) CommitHandler() commits.CommitHandler {
 		commitMessage := swag.StringValue(params.Commit.Message)
 		commit, err := deps.Cataloger.Commit(c.Context(), params.Repository,
 			params.Branch, commitMessage, committer, params.Commit.Metadata)
+		if errors.Is(err, catalog.ErrBranchProtected) {
+			return commits.NewCommitDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
+		}
+		if errors.Is(err, catalog.ErrHookRejected) {
+			return commits.NewCommitDefault(http.StatusPreconditionFailed).WithPayload(responseErrorFrom(err))
+		}
+		if errors.Is(err, catalog.ErrBranchChangedDuringHooks) {
+			return commits.NewCommitDefault(http.StatusConflict).WithPayload(responseErrorFrom(err))
+		}
 		if err != nil {
 			return commits.NewCommitDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
 		}
@@ -385,8 +448,29 @@ func (c *Controller) CommitsGetBranchCommitLogHandler() commits.GetBranchCommitL
 		cataloger := deps.Cataloger
 
 		after, amount := getPaginationParams(params.After, params.Amount)
+		listParams := catalog.ListCommitsParams{
+			Prefix:    swag.StringValue(params.Prefix),
+			Committer: swag.StringValue(params.Committer),
+		}
+		if params.Since != nil {
+			listParams.Since = time.Unix(*params.Since, 0)
+		}
+		if params.Until != nil {
+			listParams.Until = time.Unix(*params.Until, 0)
+		}
+		for _, pair := range params.Metadata {
+			kv := strings.SplitN(pair, "=", 2)
+			if len(kv) != 2 {
+				return commits.NewGetBranchCommitLogBadRequest().
+					WithPayload(responseError("invalid metadata filter '%s', expected key=value", pair))
+			}
+			if listParams.Metadata == nil {
+				listParams.Metadata = catalog.Metadata{}
+			}
+			listParams.Metadata[kv[0]] = kv[1]
+		}
 		// get commit log
-		commitLog, hasMore, err := cataloger.ListCommits(c.Context(), params.Repository, params.Branch, after, amount)
+		commitLog, hasMore, err := cataloger.ListCommits(c.Context(), params.Repository, params.Branch, after, amount, listParams)
 		if errors.Is(err, db.ErrNotFound) {
 			return commits.NewGetBranchCommitLogNotFound().WithPayload(responseError("branch '%s' not found", params.Branch))
 		}
@@ -475,12 +559,7 @@ func (c *Controller) CreateRepositoryHandler() repositories.CreateRepositoryHand
 				WithPayload(responseError(fmt.Sprintf("error creating repository: %s", err)))
 		}
 
-		return repositories.NewCreateRepositoryCreated().WithPayload(&models.Repository{
-			StorageNamespace: repo.StorageNamespace,
-			CreationDate:     repo.CreationDate.Unix(),
-			DefaultBranch:    repo.DefaultBranch,
-			ID:               repo.Name,
-		})
+		return repositories.NewCreateRepositoryCreated().WithPayload(transformRepository(repo))
 	})
 }
 
@@ -624,6 +703,9 @@ func (c *Controller) DeleteBranchHandler() branches.DeleteBranchHandler {
 			return branches.NewDeleteBranchNotFound().
 				WithPayload(responseError("branch '%s' not found", params.Branch))
 		}
+		if errors.Is(err, catalog.ErrBranchProtected) {
+			return branches.NewDeleteBranchDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
+		}
 		if err != nil {
 			return branches.NewDeleteBranchDefault(http.StatusInternalServerError).
 				WithPayload(responseError("error fetching branch: %s", err))
@@ -633,6 +715,345 @@ func (c *Controller) DeleteBranchHandler() branches.DeleteBranchHandler {
 	})
 }
 
+func (c *Controller) RenameBranchHandler() branches.RenameBranchHandler {
+	return branches.RenameBranchHandlerFunc(func(params branches.RenameBranchParams, user *models.User) middleware.Responder {
+		name := swag.StringValue(params.Rename.Name)
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.DeleteBranchAction,
+				Resource: permissions.BranchArn(params.Repository, params.Branch),
+			},
+			{
+				Action:   permissions.CreateBranchAction,
+				Resource: permissions.BranchArn(params.Repository, name),
+			},
+		})
+		if err != nil {
+			return branches.NewRenameBranchUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("rename_branch")
+		err = deps.Cataloger.RenameBranch(c.Context(), params.Repository, params.Branch, name)
+		switch {
+		case err == nil:
+			return branches.NewRenameBranchNoContent()
+		case errors.Is(err, catalog.ErrBranchProtected):
+			return branches.NewRenameBranchDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
+		case errors.Is(err, catalog.ErrInvalidValue), errors.Is(err, catalog.ErrOperationNotPermitted):
+			return branches.NewRenameBranchBadRequest().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, db.ErrAlreadyExists):
+			return branches.NewRenameBranchConflict().
+				WithPayload(responseError("branch '%s' already exists", name))
+		case errors.Is(err, db.ErrNotFound):
+			return branches.NewRenameBranchNotFound().
+				WithPayload(responseError("branch '%s' not found", params.Branch))
+		default:
+			return branches.NewRenameBranchDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+	})
+}
+
+func (c *Controller) ListBranchProtectionRulesHandler() branches.ListBranchProtectionRulesHandler {
+	return branches.ListBranchProtectionRulesHandlerFunc(func(params branches.ListBranchProtectionRulesParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.ListBranchProtectionRulesAction,
+				Resource: permissions.RepoArn(params.Repository),
+			},
+		})
+		if err != nil {
+			return branches.NewListBranchProtectionRulesUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("list_branch_protection_rules")
+		res, err := deps.Cataloger.ListBranchProtectionRules(c.Context(), params.Repository)
+		if errors.Is(err, db.ErrNotFound) {
+			return branches.NewListBranchProtectionRulesNotFound().
+				WithPayload(responseError("repository '%s' not found", params.Repository))
+		}
+		if err != nil {
+			return branches.NewListBranchProtectionRulesDefault(http.StatusInternalServerError).
+				WithPayload(responseError("could not list branch protection rules: %s", err))
+		}
+		rules := make([]*models.BranchProtectionRule, len(res))
+		for i, rule := range res {
+			rules[i] = transformBranchProtectionRule(rule)
+		}
+		return branches.NewListBranchProtectionRulesOK().WithPayload(&branches.ListBranchProtectionRulesOKBody{
+			Results: rules,
+		})
+	})
+}
+
+func (c *Controller) CreateBranchProtectionRuleHandler() branches.CreateBranchProtectionRuleHandler {
+	return branches.CreateBranchProtectionRuleHandlerFunc(func(params branches.CreateBranchProtectionRuleParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.CreateBranchProtectionRuleAction,
+				Resource: permissions.RepoArn(params.Repository),
+			},
+		})
+		if err != nil {
+			return branches.NewCreateBranchProtectionRuleUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("create_branch_protection_rule")
+		err = deps.Cataloger.CreateBranchProtectionRule(c.Context(), params.Repository, swag.StringValue(params.Rule.Pattern))
+		switch {
+		case errors.Is(err, catalog.ErrInvalidValue):
+			return branches.NewCreateBranchProtectionRuleBadRequest().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, db.ErrNotFound):
+			return branches.NewCreateBranchProtectionRuleNotFound().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, db.ErrAlreadyExists):
+			return branches.NewCreateBranchProtectionRuleConflict().WithPayload(responseErrorFrom(err))
+		case err != nil:
+			return branches.NewCreateBranchProtectionRuleDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		return branches.NewCreateBranchProtectionRuleNoContent()
+	})
+}
+
+func (c *Controller) DeleteBranchProtectionRuleHandler() branches.DeleteBranchProtectionRuleHandler {
+	return branches.DeleteBranchProtectionRuleHandlerFunc(func(params branches.DeleteBranchProtectionRuleParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.DeleteBranchProtectionRuleAction,
+				Resource: permissions.RepoArn(params.Repository),
+			},
+		})
+		if err != nil {
+			return branches.NewDeleteBranchProtectionRuleUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("delete_branch_protection_rule")
+		err = deps.Cataloger.DeleteBranchProtectionRule(c.Context(), params.Repository, params.Pattern)
+		if errors.Is(err, db.ErrNotFound) {
+			return branches.NewDeleteBranchProtectionRuleNotFound().
+				WithPayload(responseError("branch protection rule '%s' not found", params.Pattern))
+		}
+		if err != nil {
+			return branches.NewDeleteBranchProtectionRuleDefault(http.StatusInternalServerError).
+				WithPayload(responseError("error deleting branch protection rule: %s", err))
+		}
+		return branches.NewDeleteBranchProtectionRuleNoContent()
+	})
+}
+
+func (c *Controller) ListHooksHandler() hooks.ListHooksHandler {
+	return hooks.ListHooksHandlerFunc(func(params hooks.ListHooksParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.ListHooksAction,
+				Resource: permissions.RepoArn(params.Repository),
+			},
+		})
+		if err != nil {
+			return hooks.NewListHooksUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("list_hooks")
+		res, err := deps.Cataloger.ListHooks(c.Context(), params.Repository)
+		if errors.Is(err, db.ErrNotFound) {
+			return hooks.NewListHooksNotFound().
+				WithPayload(responseError("repository '%s' not found", params.Repository))
+		}
+		if err != nil {
+			return hooks.NewListHooksDefault(http.StatusInternalServerError).
+				WithPayload(responseError("could not list hooks: %s", err))
+		}
+		hookList := make([]*models.Hook, len(res))
+		for i, hook := range res {
+			hookList[i] = transformHook(hook)
+		}
+		return hooks.NewListHooksOK().WithPayload(&hooks.ListHooksOKBody{
+			Results: hookList,
+		})
+	})
+}
+
+func (c *Controller) CreateHookHandler() hooks.CreateHookHandler {
+	return hooks.CreateHookHandlerFunc(func(params hooks.CreateHookParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.CreateHookAction,
+				Resource: permissions.RepoArn(params.Repository),
+			},
+		})
+		if err != nil {
+			return hooks.NewCreateHookUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("create_hook")
+		hook := &catalog.Hook{
+			Name:    swag.StringValue(params.Hook.Name),
+			Type:    catalog.HookType(swag.StringValue(params.Hook.Type)),
+			URL:     swag.StringValue(params.Hook.URL),
+			Timeout: time.Duration(params.Hook.Timeout) * time.Second,
+		}
+		err = deps.Cataloger.CreateHook(c.Context(), params.Repository, hook)
+		switch {
+		case errors.Is(err, catalog.ErrInvalidValue):
+			return hooks.NewCreateHookBadRequest().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, db.ErrNotFound):
+			return hooks.NewCreateHookNotFound().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, db.ErrAlreadyExists):
+			return hooks.NewCreateHookConflict().WithPayload(responseErrorFrom(err))
+		case err != nil:
+			return hooks.NewCreateHookDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		return hooks.NewCreateHookCreated().WithPayload(transformHook(hook))
+	})
+}
+
+func (c *Controller) DeleteHookHandler() hooks.DeleteHookHandler {
+	return hooks.DeleteHookHandlerFunc(func(params hooks.DeleteHookParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.DeleteHookAction,
+				Resource: permissions.RepoArn(params.Repository),
+			},
+		})
+		if err != nil {
+			return hooks.NewDeleteHookUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("delete_hook")
+		err = deps.Cataloger.DeleteHook(c.Context(), params.Repository, params.Hook)
+		if errors.Is(err, db.ErrNotFound) {
+			return hooks.NewDeleteHookNotFound().
+				WithPayload(responseError("hook '%s' not found", params.Hook))
+		}
+		if err != nil {
+			return hooks.NewDeleteHookDefault(http.StatusInternalServerError).
+				WithPayload(responseError("error deleting hook: %s", err))
+		}
+		return hooks.NewDeleteHookNoContent()
+	})
+}
+
+func (c *Controller) ListTagsHandler() tags.ListTagsHandler {
+	return tags.ListTagsHandlerFunc(func(params tags.ListTagsParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.ListTagsAction,
+				Resource: permissions.RepoArn(params.Repository),
+			},
+		})
+		if err != nil {
+			return tags.NewListTagsUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("list_tags")
+		cataloger := deps.Cataloger
+
+		after, amount := getPaginationParams(params.After, params.Amount)
+
+		res, hasMore, err := cataloger.ListTags(c.Context(), params.Repository, amount, after)
+		if errors.Is(err, db.ErrNotFound) {
+			return tags.NewListTagsNotFound().
+				WithPayload(responseError("repository '%s' not found", params.Repository))
+		}
+		if err != nil {
+			return tags.NewListTagsDefault(http.StatusInternalServerError).
+				WithPayload(responseError("could not list tags: %s", err))
+		}
+
+		tagList := make([]*models.Tag, len(res))
+		var lastID string
+		for i, tag := range res {
+			tagList[i] = transformTag(tag)
+			lastID = tag.Name
+		}
+		returnValue := tags.NewListTagsOK().WithPayload(&tags.ListTagsOKBody{
+			Pagination: &models.Pagination{
+				HasMore:    swag.Bool(hasMore),
+				Results:    swag.Int64(int64(len(tagList))),
+				MaxPerPage: swag.Int64(MaxResultsPerPage),
+			},
+			Results: tagList,
+		})
+
+		if hasMore {
+			returnValue.Payload.Pagination.NextOffset = lastID
+		}
+
+		return returnValue
+	})
+}
+
+func (c *Controller) GetTagHandler() tags.GetTagHandler {
+	return tags.GetTagHandlerFunc(func(params tags.GetTagParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.ReadTagAction,
+				Resource: permissions.TagArn(params.Repository, params.Tag),
+			},
+		})
+		if err != nil {
+			return tags.NewGetTagUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("get_tag")
+		tag, err := deps.Cataloger.GetTag(c.Context(), params.Repository, params.Tag)
+		if errors.Is(err, db.ErrNotFound) {
+			return tags.NewGetTagNotFound().
+				WithPayload(responseError("tag '%s' not found", params.Tag))
+		}
+		if err != nil {
+			return tags.NewGetTagDefault(http.StatusInternalServerError).
+				WithPayload(responseError("error fetching tag: %s", err))
+		}
+
+		return tags.NewGetTagOK().WithPayload(transformTag(tag))
+	})
+}
+
+func (c *Controller) CreateTagHandler() tags.CreateTagHandler {
+	return tags.CreateTagHandlerFunc(func(params tags.CreateTagParams, user *models.User) middleware.Responder {
+		repository := params.Repository
+		tagID := swag.StringValue(params.Tag.ID)
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.CreateTagAction,
+				Resource: permissions.TagArn(repository, tagID),
+			},
+		})
+		if err != nil {
+			return tags.NewCreateTagUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("create_tag")
+		tag, err := deps.Cataloger.CreateTag(c.Context(), repository, tagID, swag.StringValue(params.Tag.Ref))
+		switch {
+		case errors.Is(err, catalog.ErrInvalidValue), errors.Is(err, catalog.ErrOperationNotPermitted):
+			return tags.NewCreateTagBadRequest().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, db.ErrNotFound):
+			return tags.NewCreateTagNotFound().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, db.ErrAlreadyExists):
+			return tags.NewCreateTagConflict().WithPayload(responseErrorFrom(err))
+		case err != nil:
+			return tags.NewCreateTagDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+		return tags.NewCreateTagCreated().WithPayload(transformTag(tag))
+	})
+}
+
+func (c *Controller) DeleteTagHandler() tags.DeleteTagHandler {
+	return tags.DeleteTagHandlerFunc(func(params tags.DeleteTagParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.DeleteTagAction,
+				Resource: permissions.TagArn(params.Repository, params.Tag),
+			},
+		})
+		if err != nil {
+			return tags.NewDeleteTagUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("delete_tag")
+		err = deps.Cataloger.DeleteTag(c.Context(), params.Repository, params.Tag)
+		if errors.Is(err, db.ErrNotFound) {
+			return tags.NewDeleteTagNotFound().
+				WithPayload(responseError("tag '%s' not found", params.Tag))
+		}
+		if err != nil {
+			return tags.NewDeleteTagDefault(http.StatusInternalServerError).
+				WithPayload(responseError("error deleting tag: %s", err))
+		}
+
+		return tags.NewDeleteTagNoContent()
+	})
+}
+
 func (c *Controller) MergeMergeIntoBranchHandler() refs.MergeIntoBranchHandler {
 	return refs.MergeIntoBranchHandlerFunc(func(params refs.MergeIntoBranchParams, user *models.User) middleware.Responder {
 		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
@@ -651,29 +1072,50 @@ func (c *Controller) MergeMergeIntoBranchHandler() refs.MergeIntoBranchHandler {
 		}
 		var message string
 		var metadata map[string]string
+		var mergeParams catalog.MergeParams
 		if params.Merge != nil {
 			message = params.Merge.Message
 			metadata = params.Merge.Metadata
+			mergeParams.Strategy = catalog.MergeStrategy(params.Merge.Strategy)
+			mergeParams.DryRun = params.Merge.DryRun
 		}
 		res, err := deps.Cataloger.Merge(c.Context(),
 			params.Repository, params.SourceRef, params.DestinationRef,
 			userModel.DisplayName,
 			message,
-			metadata)
+			metadata,
+			mergeParams)
 
 		// convert merge differences into merge results
 		var mergeResults []*models.MergeResult
+		var mergeConflicts []*models.MergeConflict
+		var mergeSummary *models.MergeSummary
 		if res != nil {
+			mergeSummary = transformMergeSummary(res.Summary)
 			mergeResults = make([]*models.MergeResult, len(res.Differences))
 			for i, d := range res.Differences {
 				mergeResults[i] = transformDifferenceToMergeResult(d)
 			}
+			mergeConflicts = make([]*models.MergeConflict, len(res.Conflicts))
+			for i, conflict := range res.Conflicts {
+				mergeConflicts[i] = transformMergeConflict(conflict)
+			}
 		}
 
+		if errors.Is(err, catalog.ErrInvalidValue) {
+			return refs.NewMergeIntoBranchBadRequest().WithPayload(responseErrorFrom(err))
+		}
+		if errors.Is(err, catalog.ErrHookRejected) {
+			return refs.NewMergeIntoBranchDefault(http.StatusPreconditionFailed).WithPayload(responseErrorFrom(err))
+		}
+		if errors.Is(err, catalog.ErrBranchChangedDuringHooks) {
+			return refs.NewMergeIntoBranchDefault(http.StatusConflict).WithPayload(responseErrorFrom(err))
+		}
 		switch err {
 		case nil:
 			pl := new(refs.MergeIntoBranchOKBody)
 			pl.Results = mergeResults
+			pl.Summary = mergeSummary
 			return refs.NewMergeIntoBranchOK().WithPayload(pl)
 		case catalog.ErrUnsupportedRelation:
 			return refs.NewMergeIntoBranchDefault(http.StatusInternalServerError).WithPayload(responseError("branches have no common base"))
@@ -682,6 +1124,8 @@ func (c *Controller) MergeMergeIntoBranchHandler() refs.MergeIntoBranchHandler {
 		case catalog.ErrConflictFound:
 			pl := new(refs.MergeIntoBranchConflictBody)
 			pl.Results = mergeResults
+			pl.Conflicts = mergeConflicts
+			pl.Summary = mergeSummary
 			return refs.NewMergeIntoBranchConflict().WithPayload(pl)
 		case catalog.ErrNoDifferenceWasFound:
 			return refs.NewMergeIntoBranchDefault(http.StatusInternalServerError).WithPayload(responseError("no difference was found"))
@@ -704,18 +1148,23 @@ func (c *Controller) BranchesDiffBranchHandler() branches.DiffBranchHandler {
 		}
 		deps.LogAction("diff_workspace")
 		cataloger := deps.Cataloger
-		diff, err := cataloger.DiffUncommitted(c.Context(), params.Repository, params.Branch)
+		diffParams := getDiffParams(params.After, params.Amount, params.Prefix, params.Type)
+		diff, err := cataloger.DiffUncommitted(c.Context(), params.Repository, params.Branch, diffParams)
 		if err != nil {
 			return branches.NewDiffBranchDefault(http.StatusInternalServerError).
 				WithPayload(responseError("could not diff branch: %s", err))
 		}
 
-		results := make([]*models.Diff, len(diff))
-		for i, d := range diff {
+		results := make([]*models.Diff, len(diff.Differences))
+		for i, d := range diff.Differences {
 			results[i] = transformDifferenceToDiff(d)
 		}
 
-		return branches.NewDiffBranchOK().WithPayload(&branches.DiffBranchOKBody{Results: results})
+		return branches.NewDiffBranchOK().WithPayload(&branches.DiffBranchOKBody{
+			Pagination: transformDiffPagination(diff),
+			Summary:    transformDiffSummary(diff.Summary),
+			Results:    results,
+		})
 	})
 }
 
@@ -732,7 +1181,8 @@ func (c *Controller) RefsDiffRefsHandler() refs.DiffRefsHandler {
 		}
 		deps.LogAction("diff_refs")
 		cataloger := deps.Cataloger
-		diff, err := cataloger.Diff(c.Context(), params.Repository, params.LeftRef, params.RightRef)
+		diffParams := getDiffParams(params.After, params.Amount, params.Prefix, params.Type)
+		diff, err := cataloger.Diff(c.Context(), params.Repository, params.LeftRef, params.RightRef, diffParams)
 		if errors.Is(err, catalog.ErrFeatureNotSupported) {
 			return refs.NewDiffRefsDefault(http.StatusNotImplemented).WithPayload(responseError(err.Error()))
 		}
@@ -741,11 +1191,15 @@ func (c *Controller) RefsDiffRefsHandler() refs.DiffRefsHandler {
 				WithPayload(responseError("could not diff references: %s", err))
 		}
 
-		results := make([]*models.Diff, len(diff))
-		for i, d := range diff {
+		results := make([]*models.Diff, len(diff.Differences))
+		for i, d := range diff.Differences {
 			results[i] = transformDifferenceToDiff(d)
 		}
-		return refs.NewDiffRefsOK().WithPayload(&refs.DiffRefsOKBody{Results: results})
+		return refs.NewDiffRefsOK().WithPayload(&refs.DiffRefsOKBody{
+			Pagination: transformDiffPagination(diff),
+			Summary:    transformDiffSummary(diff.Summary),
+			Results:    results,
+		})
 	})
 }
 
@@ -779,6 +1233,8 @@ func (c *Controller) ObjectsStatObjectHandler() objects.StatObjectHandler {
 			Path:      params.Path,
 			PathType:  models.ObjectStatsPathTypeObject,
 			SizeBytes: entry.Size,
+			Metadata:  entry.Metadata.WithoutTags(),
+			Tags:      entry.Metadata.Tags(),
 		}
 
 		if entry.Expired {
@@ -866,6 +1322,22 @@ func (c *Controller) ObjectsGetObjectHandler() objects.GetObjectHandler {
 		if err != nil {
 			return objects.NewGetObjectDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
 		}
+		// evaluate conditional headers
+		conditions := httputil.Conditions{
+			IfMatch:           swag.StringValue(params.IfMatch),
+			IfNoneMatch:       swag.StringValue(params.IfNoneMatch),
+			IfModifiedSince:   swag.StringValue(params.IfModifiedSince),
+			IfUnmodifiedSince: swag.StringValue(params.IfUnmodifiedSince),
+		}
+		switch conditions.Evaluate(entry.Checksum, entry.CreationDate) {
+		case httputil.ConditionNotModified:
+			return objects.NewGetObjectNotModified().
+				WithETag(httputil.ETag(entry.Checksum)).
+				WithLastModified(httputil.HeaderTimestamp(entry.CreationDate))
+		case httputil.ConditionFailed:
+			return objects.NewGetObjectPreconditionFailed().WithPayload(responseError("precondition failed"))
+		}
+
 		// setup response
 		res := objects.NewGetObjectOK()
 		res.ETag = httputil.ETag(entry.Checksum)
@@ -1048,6 +1520,50 @@ func (c *Controller) ObjectsListObjectsHandler() objects.ListObjectsHandler {
 	})
 }
 
+func (c *Controller) ObjectsGetObjectHistoryHandler() objects.GetObjectHistoryHandler {
+	return objects.GetObjectHistoryHandlerFunc(func(params objects.GetObjectHistoryParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.ReadObjectAction,
+				Resource: permissions.ObjectArn(params.Repository, params.Path),
+			},
+		})
+		if err != nil {
+			return objects.NewGetObjectHistoryUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("get_object_history")
+
+		after, amount := getPaginationParams(params.After, params.Amount)
+		res, hasMore, err := deps.Cataloger.ListEntryHistory(c.Context(), params.Repository, params.Ref, params.Path, after, amount)
+		switch {
+		case errors.Is(err, catalog.ErrInvalidValue), errors.Is(err, catalog.ErrInvalidReference):
+			return objects.NewGetObjectHistoryBadRequest().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, db.ErrNotFound):
+			return objects.NewGetObjectHistoryNotFound().WithPayload(responseErrorFrom(err))
+		case err != nil:
+			return objects.NewGetObjectHistoryDefault(http.StatusInternalServerError).
+				WithPayload(responseError("error while listing object history: %s", err))
+		}
+
+		versions := make([]*models.ObjectVersion, len(res))
+		for i, version := range res {
+			versions[i] = transformEntryVersion(version)
+		}
+		returnValue := objects.NewGetObjectHistoryOK().WithPayload(&objects.GetObjectHistoryOKBody{
+			Pagination: &models.Pagination{
+				HasMore:    swag.Bool(hasMore),
+				Results:    swag.Int64(int64(len(versions))),
+				MaxPerPage: swag.Int64(MaxResultsPerPage),
+			},
+			Results: versions,
+		})
+		if hasMore && len(res) > 0 {
+			returnValue.Payload.Pagination.NextOffset = res[len(res)-1].Reference
+		}
+		return returnValue
+	})
+}
+
 func (c *Controller) ObjectsUploadObjectHandler() objects.UploadObjectHandler {
 	return objects.UploadObjectHandlerFunc(func(params objects.UploadObjectParams, user *models.User) middleware.Responder {
 		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
@@ -1109,6 +1625,9 @@ func (c *Controller) ObjectsUploadObjectHandler() objects.UploadObjectHandler {
 		if errors.Is(err, db.ErrNotFound) {
 			return objects.NewUploadObjectNotFound().WithPayload(responseErrorFrom(err))
 		}
+		if errors.Is(err, catalog.ErrBranchProtected) {
+			return objects.NewUploadObjectDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
+		}
 		if err != nil {
 			return objects.NewUploadObjectDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
 		}
@@ -1140,6 +1659,9 @@ func (c *Controller) ObjectsDeleteObjectHandler() objects.DeleteObjectHandler {
 		if errors.Is(err, db.ErrNotFound) {
 			return objects.NewDeleteObjectNotFound().WithPayload(responseError("resource not found"))
 		}
+		if errors.Is(err, catalog.ErrBranchProtected) {
+			return objects.NewDeleteObjectDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
+		}
 		if err != nil {
 			return objects.NewDeleteObjectDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
 		}
@@ -1148,6 +1670,75 @@ func (c *Controller) ObjectsDeleteObjectHandler() objects.DeleteObjectHandler {
 	})
 }
 
+func (c *Controller) ObjectsMoveObjectsHandler() objects.MoveObjectsHandler {
+	return objects.MoveObjectsHandlerFunc(func(params objects.MoveObjectsParams, user *models.User) middleware.Responder {
+		source := swag.StringValue(params.Move.Source)
+		destination := swag.StringValue(params.Move.Destination)
+		deps, err := c.setupRequest(user, params.HTTPRequest, movePermissions(params.Repository, source, destination))
+		if err != nil {
+			return objects.NewMoveObjectsUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		if params.Move.Prefix {
+			// every moved entry and the path it is moved to must be authorized, not just the prefixes
+			var after string
+			hasMore := true
+			for hasMore {
+				var entries []*catalog.Entry
+				entries, hasMore, err = deps.Cataloger.ListEntries(c.Context(), params.Repository, params.Branch, source, after, "", -1)
+				if errors.Is(err, db.ErrNotFound) {
+					return objects.NewMoveObjectsNotFound().WithPayload(responseErrorFrom(err))
+				}
+				if err != nil {
+					return objects.NewMoveObjectsDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+				}
+				perms := make([]permissions.Permission, 0, 3*len(entries))
+				for _, entry := range entries {
+					perms = append(perms, movePermissions(params.Repository, entry.Path, destination+strings.TrimPrefix(entry.Path, source))...)
+					after = entry.Path
+				}
+				if len(perms) == 0 {
+					continue
+				}
+				if err := authorize(deps.Auth, user, perms); err != nil {
+					return objects.NewMoveObjectsUnauthorized().WithPayload(responseErrorFrom(err))
+				}
+			}
+		}
+		deps.LogAction("move_objects")
+		moved, err := deps.Cataloger.MoveEntries(c.Context(), params.Repository, params.Branch, source, destination, params.Move.Prefix)
+		switch {
+		case err == nil:
+			return objects.NewMoveObjectsOK().WithPayload(&objects.MoveObjectsOKBody{Moved: int64(moved)})
+		case errors.Is(err, catalog.ErrBranchProtected):
+			return objects.NewMoveObjectsDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
+		case errors.Is(err, catalog.ErrInvalidValue):
+			return objects.NewMoveObjectsBadRequest().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, db.ErrNotFound):
+			return objects.NewMoveObjectsNotFound().WithPayload(responseErrorFrom(err))
+		default:
+			return objects.NewMoveObjectsDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+	})
+}
+
+// movePermissions returns the permissions required to move source to destination
+func movePermissions(repository, source, destination string) []permissions.Permission {
+	return []permissions.Permission{
+		{
+			Action:   permissions.ReadObjectAction,
+			Resource: permissions.ObjectArn(repository, source),
+		},
+		{
+			Action:   permissions.DeleteObjectAction,
+			Resource: permissions.ObjectArn(repository, source),
+		},
+		{
+			Action:   permissions.WriteObjectAction,
+			Resource: permissions.ObjectArn(repository, destination),
+		},
+	}
+}
+
 func (c *Controller) RevertBranchHandler() branches.RevertBranchHandler {
 	return branches.RevertBranchHandlerFunc(func(params branches.RevertBranchParams, user *models.User) middleware.Responder {
 		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
@@ -1165,7 +1756,17 @@ func (c *Controller) RevertBranchHandler() branches.RevertBranchHandler {
 		ctx := c.Context()
 		switch swag.StringValue(params.Revert.Type) {
 		case models.RevertCreationTypeCommit:
-			err = cataloger.RollbackCommit(ctx, params.Repository, params.Revert.Commit)
+			if _, parseErr := catalog.ParseRef(params.Revert.Commit); parseErr != nil {
+				return branches.NewRevertBranchDefault(http.StatusBadRequest).WithPayload(responseErrorFrom(parseErr))
+			}
+			// the catalog verifies the commit is on the authorized branch
+			err = cataloger.RollbackCommit(ctx, params.Repository, params.Branch, params.Revert.Commit)
+		case models.RevertCreationTypeInverseCommit:
+			userModel, authErr := deps.Auth.GetUser(user.ID)
+			if authErr != nil {
+				return branches.NewRevertBranchUnauthorized().WithPayload(responseErrorFrom(authErr))
+			}
+			_, err = cataloger.RevertCommit(ctx, params.Repository, params.Branch, params.Revert.Commit, userModel.DisplayName)
 		case models.RevertCreationTypeCommonPrefix:
 			err = cataloger.ResetEntries(ctx, params.Repository, params.Branch, params.Revert.Path)
 		case models.RevertCreationTypeReset:
@@ -1179,6 +1780,15 @@ func (c *Controller) RevertBranchHandler() branches.RevertBranchHandler {
 		if errors.Is(err, db.ErrNotFound) {
 			return branches.NewRevertBranchNotFound().WithPayload(responseErrorFrom(err))
 		}
+		if errors.Is(err, catalog.ErrBranchProtected) {
+			return branches.NewRevertBranchDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
+		}
+		if errors.Is(err, catalog.ErrCommitNotInLineage) || errors.Is(err, catalog.ErrCommitNotOnBranch) {
+			return branches.NewRevertBranchDefault(http.StatusBadRequest).WithPayload(responseErrorFrom(err))
+		}
+		if errors.Is(err, catalog.ErrRollbackWithActiveBranch) || errors.Is(err, catalog.ErrConflictFound) {
+			return branches.NewRevertBranchDefault(http.StatusConflict).WithPayload(responseErrorFrom(err))
+		}
 		if err != nil {
 			return branches.NewRevertBranchDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
 		}
@@ -1187,6 +1797,60 @@ func (c *Controller) RevertBranchHandler() branches.RevertBranchHandler {
 	})
 }
 
+func (c *Controller) CherryPickHandler() branches.CherryPickHandler {
+	return branches.CherryPickHandlerFunc(func(params branches.CherryPickParams, user *models.User) middleware.Responder {
+		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
+			{
+				Action:   permissions.CreateCommitAction,
+				Resource: permissions.BranchArn(params.Repository, params.Branch),
+			},
+		})
+		if err != nil {
+			return branches.NewCherryPickUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		deps.LogAction("cherry_pick")
+		userModel, err := deps.Auth.GetUser(user.ID)
+		if err != nil {
+			return branches.NewCherryPickUnauthorized().WithPayload(responseErrorFrom(err))
+		}
+		res, err := deps.Cataloger.CherryPick(c.Context(),
+			params.Repository, swag.StringValue(params.CherryPick.Ref), params.Branch,
+			userModel.DisplayName)
+
+		// convert cherry-pick differences into merge results
+		var mergeResults []*models.MergeResult
+		if res != nil {
+			mergeResults = make([]*models.MergeResult, len(res.Differences))
+			for i, d := range res.Differences {
+				mergeResults[i] = transformDifferenceToMergeResult(d)
+			}
+		}
+
+		switch {
+		case err == nil:
+			return branches.NewCherryPickOK().WithPayload(&branches.CherryPickOKBody{
+				Reference: res.Reference,
+				Results:   mergeResults,
+			})
+		case errors.Is(err, catalog.ErrConflictFound):
+			pl := new(branches.CherryPickConflictBody)
+			pl.Results = mergeResults
+			return branches.NewCherryPickConflict().WithPayload(pl)
+		case errors.Is(err, catalog.ErrBranchProtected):
+			return branches.NewCherryPickDefault(http.StatusForbidden).WithPayload(responseErrorFrom(err))
+		case errors.Is(err, catalog.ErrInvalidValue), errors.Is(err, catalog.ErrInvalidReference),
+			errors.Is(err, catalog.ErrOperationNotPermitted):
+			return branches.NewCherryPickBadRequest().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, db.ErrNotFound):
+			return branches.NewCherryPickNotFound().WithPayload(responseErrorFrom(err))
+		case errors.Is(err, catalog.ErrNoDifferenceWasFound):
+			return branches.NewCherryPickDefault(http.StatusInternalServerError).WithPayload(responseError("no difference was found"))
+		default:
+			return branches.NewCherryPickDefault(http.StatusInternalServerError).WithPayload(responseErrorFrom(err))
+		}
+	})
+}
+
 func (c *Controller) CreateUserHandler() authop.CreateUserHandler {
 	return authop.CreateUserHandlerFunc(func(params authop.CreateUserParams, user *models.User) middleware.Responder {
 		deps, err := c.setupRequest(user, params.HTTPRequest, []permissions.Permission{
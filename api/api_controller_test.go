@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"net/http"
 	"strconv"
 	"strings"
 	"testing"
@@ -20,10 +21,12 @@ import (
 	"github.com/treeverse/lakefs/api/gen/client/repositories"
 	"github.com/treeverse/lakefs/api/gen/client/retention"
 	"github.com/treeverse/lakefs/api/gen/models"
+	authmodel "github.com/treeverse/lakefs/auth/model"
 	"github.com/treeverse/lakefs/block"
 	"github.com/treeverse/lakefs/catalog"
 	"github.com/treeverse/lakefs/db"
 	"github.com/treeverse/lakefs/httputil"
+	"github.com/treeverse/lakefs/permissions"
 	"github.com/treeverse/lakefs/testutil"
 	"github.com/treeverse/lakefs/upload"
 )
@@ -685,6 +688,205 @@ func TestHandler_DeleteBranchHandler(t *testing.T) {
 	})
 }
 
+func TestHandler_RenameBranchHandler(t *testing.T) {
+	handler, deps := getHandler(t)
+
+	// create user
+	creds := createDefaultAdminUser(deps.auth, t)
+	bauth := httptransport.BasicAuth(creds.AccessKeyID, creds.AccessSecretKey)
+
+	// setup client
+	clt := client.Default
+	clt.SetTransport(&handlerTransport{Handler: handler})
+
+	ctx := context.Background()
+	testutil.Must(t, deps.cataloger.CreateRepository(ctx, "my-new-repo", "s3://foo1", "master"))
+	_, err := deps.cataloger.CreateBranch(ctx, "my-new-repo", "branch1", "master")
+	testutil.Must(t, err)
+
+	t.Run("rename branch success", func(t *testing.T) {
+		_, err := clt.Branches.RenameBranch(&branches.RenameBranchParams{
+			Branch:     "branch1",
+			Repository: "my-new-repo",
+			Rename:     &models.BranchRename{Name: swag.String("branch2")},
+		}, bauth)
+		if err != nil {
+			t.Fatalf("unexpected error renaming branch: %s", err)
+		}
+		_, err = deps.cataloger.GetBranchReference(ctx, "my-new-repo", "branch2")
+		if err != nil {
+			t.Fatalf("expected renamed branch, got error: %s", err)
+		}
+	})
+
+	t.Run("rename branch to existing branch", func(t *testing.T) {
+		_, err := clt.Branches.RenameBranch(&branches.RenameBranchParams{
+			Branch:     "branch2",
+			Repository: "my-new-repo",
+			Rename:     &models.BranchRename{Name: swag.String("master")},
+		}, bauth)
+		if _, ok := err.(*branches.RenameBranchConflict); !ok {
+			t.Fatalf("expected conflict renaming to existing branch, got %v", err)
+		}
+	})
+
+	t.Run("rename branch doesnt exist", func(t *testing.T) {
+		_, err := clt.Branches.RenameBranch(&branches.RenameBranchParams{
+			Branch:     "branch1",
+			Repository: "my-new-repo",
+			Rename:     &models.BranchRename{Name: swag.String("branch3")},
+		}, bauth)
+		if _, ok := err.(*branches.RenameBranchNotFound); !ok {
+			t.Fatalf("expected not found renaming missing branch, got %v", err)
+		}
+	})
+}
+
+func TestHandler_RevertBranchHandler(t *testing.T) {
+	handler, deps := getHandler(t)
+
+	// create user
+	creds := createDefaultAdminUser(deps.auth, t)
+	bauth := httptransport.BasicAuth(creds.AccessKeyID, creds.AccessSecretKey)
+
+	// setup client
+	clt := client.Default
+	clt.SetTransport(&handlerTransport{Handler: handler})
+
+	ctx := context.Background()
+	testutil.Must(t, deps.cataloger.CreateRepository(ctx, "my-new-repo", "s3://foo1", "master"))
+	commitFile := func(branch, path string) string {
+		testutil.MustDo(t, "create entry "+path, deps.cataloger.CreateEntry(ctx, "my-new-repo", branch,
+			catalog.Entry{Path: path, PhysicalAddress: path + "addr", CreationDate: time.Now(), Size: 1, Checksum: path},
+			catalog.CreateEntryParams{},
+		))
+		commitLog, err := deps.cataloger.Commit(ctx, "my-new-repo", branch, "add "+path, DefaultUserID, nil)
+		testutil.MustDo(t, "commit "+path, err)
+		return commitLog.Reference
+	}
+	commitFile("master", "file1")
+	_, err := deps.cataloger.CreateBranch(ctx, "my-new-repo", "prod", "master")
+	testutil.Must(t, err)
+	prodReference := commitFile("prod", "file2")
+	commitFile("prod", "file3")
+
+	t.Run("revert to commit of another branch", func(t *testing.T) {
+		// a reference naming the authorized branch, with the commit id of prod
+		prodRef, err := catalog.ParseRef(prodReference)
+		testutil.Must(t, err)
+		_, err = clt.Branches.RevertBranch(&branches.RevertBranchParams{
+			Branch:     "master",
+			Repository: "my-new-repo",
+			Revert: &models.RevertCreation{
+				Type:   swag.String(models.RevertCreationTypeCommit),
+				Commit: catalog.MakeReference("master", prodRef.CommitID),
+			},
+		}, bauth)
+		if resp, ok := err.(*branches.RevertBranchDefault); !ok || resp.Code() != http.StatusBadRequest {
+			t.Fatalf("expected bad request reverting to a commit of another branch, got %v", err)
+		}
+		if _, err := deps.cataloger.GetEntry(ctx, "my-new-repo", "prod", "file3", catalog.GetEntryParams{}); err != nil {
+			t.Fatalf("prod rolled back by revert of master: %s", err)
+		}
+	})
+
+	t.Run("revert to commit made before rename", func(t *testing.T) {
+		testutil.Must(t, deps.cataloger.RenameBranch(ctx, "my-new-repo", "prod", "production"))
+		_, err := clt.Branches.RevertBranch(&branches.RevertBranchParams{
+			Branch:     "production",
+			Repository: "my-new-repo",
+			Revert: &models.RevertCreation{
+				Type:   swag.String(models.RevertCreationTypeCommit),
+				Commit: prodReference,
+			},
+		}, bauth)
+		if err != nil {
+			t.Fatalf("unexpected error reverting renamed branch: %s", err)
+		}
+		_, err = deps.cataloger.GetEntry(ctx, "my-new-repo", "production", "file3", catalog.GetEntryParams{})
+		if !errors.Is(err, db.ErrNotFound) {
+			t.Fatalf("expected file3 removed by revert, got %v", err)
+		}
+	})
+}
+
+func TestHandler_ObjectsMoveObjectsHandler(t *testing.T) {
+	handler, deps := getHandler(t)
+
+	// create a user allowed on every object of the repository, except deleting under data/protected/
+	createDefaultAdminUser(deps.auth, t)
+	testutil.Must(t, deps.auth.CreateUser(&authmodel.User{CreatedAt: time.Now(), DisplayName: "mover"}))
+	testutil.Must(t, deps.auth.WritePolicy(&authmodel.Policy{
+		CreatedAt:   time.Now(),
+		DisplayName: "MoveUnprotected",
+		Statement: authmodel.Statements{
+			{
+				Action:   []string{"fs:*"},
+				Resource: permissions.ObjectArn("my-new-repo", "*"),
+				Effect:   authmodel.StatementEffectAllow,
+			},
+			{
+				Action:   []string{permissions.DeleteObjectAction},
+				Resource: permissions.ObjectArn("my-new-repo", "data/protected/*"),
+				Effect:   authmodel.StatementEffectDeny,
+			},
+		},
+	}))
+	testutil.Must(t, deps.auth.AttachPolicyToUser("MoveUnprotected", "mover"))
+	creds, err := deps.auth.CreateCredentials("mover")
+	testutil.Must(t, err)
+	bauth := httptransport.BasicAuth(creds.AccessKeyID, creds.AccessSecretKey)
+
+	// setup client
+	clt := client.Default
+	clt.SetTransport(&handlerTransport{Handler: handler})
+
+	ctx := context.Background()
+	testutil.Must(t, deps.cataloger.CreateRepository(ctx, "my-new-repo", "s3://foo1", "master"))
+	for _, path := range []string{"data/file1", "data/protected/file2"} {
+		testutil.MustDo(t, "create entry "+path, deps.cataloger.CreateEntry(ctx, "my-new-repo", "master",
+			catalog.Entry{Path: path, PhysicalAddress: path + "addr", CreationDate: time.Now(), Size: 1, Checksum: path},
+			catalog.CreateEntryParams{},
+		))
+	}
+
+	t.Run("move prefix with denied entry", func(t *testing.T) {
+		_, err := clt.Objects.MoveObjects(&objects.MoveObjectsParams{
+			Repository: "my-new-repo",
+			Branch:     "master",
+			Move: &models.ObjectMove{
+				Source:      swag.String("data/"),
+				Destination: swag.String("moved/"),
+				Prefix:      true,
+			},
+		}, bauth)
+		if _, ok := err.(*objects.MoveObjectsUnauthorized); !ok {
+			t.Fatalf("expected unauthorized moving a prefix with a protected entry, got %v", err)
+		}
+		if _, err := deps.cataloger.GetEntry(ctx, "my-new-repo", "master", "data/protected/file2", catalog.GetEntryParams{}); err != nil {
+			t.Fatalf("protected entry moved: %s", err)
+		}
+	})
+
+	t.Run("move prefix", func(t *testing.T) {
+		resp, err := clt.Objects.MoveObjects(&objects.MoveObjectsParams{
+			Repository: "my-new-repo",
+			Branch:     "master",
+			Move: &models.ObjectMove{
+				Source:      swag.String("data/file"),
+				Destination: swag.String("moved/file"),
+				Prefix:      true,
+			},
+		}, bauth)
+		if err != nil {
+			t.Fatalf("unexpected error moving prefix: %s", err)
+		}
+		if resp.Payload.Moved != 1 {
+			t.Fatalf("expected 1 entry moved, got %d", resp.Payload.Moved)
+		}
+	})
+}
+
 func TestHandler_ObjectsStatObjectHandler(t *testing.T) {
 	handler, deps := getHandler(t)
 
@@ -16,10 +16,12 @@ import (
 	"github.com/treeverse/lakefs/api/gen/client/auth"
 	"github.com/treeverse/lakefs/api/gen/client/branches"
 	"github.com/treeverse/lakefs/api/gen/client/commits"
+	"github.com/treeverse/lakefs/api/gen/client/hooks"
 	"github.com/treeverse/lakefs/api/gen/client/objects"
 	"github.com/treeverse/lakefs/api/gen/client/refs"
 	"github.com/treeverse/lakefs/api/gen/client/repositories"
 	"github.com/treeverse/lakefs/api/gen/client/retention"
+	"github.com/treeverse/lakefs/api/gen/client/tags"
 	"github.com/treeverse/lakefs/api/gen/models"
 	"github.com/treeverse/lakefs/catalog"
 )
@@ -58,28 +60,46 @@ type RepositoryClient interface {
 	ListRepositories(ctx context.Context, after string, amount int) ([]*models.Repository, *models.Pagination, error)
 	GetRepository(ctx context.Context, repository string) (*models.Repository, error)
 	CreateRepository(ctx context.Context, repository *models.RepositoryCreation) error
+	UpdateRepository(ctx context.Context, repository string, update *models.RepositoryUpdate) (*models.Repository, error)
 	DeleteRepository(ctx context.Context, repository string) error
 
 	ListBranches(ctx context.Context, repository string, from string, amount int) ([]string, *models.Pagination, error)
 	GetBranch(ctx context.Context, repository, branchId string) (string, error)
 	CreateBranch(ctx context.Context, repository string, branch *models.BranchCreation) (string, error)
 	DeleteBranch(ctx context.Context, repository, branchId string) error
+	RenameBranch(ctx context.Context, repository, branchId, name string) error
 	RevertBranch(ctx context.Context, repository, branchId string, revertProps *models.RevertCreation) error
 
+	ListBranchProtectionRules(ctx context.Context, repository string) ([]*models.BranchProtectionRule, error)
+	CreateBranchProtectionRule(ctx context.Context, repository, pattern string) error
+	DeleteBranchProtectionRule(ctx context.Context, repository, pattern string) error
+
+	ListHooks(ctx context.Context, repository string) ([]*models.Hook, error)
+	CreateHook(ctx context.Context, repository string, hook *models.HookCreation) (*models.Hook, error)
+	DeleteHook(ctx context.Context, repository, hookName string) error
+
+	ListTags(ctx context.Context, repository string, after string, amount int) ([]*models.Tag, *models.Pagination, error)
+	GetTag(ctx context.Context, repository, tagId string) (*models.Tag, error)
+	CreateTag(ctx context.Context, repository string, tag *models.TagCreation) (*models.Tag, error)
+	DeleteTag(ctx context.Context, repository, tagId string) error
+
 	Commit(ctx context.Context, repository, branchId, message string, metadata map[string]string) (*models.Commit, error)
 	GetCommit(ctx context.Context, repository, commitId string) (*models.Commit, error)
-	GetCommitLog(ctx context.Context, repository, branchId, after string, amount int) ([]*models.Commit, *models.Pagination, error)
+	GetCommitLog(ctx context.Context, repository, branchId, after string, amount int, filter catalog.ListCommitsParams) ([]*models.Commit, *models.Pagination, error)
 
 	StatObject(ctx context.Context, repository, ref, path string) (*models.ObjectStats, error)
 	ListObjects(ctx context.Context, repository, ref, prefix, from string, amount int) ([]*models.ObjectStats, *models.Pagination, error)
+	GetObjectHistory(ctx context.Context, repository, ref, path, after string, amount int) ([]*models.ObjectVersion, *models.Pagination, error)
 	GetObject(ctx context.Context, repository, ref, path string, w io.Writer) (*objects.GetObjectOK, error)
 	UploadObject(ctx context.Context, repository, branchId, path string, r io.Reader) (*models.ObjectStats, error)
 	DeleteObject(ctx context.Context, repository, branchId, path string) error
+	MoveObjects(ctx context.Context, repository, branchId string, move *models.ObjectMove) (int, error)
 
-	DiffRefs(ctx context.Context, repository, leftRef, rightRef string) ([]*models.Diff, error)
-	Merge(ctx context.Context, repository, leftRef, rightRef string) ([]*models.MergeResult, error)
+	DiffRefs(ctx context.Context, repository, leftRef, rightRef, prefix, after string, amount int, types []string) ([]*models.Diff, *models.DiffSummary, *models.Pagination, error)
+	Merge(ctx context.Context, repository, leftRef, rightRef string, merge *models.Merge) ([]*models.MergeResult, []*models.MergeConflict, error)
+	CherryPick(ctx context.Context, repository, branchId, ref string) (string, []*models.MergeResult, error)
 
-	DiffBranch(ctx context.Context, repository, branch string) ([]*models.Diff, error)
+	DiffBranch(ctx context.Context, repository, branch, prefix, after string, amount int, types []string) ([]*models.Diff, *models.DiffSummary, *models.Pagination, error)
 
 	GetRetentionPolicy(ctx context.Context, repository string) (*models.RetentionPolicyWithCreationDate, error)
 	UpdateRetentionPolicy(ctx context.Context, repository string, policy *models.RetentionPolicy) error
@@ -413,6 +433,18 @@ func (c *client) GetRepository(ctx context.Context, repository string) (*models.
 	return resp.GetPayload(), nil
 }
 
+func (c *client) UpdateRepository(ctx context.Context, repository string, update *models.RepositoryUpdate) (*models.Repository, error) {
+	resp, err := c.remote.Repositories.UpdateRepository(&repositories.UpdateRepositoryParams{
+		Repository: repository,
+		Update:     update,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
 func (c *client) ListBranches(ctx context.Context, repository string, after string, amount int) ([]string, *models.Pagination, error) {
 	resp, err := c.remote.Branches.ListBranches(&branches.ListBranchesParams{
 		After:      swag.String(after),
@@ -475,6 +507,16 @@ func (c *client) DeleteBranch(ctx context.Context, repository, branchId string)
 	return err
 }
 
+func (c *client) RenameBranch(ctx context.Context, repository, branchId, name string) error {
+	_, err := c.remote.Branches.RenameBranch(&branches.RenameBranchParams{
+		Branch:     branchId,
+		Rename:     &models.BranchRename{Name: swag.String(name)},
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	return err
+}
+
 func (c *client) RevertBranch(ctx context.Context, repository, branchId string, revertProps *models.RevertCreation) error {
 	_, err := c.remote.Branches.RevertBranch(&branches.RevertBranchParams{
 		Branch:     branchId,
@@ -485,6 +527,115 @@ func (c *client) RevertBranch(ctx context.Context, repository, branchId string,
 	return err
 }
 
+func (c *client) ListBranchProtectionRules(ctx context.Context, repository string) ([]*models.BranchProtectionRule, error) {
+	resp, err := c.remote.Branches.ListBranchProtectionRules(&branches.ListBranchProtectionRulesParams{
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload().Results, nil
+}
+
+func (c *client) CreateBranchProtectionRule(ctx context.Context, repository, pattern string) error {
+	_, err := c.remote.Branches.CreateBranchProtectionRule(&branches.CreateBranchProtectionRuleParams{
+		Rule: &models.BranchProtectionRuleCreation{
+			Pattern: swag.String(pattern),
+		},
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	return err
+}
+
+func (c *client) DeleteBranchProtectionRule(ctx context.Context, repository, pattern string) error {
+	_, err := c.remote.Branches.DeleteBranchProtectionRule(&branches.DeleteBranchProtectionRuleParams{
+		Pattern:    pattern,
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	return err
+}
+
+func (c *client) ListHooks(ctx context.Context, repository string) ([]*models.Hook, error) {
+	resp, err := c.remote.Hooks.ListHooks(&hooks.ListHooksParams{
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload().Results, nil
+}
+
+func (c *client) CreateHook(ctx context.Context, repository string, hook *models.HookCreation) (*models.Hook, error) {
+	resp, err := c.remote.Hooks.CreateHook(&hooks.CreateHookParams{
+		Hook:       hook,
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+func (c *client) DeleteHook(ctx context.Context, repository, hookName string) error {
+	_, err := c.remote.Hooks.DeleteHook(&hooks.DeleteHookParams{
+		Hook:       hookName,
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	return err
+}
+
+func (c *client) ListTags(ctx context.Context, repository string, after string, amount int) ([]*models.Tag, *models.Pagination, error) {
+	resp, err := c.remote.Tags.ListTags(&tags.ListTagsParams{
+		After:      swag.String(after),
+		Amount:     swag.Int64(int64(amount)),
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.GetPayload().Results, resp.GetPayload().Pagination, nil
+}
+
+func (c *client) GetTag(ctx context.Context, repository, tagId string) (*models.Tag, error) {
+	resp, err := c.remote.Tags.GetTag(&tags.GetTagParams{
+		Tag:        tagId,
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+func (c *client) CreateTag(ctx context.Context, repository string, tag *models.TagCreation) (*models.Tag, error) {
+	resp, err := c.remote.Tags.CreateTag(&tags.CreateTagParams{
+		Tag:        tag,
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPayload(), nil
+}
+
+func (c *client) DeleteTag(ctx context.Context, repository, tagId string) error {
+	_, err := c.remote.Tags.DeleteTag(&tags.DeleteTagParams{
+		Tag:        tagId,
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	return err
+}
+
 func (c *client) Commit(ctx context.Context, repository, branchId, message string, metadata map[string]string) (*models.Commit, error) {
 	commit, err := c.remote.Commits.Commit(&commits.CommitParams{
 		Branch: branchId,
@@ -513,62 +664,104 @@ func (c *client) GetCommit(ctx context.Context, repository, commitId string) (*m
 	return commit.GetPayload(), nil
 }
 
-func (c *client) GetCommitLog(ctx context.Context, repository, branchId, after string, amount int) ([]*models.Commit, *models.Pagination, error) {
-	resp, err := c.remote.Commits.GetBranchCommitLog(&commits.GetBranchCommitLogParams{
+func (c *client) GetCommitLog(ctx context.Context, repository, branchId, after string, amount int, filter catalog.ListCommitsParams) ([]*models.Commit, *models.Pagination, error) {
+	params := &commits.GetBranchCommitLogParams{
 		Amount:     swag.Int64(int64(amount)),
 		After:      swag.String(after),
 		Branch:     branchId,
 		Repository: repository,
 		Context:    ctx,
-	}, c.auth)
+	}
+	if filter.Prefix != "" {
+		params.Prefix = swag.String(filter.Prefix)
+	}
+	if filter.Committer != "" {
+		params.Committer = swag.String(filter.Committer)
+	}
+	if !filter.Since.IsZero() {
+		params.Since = swag.Int64(filter.Since.Unix())
+	}
+	if !filter.Until.IsZero() {
+		params.Until = swag.Int64(filter.Until.Unix())
+	}
+	for k, v := range filter.Metadata {
+		params.Metadata = append(params.Metadata, k+"="+v)
+	}
+	resp, err := c.remote.Commits.GetBranchCommitLog(params, c.auth)
 	if err != nil {
 		return nil, nil, err
 	}
 	return resp.GetPayload().Results, resp.GetPayload().Pagination, nil
 }
 
-func (c *client) DiffRefs(ctx context.Context, repository, leftRef, rightRef string) ([]*models.Diff, error) {
+func (c *client) DiffRefs(ctx context.Context, repository, leftRef, rightRef, prefix, after string, amount int, types []string) ([]*models.Diff, *models.DiffSummary, *models.Pagination, error) {
 	diff, err := c.remote.Refs.DiffRefs(&refs.DiffRefsParams{
 		LeftRef:    leftRef,
 		RightRef:   rightRef,
+		Prefix:     swag.String(prefix),
+		After:      swag.String(after),
+		Amount:     swag.Int64(int64(amount)),
+		Type:       types,
 		Repository: repository,
 		Context:    ctx,
 	}, c.auth)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-	return diff.GetPayload().Results, nil
+	return diff.GetPayload().Results, diff.GetPayload().Summary, diff.GetPayload().Pagination, nil
 }
 
-func (c *client) Merge(ctx context.Context, repository, leftRef, rightRef string) ([]*models.MergeResult, error) {
+func (c *client) Merge(ctx context.Context, repository, leftRef, rightRef string, merge *models.Merge) ([]*models.MergeResult, []*models.MergeConflict, error) {
 	statusOK, err := c.remote.Refs.MergeIntoBranch(&refs.MergeIntoBranchParams{
 		DestinationRef: leftRef,
 		SourceRef:      rightRef,
+		Merge:          merge,
 		Repository:     repository,
 		Context:        ctx,
 	}, c.auth)
 
 	if err == nil {
-		return statusOK.Payload.Results, nil
+		return statusOK.Payload.Results, nil, nil
 	}
 	conflict, ok := err.(*refs.MergeIntoBranchConflict)
 	if ok {
-		return conflict.Payload.Results, catalog.ErrConflictFound
+		return conflict.Payload.Results, conflict.Payload.Conflicts, catalog.ErrConflictFound
 	} else {
-		return nil, err
+		return nil, nil, err
+	}
+}
+
+func (c *client) CherryPick(ctx context.Context, repository, branchId, ref string) (string, []*models.MergeResult, error) {
+	statusOK, err := c.remote.Branches.CherryPick(&branches.CherryPickParams{
+		Branch:     branchId,
+		CherryPick: &models.CherryPick{Ref: swag.String(ref)},
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	if err == nil {
+		return statusOK.Payload.Reference, statusOK.Payload.Results, nil
 	}
+	conflict, ok := err.(*branches.CherryPickConflict)
+	if ok {
+		return "", conflict.Payload.Results, catalog.ErrConflictFound
+	}
+	return "", nil, err
 }
 
-func (c *client) DiffBranch(ctx context.Context, repoID, branch string) ([]*models.Diff, error) {
+func (c *client) DiffBranch(ctx context.Context, repoID, branch, prefix, after string, amount int, types []string) ([]*models.Diff, *models.DiffSummary, *models.Pagination, error) {
 	diff, err := c.remote.Branches.DiffBranch(&branches.DiffBranchParams{
 		Branch:     branch,
+		Prefix:     swag.String(prefix),
+		After:      swag.String(after),
+		Amount:     swag.Int64(int64(amount)),
+		Type:       types,
 		Repository: repoID,
 		Context:    ctx,
 	}, c.auth)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-	return diff.GetPayload().Results, nil
+	return diff.GetPayload().Results, diff.GetPayload().Summary, diff.GetPayload().Pagination, nil
 }
 func (c *client) Symlink(ctx context.Context, repoId, branch, path string) (string, error) {
 	resp, err := c.remote.Metadata.CreateSymlink(&metadata.CreateSymlinkParams{
@@ -630,6 +823,21 @@ func (c *client) ListObjects(ctx context.Context, repoID, ref, prefix, after str
 	return resp.GetPayload().Results, resp.GetPayload().Pagination, nil
 }
 
+func (c *client) GetObjectHistory(ctx context.Context, repoID, ref, path, after string, amount int) ([]*models.ObjectVersion, *models.Pagination, error) {
+	resp, err := c.remote.Objects.GetObjectHistory(&objects.GetObjectHistoryParams{
+		After:      swag.String(after),
+		Amount:     swag.Int64(int64(amount)),
+		Ref:        ref,
+		Repository: repoID,
+		Path:       path,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.GetPayload().Results, resp.GetPayload().Pagination, nil
+}
+
 func (c *client) GetObject(ctx context.Context, repoID, ref, path string, writer io.Writer) (*objects.GetObjectOK, error) {
 	params := &objects.GetObjectParams{
 		Ref:        ref,
@@ -668,6 +876,19 @@ func (c *client) DeleteObject(ctx context.Context, repository, branchId, path st
 	return err
 }
 
+func (c *client) MoveObjects(ctx context.Context, repository, branchId string, move *models.ObjectMove) (int, error) {
+	resp, err := c.remote.Objects.MoveObjects(&objects.MoveObjectsParams{
+		Branch:     branchId,
+		Move:       move,
+		Repository: repository,
+		Context:    ctx,
+	}, c.auth)
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.GetPayload().Moved), nil
+}
+
 func NewClient(endpointURL, accessKeyId, secretAccessKey string) (Client, error) {
 	parsedURL, err := url.Parse(endpointURL)
 	if err != nil {
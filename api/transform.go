@@ -2,7 +2,9 @@ package api
 
 import (
 	"strings"
+	"time"
 
+	"github.com/go-openapi/swag"
 	"github.com/treeverse/lakefs/api/gen/models"
 	"github.com/treeverse/lakefs/catalog"
 )
@@ -30,9 +32,39 @@ func transformDifferenceToMergeResult(difference catalog.Difference) *models.Mer
 	return mr
 }
 
+func transformMergeSummary(summary map[catalog.DifferenceType]int) *models.MergeSummary {
+	return &models.MergeSummary{
+		Added:    int64(summary[catalog.DifferenceTypeAdded]),
+		Removed:  int64(summary[catalog.DifferenceTypeRemoved]),
+		Changed:  int64(summary[catalog.DifferenceTypeChanged]),
+		Conflict: int64(summary[catalog.DifferenceTypeConflict]),
+	}
+}
+
+func transformMergeConflict(conflict catalog.MergeConflict) *models.MergeConflict {
+	return &models.MergeConflict{
+		Path:        conflict.Path,
+		Source:      transformMergeConflictEntry(conflict.Source),
+		Destination: transformMergeConflictEntry(conflict.Destination),
+	}
+}
+
+func transformMergeConflictEntry(entry *catalog.Entry) *models.MergeConflictEntry {
+	if entry == nil {
+		return nil
+	}
+	return &models.MergeConflictEntry{
+		Checksum:        entry.Checksum,
+		PhysicalAddress: entry.PhysicalAddress,
+		SizeBytes:       entry.Size,
+	}
+}
+
 func transformDifferenceToDiff(difference catalog.Difference) *models.Diff {
 	d := &models.Diff{
-		Path: difference.Path,
+		Path:  difference.Path,
+		Left:  transformDiffEntry(difference.LeftEntry),
+		Right: transformDiffEntry(difference.RightEntry),
 	}
 	switch difference.Type {
 	case catalog.DifferenceTypeAdded:
@@ -52,3 +84,101 @@ func transformDifferenceToDiff(difference catalog.Difference) *models.Diff {
 	}
 	return d
 }
+
+func transformDiffTypeToDifferenceType(diffType string) catalog.DifferenceType {
+	switch diffType {
+	case models.DiffTypeRemoved:
+		return catalog.DifferenceTypeRemoved
+	case models.DiffTypeChanged:
+		return catalog.DifferenceTypeChanged
+	case models.DiffTypeConflict:
+		return catalog.DifferenceTypeConflict
+	default:
+		return catalog.DifferenceTypeAdded
+	}
+}
+
+func transformDiffEntry(entry *catalog.Entry) *models.DiffEntry {
+	if entry == nil {
+		return nil
+	}
+	return &models.DiffEntry{
+		Checksum:        entry.Checksum,
+		Metadata:        entry.Metadata,
+		Mtime:           entry.CreationDate.Unix(),
+		PhysicalAddress: entry.PhysicalAddress,
+		SizeBytes:       entry.Size,
+	}
+}
+
+func transformDiffSummary(summary *catalog.DiffSummary) *models.DiffSummary {
+	if summary == nil {
+		return nil
+	}
+	return &models.DiffSummary{
+		Files:        int64(summary.Files),
+		BytesAdded:   summary.BytesAdded,
+		BytesRemoved: summary.BytesRemoved,
+	}
+}
+
+func transformDiffPagination(diff *catalog.DiffResult) *models.Pagination {
+	pagination := &models.Pagination{
+		HasMore:    swag.Bool(diff.HasMore),
+		Results:    swag.Int64(int64(len(diff.Differences))),
+		MaxPerPage: swag.Int64(MaxResultsPerPage),
+	}
+	if diff.HasMore && len(diff.Differences) > 0 {
+		pagination.NextOffset = diff.Differences[len(diff.Differences)-1].Path
+	}
+	return pagination
+}
+
+func transformRepository(repo *catalog.Repository) *models.Repository {
+	return &models.Repository{
+		ID:               repo.Name,
+		StorageNamespace: repo.StorageNamespace,
+		CreationDate:     repo.CreationDate.Unix(),
+		DefaultBranch:    repo.DefaultBranch,
+		Description:      repo.Description,
+		Metadata:         repo.Metadata,
+	}
+}
+
+func transformEntryVersion(version *catalog.EntryVersion) *models.ObjectVersion {
+	return &models.ObjectVersion{
+		CommitID:        version.Reference,
+		Committer:       version.Committer,
+		Message:         version.Message,
+		CommitDate:      version.CommitDate.Unix(),
+		Checksum:        version.Checksum,
+		PhysicalAddress: version.PhysicalAddress,
+		Mtime:           version.CreationDate.Unix(),
+		SizeBytes:       version.Size,
+	}
+}
+
+func transformTag(tag *catalog.Tag) *models.Tag {
+	return &models.Tag{
+		ID:           tag.Name,
+		CommitID:     tag.Reference,
+		CreationDate: tag.CreationDate.Unix(),
+	}
+}
+
+func transformHook(hook *catalog.Hook) *models.Hook {
+	return &models.Hook{
+		Name:         hook.Name,
+		Type:         string(hook.Type),
+		URL:          hook.URL,
+		Timeout:      int64(hook.Timeout / time.Second),
+		CreationDate: hook.CreationDate.Unix(),
+	}
+}
+
+func transformBranchProtectionRule(rule *catalog.BranchProtectionRule) *models.BranchProtectionRule {
+	return &models.BranchProtectionRule{
+		Pattern:      rule.Pattern,
+		CreationDate: rule.CreationDate.Unix(),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/aws/aws-sdk-go/service/s3"
 )
@@ -48,6 +49,25 @@ type Properties struct {
 	StorageClass *string
 }
 
+// ObjectInfo describes an object found by Walk
+type ObjectInfo struct {
+	// Identifier of the object, relative to the walked storage namespace
+	Identifier   string
+	Size         int64
+	LastModified time.Time
+}
+
+// PartInfo describes a part uploaded to a multipart upload
+type PartInfo struct {
+	PartNumber   int64
+	ETag         string
+	Size         int64
+	LastModified time.Time
+}
+
+// WalkFunc is called by Walk for each object.  Returning an error stops the walk, and Walk returns the error.
+type WalkFunc func(info ObjectInfo) error
+
 type Adapter interface {
 	InventoryGenerator
 	WithContext(ctx context.Context) Adapter
@@ -60,9 +80,13 @@ type Adapter interface {
 	UploadPart(obj ObjectPointer, sizeBytes int64, reader io.Reader, uploadID string, partNumber int64) (string, error)
 	AbortMultiPartUpload(obj ObjectPointer, uploadID string) error
 	CompleteMultiPartUpload(obj ObjectPointer, uploadID string, multipartList *MultipartUploadCompletion) (*string, int64, error)
+	// ListParts returns the parts uploaded to a multipart upload, ordered by part number.
+	ListParts(obj ObjectPointer, uploadID string) ([]PartInfo, error)
 	// ValidateConfiguration validates an appropriate bucket
 	// configuration and returns a validation error or nil.
 	ValidateConfiguration(storageNamespace string) error
+	// Walk calls walkFn for each object stored under the storage namespace.
+	Walk(storageNamespace string, walkFn WalkFunc) error
 }
 
 type UploadIDTranslator interface {
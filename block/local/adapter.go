@@ -152,11 +152,21 @@ func (l *Adapter) CreateMultiPartUpload(obj block.ObjectPointer, r *http.Request
 	return uploadID, nil
 }
 
+// partETagSuffix follows the upload id in the names of the files keeping the part etags, so they don't match the
+// part files of the upload
+const partETagSuffix = ".etag"
+
 func (l *Adapter) UploadPart(obj block.ObjectPointer, sizeBytes int64, reader io.Reader, uploadId string, partNumber int64) (string, error) {
 	md5Read := block.NewHashingReader(reader, block.HashFunctionMD5)
 	fName := uploadId + fmt.Sprintf("-%05d", (partNumber))
 	err := l.Put(block.ObjectPointer{StorageNamespace: "", Identifier: fName}, -1, md5Read, nilPutOpts)
 	etag := "\"" + hex.EncodeToString(md5Read.Md5.Sum(nil)) + "\""
+	if err != nil {
+		return etag, err
+	}
+	// keep the etag, so listing the parts doesn't read them
+	etagName := uploadId + partETagSuffix + fmt.Sprintf("-%05d", (partNumber))
+	err = l.Put(block.ObjectPointer{StorageNamespace: "", Identifier: etagName}, -1, strings.NewReader(etag), nilPutOpts)
 	return etag, err
 }
 
@@ -166,6 +176,7 @@ func (l *Adapter) AbortMultiPartUpload(obj block.ObjectPointer, uploadID string)
 		return err
 	}
 	l.removePartFiles(files)
+	l.removePartETagFiles(uploadID)
 	return nil
 }
 
@@ -180,9 +191,69 @@ func (l *Adapter) CompleteMultiPartUpload(obj block.ObjectPointer, uploadID stri
 		return nil, -1, fmt.Errorf("multipart upload unite for %s: %w", uploadID, err)
 	}
 	l.removePartFiles(partFiles)
+	l.removePartETagFiles(uploadID)
 	return &etag, size, nil
 }
 
+func (l *Adapter) ListParts(obj block.ObjectPointer, uploadID string) ([]block.PartInfo, error) {
+	partFiles, err := l.getPartFiles(uploadID)
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]block.PartInfo, 0, len(partFiles))
+	for _, name := range partFiles {
+		partNumber, err := strconv.ParseInt(name[strings.LastIndex(name, "-")+1:], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("part file %s: %w", name, err)
+		}
+		part, err := l.partFileInfo(uploadID, name)
+		if err != nil {
+			return nil, err
+		}
+		part.PartNumber = partNumber
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// partFileInfo returns the size, modification time and etag (as returned by UploadPart) of a part file
+func (l *Adapter) partFileInfo(uploadID, name string) (block.PartInfo, error) {
+	stat, err := os.Stat(name)
+	if err != nil {
+		return block.PartInfo{}, fmt.Errorf("stat file %s: %w", name, err)
+	}
+	etagName := l.getPath(uploadID) + partETagSuffix + name[strings.LastIndex(name, "-"):]
+	etag, err := ioutil.ReadFile(etagName)
+	if errors.Is(err, os.ErrNotExist) {
+		// part uploaded without its etag file
+		etag, err = partFileETag(name)
+	}
+	if err != nil {
+		return block.PartInfo{}, fmt.Errorf("part etag %s: %w", name, err)
+	}
+	return block.PartInfo{
+		ETag:         string(etag),
+		Size:         stat.Size(),
+		LastModified: stat.ModTime(),
+	}, nil
+}
+
+// partFileETag computes the etag of a part file from its data
+func partFileETag(name string) ([]byte, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	h := md5.New() //nolint:gosec
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return []byte("\"" + hex.EncodeToString(h.Sum(nil)) + "\""), nil
+}
+
 func computeETag(parts []*s3.CompletedPart) string {
 	var etagHex []string
 	for _, p := range parts {
@@ -230,6 +301,14 @@ func (l *Adapter) removePartFiles(files []string) {
 	}
 }
 
+func (l *Adapter) removePartETagFiles(uploadID string) {
+	names, err := filepath.Glob(l.getPath(uploadID) + partETagSuffix + "-*")
+	if err != nil {
+		return
+	}
+	l.removePartFiles(names)
+}
+
 func (l *Adapter) getPartFiles(uploadID string) ([]string, error) {
 	globPathPattern := l.getPath(uploadID) + "-*"
 	names, err := filepath.Glob(globPathPattern)
@@ -244,6 +323,31 @@ func (l *Adapter) ValidateConfiguration(_ string) error {
 	return nil
 }
 
+// Walk walks all the files under the adapter path - the local adapter stores objects of all storage namespaces
+// in the same directory, by their identifier.
+func (l *Adapter) Walk(_ string, walkFn block.WalkFunc) error {
+	return filepath.Walk(l.path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := l.ctx.Err(); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(l.path, p)
+		if err != nil {
+			return err
+		}
+		return walkFn(block.ObjectInfo{
+			Identifier:   filepath.ToSlash(rel),
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+	})
+}
+
 func (l *Adapter) GenerateInventory(_ logging.Logger, _ string) (block.Inventory, error) {
 	return nil, errors.New("inventory feature not implemented for local storage adapter")
 }
@@ -0,0 +1,92 @@
+package local_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/block/local"
+)
+
+func TestAdapter_ListParts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "local-list-parts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	adapter, err := local.NewAdapter(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj := block.ObjectPointer{StorageNamespace: "local://ns", Identifier: "obj"}
+	uploadID, err := adapter.CreateMultiPartUpload(obj, nil, block.CreateMultiPartUploadOpts{})
+	if err != nil {
+		t.Fatal("create multipart upload:", err)
+	}
+	parts, err := adapter.ListParts(obj, uploadID)
+	if err != nil {
+		t.Fatal("list parts:", err)
+	}
+	if len(parts) != 0 {
+		t.Fatalf("list parts of new upload got %d parts, expected none", len(parts))
+	}
+
+	partsData := map[int64]string{2: "second part", 1: "first", 10: "tenth part data"}
+	etags := make(map[int64]string)
+	for partNumber, data := range partsData {
+		etag, err := adapter.UploadPart(obj, int64(len(data)), bytes.NewReader([]byte(data)), uploadID, partNumber)
+		if err != nil {
+			t.Fatalf("upload part %d: %s", partNumber, err)
+		}
+		etags[partNumber] = etag
+	}
+	parts, err = adapter.ListParts(obj, uploadID)
+	if err != nil {
+		t.Fatal("list parts:", err)
+	}
+	expectedPartNumbers := []int64{1, 2, 10}
+	if len(parts) != len(expectedPartNumbers) {
+		t.Fatalf("list parts got %d parts, expected %d", len(parts), len(expectedPartNumbers))
+	}
+	for i, part := range parts {
+		partNumber := expectedPartNumbers[i]
+		if part.PartNumber != partNumber {
+			t.Errorf("part %d number %d, expected %d", i, part.PartNumber, partNumber)
+		}
+		if part.Size != int64(len(partsData[partNumber])) {
+			t.Errorf("part %d size %d, expected %d", partNumber, part.Size, len(partsData[partNumber]))
+		}
+		if part.ETag != etags[partNumber] {
+			t.Errorf("part %d etag %s, expected %s", partNumber, part.ETag, etags[partNumber])
+		}
+		if part.LastModified.IsZero() {
+			t.Errorf("part %d missing last modified", partNumber)
+		}
+	}
+
+	// the etags kept by UploadPart are listed without reading the parts
+	if err := ioutil.WriteFile(filepath.Join(dir, uploadID+"-00001"), []byte("FIRST"), 0600); err != nil {
+		t.Fatal("overwrite part file:", err)
+	}
+	parts, err = adapter.ListParts(obj, uploadID)
+	if err != nil {
+		t.Fatal("list parts:", err)
+	}
+	if len(parts) == 0 || parts[0].ETag != etags[1] {
+		t.Fatalf("list parts got %v, expected part 1 etag %s", parts, etags[1])
+	}
+
+	if err := adapter.AbortMultiPartUpload(obj, uploadID); err != nil {
+		t.Fatal("abort multipart upload:", err)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, uploadID+"*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Fatalf("files %v left after abort, expected none", files)
+	}
+}
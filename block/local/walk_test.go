@@ -0,0 +1,52 @@
+package local_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/block/local"
+)
+
+func TestAdapter_Walk(t *testing.T) {
+	dir, err := ioutil.TempDir("", "local-walk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	adapter, err := local.NewAdapter(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(dir+"/dir", 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, identifier := range []string{"obj1", "obj2", "dir/obj3"} {
+		data := []byte(identifier)
+		err := adapter.Put(block.ObjectPointer{StorageNamespace: "local://ns", Identifier: identifier},
+			int64(len(data)), bytes.NewReader(data), block.PutOpts{})
+		if err != nil {
+			t.Fatalf("put %s: %s", identifier, err)
+		}
+	}
+
+	var identifiers []string
+	err = adapter.Walk("local://ns", func(info block.ObjectInfo) error {
+		if info.Size != int64(len(info.Identifier)) {
+			t.Errorf("object %s size %d, expected %d", info.Identifier, info.Size, len(info.Identifier))
+		}
+		identifiers = append(identifiers, info.Identifier)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("walk", err)
+	}
+	sort.Strings(identifiers)
+	if diff := deep.Equal(identifiers, []string{"dir/obj3", "obj1", "obj2"}); diff != nil {
+		t.Fatal("walk identifiers", diff)
+	}
+}
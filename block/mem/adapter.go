@@ -11,7 +11,9 @@ import (
 	"io/ioutil"
 	"net/http"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/treeverse/lakefs/block"
@@ -20,9 +22,15 @@ import (
 
 const BlockstoreType = "mem"
 
+type mpuPart struct {
+	data     []byte
+	etag     string
+	modified time.Time
+}
+
 type mpu struct {
 	id    string
-	parts map[int64][]byte
+	parts map[int64]*mpuPart
 }
 
 func newMPU() *mpu {
@@ -30,18 +38,26 @@ func newMPU() *mpu {
 	uploadID := hex.EncodeToString(uid[:])
 	return &mpu{
 		id:    uploadID,
-		parts: make(map[int64][]byte),
+		parts: make(map[int64]*mpuPart),
 	}
 }
 
-func (m *mpu) get() []byte {
-	buf := bytes.NewBuffer(nil)
-	keys := make([]int64, len(m.parts))
+// partNumbers returns the numbers of the uploaded parts in ascending order
+func (m *mpu) partNumbers() []int64 {
+	keys := make([]int64, 0, len(m.parts))
+	for partNumber := range m.parts {
+		keys = append(keys, partNumber)
+	}
 	sort.Slice(keys, func(i, j int) bool {
 		return keys[i] < keys[j]
 	})
-	for _, part := range keys {
-		buf.Write(m.parts[part])
+	return keys
+}
+
+func (m *mpu) get() []byte {
+	buf := bytes.NewBuffer(nil)
+	for _, part := range m.partNumbers() {
+		buf.Write(m.parts[part].data)
 	}
 	return buf.Bytes()
 }
@@ -49,6 +65,7 @@ func (m *mpu) get() []byte {
 type Adapter struct {
 	ctx                context.Context
 	data               map[string][]byte
+	modified           map[string]time.Time
 	mpu                map[string]*mpu
 	properties         map[string]block.Properties
 	mutex              *sync.RWMutex
@@ -60,6 +77,7 @@ func New(opts ...func(a *Adapter)) *Adapter {
 		ctx:                context.Background(),
 		uploadIDTranslator: &block.NoOpTranslator{},
 		data:               make(map[string][]byte),
+		modified:           make(map[string]time.Time),
 		mpu:                make(map[string]*mpu),
 		properties:         make(map[string]block.Properties),
 		mutex:              &sync.RWMutex{},
@@ -84,6 +102,7 @@ func (a *Adapter) WithContext(ctx context.Context) block.Adapter {
 	return &Adapter{
 		ctx:                ctx,
 		data:               a.data,
+		modified:           a.modified,
 		mpu:                a.mpu,
 		properties:         a.properties,
 		mutex:              a.mutex,
@@ -100,6 +119,7 @@ func (a *Adapter) Put(obj block.ObjectPointer, sizeBytes int64, reader io.Reader
 	}
 	key := getKey(obj)
 	a.data[key] = data
+	a.modified[key] = time.Now()
 	a.properties[key] = block.Properties(opts)
 	return nil
 }
@@ -137,7 +157,9 @@ func (a *Adapter) GetProperties(obj block.ObjectPointer) (block.Properties, erro
 func (a *Adapter) Remove(obj block.ObjectPointer) error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
-	delete(a.data, getKey(obj))
+	key := getKey(obj)
+	delete(a.data, key)
+	delete(a.modified, key)
 	return nil
 }
 
@@ -168,8 +190,13 @@ func (a *Adapter) UploadPart(obj block.ObjectPointer, sizeBytes int64, reader io
 		return "", err
 	}
 	code := h.Sum(nil)
-	mpu.parts[partNumber] = data
-	return fmt.Sprintf("%x", code), nil
+	etag := fmt.Sprintf("%x", code)
+	mpu.parts[partNumber] = &mpuPart{
+		data:     data,
+		etag:     etag,
+		modified: time.Now(),
+	}
+	return etag, nil
 }
 
 func (a *Adapter) AbortMultiPartUpload(obj block.ObjectPointer, uploadID string) error {
@@ -202,14 +229,64 @@ func (a *Adapter) CompleteMultiPartUpload(obj block.ObjectPointer, uploadID stri
 	code := h.Sum(nil)
 	hexCode := fmt.Sprintf("%x", code)
 	a.uploadIDTranslator.RemoveUploadID(uploadID)
-	a.data[getKey(obj)] = data
+	key := getKey(obj)
+	a.data[key] = data
+	a.modified[key] = time.Now()
 	return &hexCode, int64(len(data)), nil
 }
 
+func (a *Adapter) ListParts(obj block.ObjectPointer, uploadID string) ([]block.PartInfo, error) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	uploadID = a.uploadIDTranslator.TranslateUploadID(uploadID)
+	mpu, ok := a.mpu[uploadID]
+	if !ok {
+		return nil, fmt.Errorf("multipart ID not found")
+	}
+	partNumbers := mpu.partNumbers()
+	parts := make([]block.PartInfo, len(partNumbers))
+	for i, partNumber := range partNumbers {
+		part := mpu.parts[partNumber]
+		parts[i] = block.PartInfo{
+			PartNumber:   partNumber,
+			ETag:         part.etag,
+			Size:         int64(len(part.data)),
+			LastModified: part.modified,
+		}
+	}
+	return parts, nil
+}
+
 func (a *Adapter) ValidateConfiguration(_ string) error {
 	return nil
 }
 
+func (a *Adapter) Walk(storageNamespace string, walkFn block.WalkFunc) error {
+	prefix := getKey(block.ObjectPointer{StorageNamespace: storageNamespace})
+	// collect the objects first, walkFn may change the adapter data
+	a.mutex.RLock()
+	var objects []block.ObjectInfo
+	for key, data := range a.data {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, block.ObjectInfo{
+				Identifier:   key[len(prefix):],
+				Size:         int64(len(data)),
+				LastModified: a.modified[key],
+			})
+		}
+	}
+	a.mutex.RUnlock()
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Identifier < objects[j].Identifier
+	})
+	for _, obj := range objects {
+		if err := walkFn(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (a *Adapter) GenerateInventory(_ logging.Logger, _ string) (block.Inventory, error) {
 	return nil, errors.New("inventory feature not implemented for memory storage adapter")
 }
@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -336,6 +337,38 @@ func (s *Adapter) Remove(obj block.ObjectPointer) error {
 	return err
 }
 
+func (s *Adapter) Walk(storageNamespace string, walkFn block.WalkFunc) error {
+	var err error
+	defer reportMetrics("Walk", time.Now(), nil, &err)
+	qualifiedPrefix, err := resolveNamespace(block.ObjectPointer{StorageNamespace: storageNamespace})
+	if err != nil {
+		return err
+	}
+	var walkErr error
+	err = s.s3.ListObjectsV2PagesWithContext(s.ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(qualifiedPrefix.StorageNamespace),
+		Prefix: aws.String(qualifiedPrefix.Key),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			walkErr = walkFn(block.ObjectInfo{
+				Identifier:   strings.TrimPrefix(aws.StringValue(obj.Key), qualifiedPrefix.Key),
+				Size:         aws.Int64Value(obj.Size),
+				LastModified: aws.TimeValue(obj.LastModified),
+			})
+			if walkErr != nil {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		s.log().WithError(err).Error("failed to list S3 objects")
+		return err
+	}
+	err = walkErr
+	return err
+}
+
 func (s *Adapter) CreateMultiPartUpload(obj block.ObjectPointer, r *http.Request, opts block.CreateMultiPartUploadOpts) (string, error) {
 	var err error
 	defer reportMetrics("CreateMultiPartUpload", time.Now(), nil, &err)
@@ -428,6 +461,37 @@ func (s *Adapter) CompleteMultiPartUpload(obj block.ObjectPointer, uploadID stri
 	}
 }
 
+func (s *Adapter) ListParts(obj block.ObjectPointer, uploadID string) ([]block.PartInfo, error) {
+	var err error
+	defer reportMetrics("ListParts", time.Now(), nil, &err)
+	qualifiedKey, err := resolveNamespace(obj)
+	if err != nil {
+		return nil, err
+	}
+	input := &s3.ListPartsInput{
+		Bucket:   aws.String(qualifiedKey.StorageNamespace),
+		Key:      aws.String(qualifiedKey.Key),
+		UploadId: aws.String(s.uploadIDTranslator.TranslateUploadID(uploadID)),
+	}
+	var parts []block.PartInfo
+	err = s.s3.ListPartsPagesWithContext(s.ctx, input, func(page *s3.ListPartsOutput, _ bool) bool {
+		for _, part := range page.Parts {
+			parts = append(parts, block.PartInfo{
+				PartNumber:   aws.Int64Value(part.PartNumber),
+				ETag:         aws.StringValue(part.ETag),
+				Size:         aws.Int64Value(part.Size),
+				LastModified: aws.TimeValue(part.LastModified),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		s.log().WithError(err).WithField("upload_id", uploadID).Error("ListParts failed")
+		return nil, err
+	}
+	return parts, nil
+}
+
 func contains(tags []*s3.Tag, pred func(string, string) bool) bool {
 	for _, tag := range tags {
 		if pred(*tag.Key, *tag.Value) {
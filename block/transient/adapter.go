@@ -100,10 +100,20 @@ func (a *Adapter) CompleteMultiPartUpload(block.ObjectPointer, string, *block.Mu
 	return &codeHex, dataSize, nil
 }
 
+func (a *Adapter) ListParts(block.ObjectPointer, string) ([]block.PartInfo, error) {
+	// parts are not stored
+	return nil, nil
+}
+
 func (a *Adapter) ValidateConfiguration(_ string) error {
 	return nil
 }
 
+func (a *Adapter) Walk(_ string, _ block.WalkFunc) error {
+	// nothing is stored
+	return nil
+}
+
 func (a *Adapter) GenerateInventory(_ logging.Logger, _ string) (block.Inventory, error) {
 	return nil, errors.New("inventory feature not implemented for transient storage adapter")
 }
@@ -13,6 +13,7 @@ type SetFn func() (v interface{}, err error)
 
 type Cache interface {
 	GetOrSet(k interface{}, setFn SetFn) (v interface{}, err error)
+	Remove(k interface{})
 }
 
 type GetSetCache struct {
@@ -61,6 +62,11 @@ func (c *GetSetCache) GetOrSet(k interface{}, setFn SetFn) (v interface{}, err e
 	return nil, ErrCacheItemNotFound
 }
 
+// Remove evicts the key, the next GetOrSet of the key calls its set function
+func (c *GetSetCache) Remove(k interface{}) {
+	c.lru.Remove(k)
+}
+
 func NewJitterFn(jitter time.Duration) JitterFn {
 	return func() time.Duration {
 		return time.Duration(rand.Intn(int(jitter)))
@@ -14,6 +14,8 @@ type Cache interface {
 	Repository(repository string, setFn GetRepositoryFn) (*Repository, error)
 	RepositoryID(repository string, setFn GetRepositoryIDFn) (int, error)
 	BranchID(repository string, branch string, setFn GetBranchIDFn) (int64, error)
+	InvalidateRepository(repository string)
+	InvalidateBranchID(repository string, branch string)
 }
 
 type LRUCache struct {
@@ -52,7 +54,7 @@ func (c *LRUCache) RepositoryID(repository string, setFn GetRepositoryIDFn) (int
 }
 
 func (c *LRUCache) BranchID(repository string, branch string, setFn GetBranchIDFn) (int64, error) {
-	key := repository + "/" + branch
+	key := branchIDKey(repository, branch)
 	v, err := c.branchID.GetOrSet(key, func() (interface{}, error) {
 		return setFn(repository, branch)
 	})
@@ -62,6 +64,18 @@ func (c *LRUCache) BranchID(repository string, branch string, setFn GetBranchIDF
 	return v.(int64), nil
 }
 
+func (c *LRUCache) InvalidateRepository(repository string) {
+	c.repository.Remove(repository)
+}
+
+func (c *LRUCache) InvalidateBranchID(repository string, branch string) {
+	c.branchID.Remove(branchIDKey(repository, branch))
+}
+
+func branchIDKey(repository string, branch string) string {
+	return repository + "/" + branch
+}
+
 type DummyCache struct{}
 
 func (c *DummyCache) Repository(repository string, setFn GetRepositoryFn) (*Repository, error) {
@@ -75,3 +89,7 @@ func (c *DummyCache) RepositoryID(repository string, setFn GetRepositoryIDFn) (i
 func (c *DummyCache) BranchID(repository string, branch string, setFn GetBranchIDFn) (int64, error) {
 	return setFn(repository, branch)
 }
+
+func (c *DummyCache) InvalidateRepository(repository string) {}
+
+func (c *DummyCache) InvalidateBranchID(repository string, branch string) {}
@@ -48,9 +48,20 @@ type ExpireResult struct {
 	InternalReference string
 }
 
+// UpdateRepositoryParams holds the repository settings to change, settings left unset are kept
+type UpdateRepositoryParams struct {
+	// DefaultBranch sets the default branch to an existing branch, when not empty
+	DefaultBranch string
+	// Description replaces the repository description, when not nil
+	Description *string
+	// Metadata replaces the repository metadata, when not nil
+	Metadata Metadata
+}
+
 type RepositoryCataloger interface {
 	CreateRepository(ctx context.Context, repository string, storageNamespace string, branch string) error
 	GetRepository(ctx context.Context, repository string) (*Repository, error)
+	UpdateRepository(ctx context.Context, repository string, params UpdateRepositoryParams) (*Repository, error)
 	DeleteRepository(ctx context.Context, repository string) error
 	ListRepositories(ctx context.Context, limit int, after string) ([]*Repository, bool, error)
 }
@@ -62,6 +73,57 @@ type BranchCataloger interface {
 	BranchExists(ctx context.Context, repository string, branch string) (bool, error)
 	GetBranchReference(ctx context.Context, repository, branch string) (string, error)
 	ResetBranch(ctx context.Context, repository, branch string) error
+	RenameBranch(ctx context.Context, repository, from, to string) error
+}
+
+type TagCataloger interface {
+	CreateTag(ctx context.Context, repository, tag string, reference string) (*Tag, error)
+	DeleteTag(ctx context.Context, repository, tag string) error
+	ListTags(ctx context.Context, repository string, limit int, after string) ([]*Tag, bool, error)
+	GetTag(ctx context.Context, repository, tag string) (*Tag, error)
+}
+
+// BranchProtectionCataloger manages the rules that protect branches from direct changes.  A branch that matches a
+// rule pattern can't be written, committed to or deleted - it is changed only by merging into it.
+type BranchProtectionCataloger interface {
+	CreateBranchProtectionRule(ctx context.Context, repository, pattern string) error
+	DeleteBranchProtectionRule(ctx context.Context, repository, pattern string) error
+	ListBranchProtectionRules(ctx context.Context, repository string) ([]*BranchProtectionRule, error)
+	// CheckBranchProtection returns ErrBranchProtected when the branch matches one of the rules
+	CheckBranchProtection(ctx context.Context, repository, branch string) error
+}
+
+// HookCataloger manages the repository hooks called before commit and merge
+type HookCataloger interface {
+	CreateHook(ctx context.Context, repository string, hook *Hook) error
+	DeleteHook(ctx context.Context, repository, name string) error
+	ListHooks(ctx context.Context, repository string) ([]*Hook, error)
+}
+
+// EventCataloger writes events to the events outbox
+type EventCataloger interface {
+	AddEvent(ctx context.Context, event *Event) error
+}
+
+// GarbageCollectionCataloger finds the physical addresses garbage collection must keep
+type GarbageCollectionCataloger interface {
+	// QueryReferencedAddresses returns ReferencedAddressRows iterating over the physical addresses of all
+	// repositories reachable from a branch head, a tag, a commit created since the given time, or an active
+	// multipart upload.
+	QueryReferencedAddresses(ctx context.Context, since time.Time) (ReferencedAddressRows, error)
+	// DeleteUnreferencedAddresses returns the physical addresses still not referenced by the points of
+	// QueryReferencedAddresses, and removes their dedup records so new objects will not be deduplicated to them.
+	DeleteUnreferencedAddresses(ctx context.Context, since time.Time, addresses []string) ([]string, error)
+}
+
+// ReferencedAddressRows is a database iterator over ReferencedAddresses.  Use Next to advance from row to row.
+type ReferencedAddressRows interface {
+	io.Closer
+	Next() bool
+	Err() error
+	// Read returns the current from ReferencedAddressRows, or an error on failure.  Call it only after
+	// successfully calling Next.
+	Read() (*ReferencedAddress, error)
 }
 
 var ErrExpired = errors.New("expired from storage")
@@ -85,6 +147,10 @@ type GetEntryParams struct {
 
 type CreateEntryParams struct {
 	Dedup DedupParams
+	// IfAbsent fails the create with ErrPreconditionFailed when path already has an entry
+	IfAbsent bool
+	// IfChecksum fails the create with ErrPreconditionFailed unless path has an entry with this checksum
+	IfChecksum string
 }
 
 type EntryCataloger interface {
@@ -94,7 +160,17 @@ type EntryCataloger interface {
 	CreateEntry(ctx context.Context, repository, branch string, entry Entry, params CreateEntryParams) error
 	CreateEntries(ctx context.Context, repository, branch string, entries []Entry) error
 	DeleteEntry(ctx context.Context, repository, branch string, path string) error
+	// SetEntryTags replaces the object tags of the entry of path, without changing its object or other metadata.
+	SetEntryTags(ctx context.Context, repository, branch string, path string, tags map[string]string) error
+	MoveEntries(ctx context.Context, repository, branch string, source, destination string, prefix bool) (int, error)
 	ListEntries(ctx context.Context, repository, reference string, prefix, after string, delimiter string, limit int) ([]*Entry, bool, error)
+	// ListEntryHistory returns the committed versions of path visible from reference, newest first.  Pass the
+	// reference of the last version as after to get the next page.
+	ListEntryHistory(ctx context.Context, repository, reference string, path string, after string, limit int) ([]*EntryVersion, bool, error)
+	// ListEntriesVersions returns the committed versions and deletions of the entries under prefix visible from
+	// reference, ordered by path and newest first.  Pass the path and reference of the last version as afterPath
+	// and afterReference to get the next page, or only afterPath to start after all its versions.
+	ListEntriesVersions(ctx context.Context, repository, reference string, prefix string, afterPath, afterReference string, limit int) ([]*EntryVersion, bool, error)
 	ResetEntry(ctx context.Context, repository, branch string, path string) error
 	ResetEntries(ctx context.Context, repository, branch string, prefix string) error
 
@@ -107,38 +183,100 @@ type EntryCataloger interface {
 }
 
 type MultipartUpdateCataloger interface {
-	CreateMultipartUpload(ctx context.Context, repository, uploadID, path, physicalAddress string, creationTime time.Time) error
+	CreateMultipartUpload(ctx context.Context, repository, branch, uploadID, path, physicalAddress string, creationTime time.Time, metadata Metadata) error
 	GetMultipartUpload(ctx context.Context, repository, uploadID string) (*MultipartUpload, error)
+	// ListMultipartUploads returns the multipart uploads of branch under prefix, ordered by path and upload id.
+	// Pass the path and upload id of the last upload as afterPath and afterUploadID to get the next page, or only
+	// afterPath to continue after all the uploads of that path.
+	ListMultipartUploads(ctx context.Context, repository, branch, prefix, afterPath, afterUploadID string, limit int) ([]*MultipartUpload, bool, error)
 	DeleteMultipartUpload(ctx context.Context, repository, uploadID string) error
 }
 
+// ListCommitsParams filters the commits returned by ListCommits.  Only commits that match all the set fields are
+// returned: commits that changed a path with Prefix, made by Committer, created in the Since-Until time range
+// (inclusive) and having all the Metadata key/value pairs.
+type ListCommitsParams struct {
+	Prefix    string
+	Committer string
+	Since     time.Time
+	Until     time.Time
+	Metadata  Metadata
+}
+
 type Committer interface {
 	Commit(ctx context.Context, repository, branch string, message string, committer string, metadata Metadata) (*CommitLog, error)
+	// CommitImport commits the imported entries of branch, and reports the commit as an import event as well
+	CommitImport(ctx context.Context, repository, branch string, message string, committer string, metadata Metadata) (*CommitLog, error)
 	GetCommit(ctx context.Context, repository, reference string) (*CommitLog, error)
-	ListCommits(ctx context.Context, repository, branch string, fromReference string, limit int) ([]*CommitLog, bool, error)
-	RollbackCommit(ctx context.Context, repository, reference string) error
+	ListCommits(ctx context.Context, repository, reference string, fromReference string, limit int, params ListCommitsParams) ([]*CommitLog, bool, error)
+	RollbackCommit(ctx context.Context, repository, branch, reference string) error
+	CherryPick(ctx context.Context, repository, commitRef, destinationBranch string, committer string) (*MergeResult, error)
+	RevertCommit(ctx context.Context, repository, branch string, reference string, committer string) (*MergeResult, error)
+}
+
+// DiffParams selects a page of differences.  Differences are ordered by path, starting after After.  Only
+// paths with Prefix and, when set, differences of one of DifferenceTypes are returned.
+type DiffParams struct {
+	Limit           int
+	After           string
+	Prefix          string
+	DifferenceTypes []DifferenceType
+	// WithSummary calculates the summary of all the differences, not only the page
+	WithSummary bool
 }
 
 type Differ interface {
-	Diff(ctx context.Context, repository, leftBranch string, rightBranch string) (Differences, error)
-	DiffUncommitted(ctx context.Context, repository, branch string) (Differences, error)
+	Diff(ctx context.Context, repository, leftReference string, rightReference string, params DiffParams) (*DiffResult, error)
+	DiffUncommitted(ctx context.Context, repository, branch string, params DiffParams) (*DiffResult, error)
+}
+
+type MergeStrategy string
+
+const (
+	MergeStrategyFail       MergeStrategy = "fail"
+	MergeStrategySourceWins MergeStrategy = "source-wins"
+	MergeStrategyDestWins   MergeStrategy = "dest-wins"
+)
+
+// MergeParams configures how Merge handles conflicts.
+type MergeParams struct {
+	// Strategy resolves conflicting paths by taking the source or the destination version.  Merge fails with
+	// ErrConflictFound when the strategy is not set or MergeStrategyFail.
+	Strategy MergeStrategy
+	// DryRun computes the merge result, including conflicts, without writing the merge.
+	DryRun bool
+}
+
+// MergeConflict describes a path changed by both sides of a merge.  Source or Destination is nil when the path
+// is deleted on that side.
+type MergeConflict struct {
+	Path        string
+	Source      *Entry
+	Destination *Entry
 }
 
 type MergeResult struct {
 	Differences Differences
+	Summary     map[DifferenceType]int
+	Conflicts   []MergeConflict
 	Reference   string
 }
 
 type Merger interface {
-	Merge(ctx context.Context, repository, sourceBranch, destinationBranch string, committer string, message string, metadata Metadata) (*MergeResult, error)
+	Merge(ctx context.Context, repository, sourceBranch, destinationBranch string, committer string, message string, metadata Metadata, params MergeParams) (*MergeResult, error)
 }
 
 type Cataloger interface {
 	RepositoryCataloger
 	BranchCataloger
+	TagCataloger
+	BranchProtectionCataloger
+	HookCataloger
+	EventCataloger
 	EntryCataloger
 	Committer
 	MultipartUpdateCataloger
+	GarbageCollectionCataloger
 	Differ
 	Merger
 	io.Closer
@@ -172,6 +310,8 @@ type cataloger struct {
 	dedupCh            chan *dedupRequest
 	dedupReportEnabled bool
 	dedupReportCh      chan *DedupReport
+	hookRunner         HookRunner
+	eventsEnabled      bool
 }
 
 type CatalogerOption func(*cataloger)
@@ -201,6 +341,21 @@ func WithDedupReportChannel(b bool) CatalogerOption {
 	}
 }
 
+// WithHookRunner sets the runner that calls the repository hooks.  Hooks are not called without a runner.
+func WithHookRunner(runner HookRunner) CatalogerOption {
+	return func(c *cataloger) {
+		c.hookRunner = runner
+	}
+}
+
+// WithEvents enables writing events to the events outbox.  Enable it only when the outbox is delivered, or it
+// will grow without bound.
+func WithEvents(enabled bool) CatalogerOption {
+	return func(c *cataloger) {
+		c.eventsEnabled = enabled
+	}
+}
+
 func NewCataloger(db db.Database, options ...CatalogerOption) Cataloger {
 	c := &cataloger{
 		clock:              clock.New(),
@@ -291,7 +446,8 @@ func (c *cataloger) dedupBatch(batch []*dedupRequest) {
 				return nil, err
 			}
 
-			// add dedup record
+			// add dedup record - its row exclusive lock on catalog_object_dedup is what garbage collection
+			// waits for before checking which addresses are still unreferenced
 			res, err := tx.Exec(`INSERT INTO catalog_object_dedup (repository_id, dedup_id, physical_address) values ($1, decode($2,'hex'), $3)
 				ON CONFLICT DO NOTHING`,
 				repoID, r.DedupID, r.Entry.PhysicalAddress)
@@ -0,0 +1,20 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// AddEvent writes an event that isn't part of a cataloger operation, like the completion of an import
+func (c *cataloger) AddEvent(ctx context.Context, event *Event) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(event.Repository)},
+	}); err != nil {
+		return err
+	}
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		return nil, c.addEvent(tx, event)
+	}, c.txOpts(ctx)...)
+	return err
+}
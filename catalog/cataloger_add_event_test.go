@@ -0,0 +1,72 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func testCatalogerEventTypes(t *testing.T, conn db.Database, repository string) []EventType {
+	t.Helper()
+	var payloads [][]byte
+	_, err := conn.Transact(func(tx db.Tx) (interface{}, error) {
+		return nil, tx.Select(&payloads, `SELECT payload FROM catalog_events_outbox
+			WHERE payload->>'repository' = $1 ORDER BY id`, repository)
+	})
+	testutil.MustDo(t, "select events", err)
+	types := make([]EventType, len(payloads))
+	for i, payload := range payloads {
+		var ev Event
+		testutil.MustDo(t, "unmarshal event", json.Unmarshal(payload, &ev))
+		types[i] = ev.Type
+	}
+	return types
+}
+
+func TestCataloger_AddEvent(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := testutil.GetDB(t, databaseURI)
+	c := NewCataloger(conn, WithEvents(true))
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "file1", nil, "")
+	_, err := c.Commit(ctx, repository, "branch1", "commit", "tester", nil)
+	testutil.MustDo(t, "commit", err)
+	_, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{})
+	testutil.MustDo(t, "merge", err)
+	testutil.MustDo(t, "reset branch", c.ResetBranch(ctx, repository, "branch1"))
+	err = c.AddEvent(ctx, &Event{Type: EventTypeImport, Repository: repository, Branch: "master"})
+	testutil.MustDo(t, "add event", err)
+	testutil.MustDo(t, "delete branch", c.DeleteBranch(ctx, repository, "branch1"))
+	testutil.MustDo(t, "delete repository", c.DeleteRepository(ctx, repository))
+
+	expected := []EventType{
+		EventTypeRepositoryCreate,
+		EventTypeBranchCreate,
+		EventTypeCommit,
+		EventTypeMerge,
+		EventTypeBranchReset,
+		EventTypeImport,
+		EventTypeBranchDelete,
+		EventTypeRepositoryDelete,
+	}
+	if diff := deep.Equal(testCatalogerEventTypes(t, conn, repository), expected); diff != nil {
+		t.Fatal("events", diff)
+	}
+}
+
+func TestCataloger_AddEvent_Disabled(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := testutil.GetDB(t, databaseURI)
+	c := NewCataloger(conn)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	err := c.AddEvent(ctx, &Event{Type: EventTypeImport, Repository: repository, Branch: "master"})
+	testutil.MustDo(t, "add event", err)
+	if types := testCatalogerEventTypes(t, conn, repository); len(types) != 0 {
+		t.Fatalf("events %v, expected none when events are disabled", types)
+	}
+}
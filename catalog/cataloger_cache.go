@@ -36,6 +36,49 @@ func (c *cataloger) getRepositoryIDCache(tx db.Tx, repository string) (int, erro
 	return repoID, err
 }
 
+// getRefBranchIDCache returns the branch id of the reference.
+// A reference to a branch that does not exist is looked up as a tag, in this case the returned reference is the
+// commit reference of the tag.
+// A commit reference is resolved by its commit id, the returned reference has the current name of its branch.
+// A relative reference is resolved to the commit reference it selects, with the branch id of that commit.
+func (c *cataloger) getRefBranchIDCache(tx db.Tx, repository string, ref *Ref) (*Ref, int64, error) {
+	baseRef, branchID, err := c.getBaseRefBranchIDCache(tx, repository, ref)
+	if err != nil || !ref.IsRelative() {
+		return baseRef, branchID, err
+	}
+	return resolveRelativeRef(tx, branchID, baseRef, ref)
+}
+
+func (c *cataloger) getBaseRefBranchIDCache(tx db.Tx, repository string, ref *Ref) (*Ref, int64, error) {
+	if ref.CommitID > UncommittedID {
+		// the branch name of a commit reference is the name the branch had when the reference was made
+		branch, err := getCommitBranch(tx, repository, ref.CommitID)
+		if errors.Is(err, db.ErrNotFound) {
+			return ref, 0, ErrCommitNotFound
+		}
+		if err != nil {
+			return ref, 0, fmt.Errorf("get commit branch: %w", err)
+		}
+		commitRef := *ref
+		commitRef.Branch = branch.Name
+		return &commitRef, branch.ID, nil
+	}
+	branchID, err := c.getBranchIDCache(tx, repository, ref.Branch)
+	if err == nil || ref.CommitID != UncommittedID || !errors.Is(err, db.ErrNotFound) {
+		return ref, branchID, err
+	}
+	tag, tagErr := getTag(tx, repository, ref.Branch)
+	if errors.Is(tagErr, db.ErrNotFound) {
+		return ref, branchID, err
+	}
+	if tagErr != nil {
+		return ref, branchID, fmt.Errorf("get tag: %w", tagErr)
+	}
+	tagRef := &Ref{Branch: tag.BranchName, CommitID: tag.CommitID}
+	branchID, err = c.getBranchIDCache(tx, repository, tagRef.Branch)
+	return tagRef, branchID, err
+}
+
 func (c *cataloger) getBranchIDCache(tx db.Tx, repository string, branch string) (int64, error) {
 	branchID, err := c.cache.BranchID(repository, branch, func(repository string, branch string) (int64, error) {
 		branchID, err := getBranchID(tx, repository, branch, LockTypeNone)
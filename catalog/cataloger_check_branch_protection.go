@@ -0,0 +1,20 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+func (c *cataloger) CheckBranchProtection(ctx context.Context, repository, branch string) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", IsValid: ValidateBranchName(branch)},
+	}); err != nil {
+		return err
+	}
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		return nil, checkBranchProtection(tx, repository, branch)
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	return err
+}
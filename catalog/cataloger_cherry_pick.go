@@ -0,0 +1,75 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// CherryPick applies the changes introduced by a single commit, compared to its parent commit, on the destination
+// branch as a new commit. Paths changed by the commit that were also changed on the destination branch are
+// reported as conflicts, the same way Merge does.
+func (c *cataloger) CherryPick(ctx context.Context, repository, commitRef, destinationBranch string, committer string) (*MergeResult, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "commitRef", IsValid: ValidateReference(commitRef)},
+		{Name: "destinationBranch", IsValid: ValidateBranchName(destinationBranch)},
+		{Name: "committer", IsValid: ValidateCommitter(committer)},
+	}); err != nil {
+		return nil, err
+	}
+	ref, err := ParseRef(commitRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *MergeResult
+	_, err = c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		destinationID, err := getBranchID(tx, repository, destinationBranch, LockTypeUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("destination branch: %w", err)
+		}
+		if err := checkBranchProtection(tx, repository, destinationBranch); err != nil {
+			return nil, err
+		}
+		sourceID, commit, err := c.getRefCommit(tx, repository, ref)
+		if err != nil {
+			return nil, err
+		}
+		if commit.PreviousCommitID <= 0 {
+			return nil, fmt.Errorf("cherry-pick commit without parent: %w", ErrOperationNotPermitted)
+		}
+
+		differences, err := diffCommits(tx, sourceID, commit.PreviousCommitID, commit.CommitID, destinationID)
+		if err != nil {
+			return nil, err
+		}
+		result = &MergeResult{
+			Differences: differences,
+		}
+		diffCounts := result.Differences.CountByType()
+		if diffCounts[DifferenceTypeConflict] > 0 {
+			return nil, ErrConflictFound
+		}
+		if len(diffCounts) == 0 {
+			return nil, ErrNoDifferenceWasFound
+		}
+
+		commitID, err := c.commitDiffResults(tx, destinationID, committer, commit.Message, commit.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		result.Reference = MakeReference(destinationBranch, commitID)
+		return nil, c.addEvent(tx, &Event{
+			Type:       EventTypeCommit,
+			Repository: repository,
+			Branch:     destinationBranch,
+			Reference:  result.Reference,
+			Committer:  committer,
+			Message:    commit.Message,
+			Metadata:   commit.Metadata,
+		})
+	}, c.txOpts(ctx)...)
+	return result, err
+}
@@ -0,0 +1,154 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_CherryPick_Basic(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	for i := 0; i < 3; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+
+	// commit to pick - add, change and delete
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file3", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "seed1")
+	testutil.MustDo(t, "delete file2", c.DeleteEntry(ctx, repository, "master", "/file2"))
+	commitLog, err := c.Commit(ctx, repository, "master", "fix commit", "tester", Metadata{"k": "v"})
+	testutil.MustDo(t, "fix commit", err)
+
+	// later commit that should not be picked
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file4", nil, "")
+	_, err = c.Commit(ctx, repository, "master", "another commit", "tester", nil)
+	testutil.MustDo(t, "another commit", err)
+
+	res, err := c.CherryPick(ctx, repository, commitLog.Reference, "branch1", "picker")
+	testutil.MustDo(t, "cherry-pick", err)
+	if res == nil || res.Reference == "" {
+		t.Fatalf("CherryPick result = %+v, expected a reference", res)
+	}
+	expectedDifferences := Differences{
+		{Type: DifferenceTypeChanged, Path: "/file1"},
+		{Type: DifferenceTypeRemoved, Path: "/file2"},
+		{Type: DifferenceTypeAdded, Path: "/file3"},
+	}
+	if !res.Differences.Equal(expectedDifferences) {
+		t.Fatalf("CherryPick differences = %s, expected %s", spew.Sdump(res.Differences), spew.Sdump(expectedDifferences))
+	}
+	testVerifyEntries(t, ctx, c, repository, "branch1", []testEntryInfo{
+		{Path: "/file0"},
+		{Path: "/file1", Seed: "seed1"},
+		{Path: "/file2", Deleted: true},
+		{Path: "/file3"},
+		{Path: "/file4", Deleted: true},
+	})
+
+	// the changes are committed on the destination branch
+	commit, err := c.GetCommit(ctx, repository, res.Reference)
+	testutil.MustDo(t, "get cherry-pick commit", err)
+	if commit.Committer != "picker" || commit.Message != "fix commit" || commit.Metadata["k"] != "v" {
+		t.Fatalf("CherryPick commit = %+v, expected committer 'picker' with the picked commit message and metadata", commit)
+	}
+	diffs, err := c.DiffUncommitted(ctx, repository, "branch1", DiffParams{Limit: -1})
+	testutil.MustDo(t, "diff uncommitted", err)
+	if len(diffs.Differences) != 0 {
+		t.Fatalf("DiffUncommitted after cherry-pick = %s, expected no changes", spew.Sdump(diffs.Differences))
+	}
+}
+
+func TestCataloger_CherryPick_Conflict(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file1", nil, "branch1")
+	_, err = c.Commit(ctx, repository, "branch1", "change file1 on branch1", "tester", nil)
+	testutil.MustDo(t, "commit to branch1", err)
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "master")
+	commitLog, err := c.Commit(ctx, repository, "master", "change files on master", "tester", nil)
+	testutil.MustDo(t, "second commit to master", err)
+
+	res, err := c.CherryPick(ctx, repository, commitLog.Reference, "branch1", "tester")
+	if !errors.Is(err, ErrConflictFound) {
+		t.Fatalf("CherryPick err = %s, expected %s", err, ErrConflictFound)
+	}
+	expectedDifferences := Differences{
+		{Type: DifferenceTypeChanged, Path: "/file0"},
+		{Type: DifferenceTypeConflict, Path: "/file1"},
+	}
+	if res == nil || !res.Differences.Equal(expectedDifferences) {
+		t.Fatalf("CherryPick result = %s, expected differences %s", spew.Sdump(res), spew.Sdump(expectedDifferences))
+	}
+	// nothing applied on conflict
+	testVerifyEntries(t, ctx, c, repository, "branch1", []testEntryInfo{
+		{Path: "/file0"},
+		{Path: "/file1", Seed: "branch1"},
+	})
+}
+
+func TestCataloger_CherryPick_NoDifference(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+
+	_, err = c.CherryPick(ctx, repository, commitLog.Reference, "branch1", "tester")
+	testutil.MustDo(t, "first cherry-pick", err)
+	_, err = c.CherryPick(ctx, repository, commitLog.Reference, "branch1", "tester")
+	if !errors.Is(err, ErrNoDifferenceWasFound) {
+		t.Fatalf("CherryPick err = %s, expected %s", err, ErrNoDifferenceWasFound)
+	}
+}
+
+func TestCataloger_CherryPick_InvalidReference(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	branchReference, err := c.GetBranchReference(ctx, repository, "branch1")
+	testutil.MustDo(t, "get branch reference", err)
+	ref, err := ParseRef(branchReference)
+	testutil.MustDo(t, "parse branch reference", err)
+
+	tests := []struct {
+		name        string
+		reference   string
+		destination string
+		wantErr     error
+	}{
+		{name: "initial commit", reference: branchReference, destination: "master", wantErr: ErrOperationNotPermitted},
+		{name: "commit of other branch", reference: MakeReference("master", ref.CommitID), destination: "branch1", wantErr: ErrCommitNotFound},
+		{name: "unknown destination", reference: branchReference, destination: "no-branch", wantErr: db.ErrNotFound},
+		{name: "invalid reference", reference: "", destination: "master", wantErr: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.CherryPick(ctx, repository, tt.reference, tt.destination, "tester")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CherryPick err = %s, expected %s", err, tt.wantErr)
+			}
+		})
+	}
+}
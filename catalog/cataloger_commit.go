@@ -9,6 +9,17 @@ import (
 )
 
 func (c *cataloger) Commit(ctx context.Context, repository, branch string, message string, committer string, metadata Metadata) (*CommitLog, error) {
+	return c.commit(ctx, repository, branch, message, committer, metadata)
+}
+
+// CommitImport commits like Commit, and also reports the commit as an import event, written by the commit
+// transaction.
+func (c *cataloger) CommitImport(ctx context.Context, repository, branch string, message string, committer string, metadata Metadata) (*CommitLog, error) {
+	return c.commit(ctx, repository, branch, message, committer, metadata, EventTypeImport)
+}
+
+// commit writes the commit event, and an event of each of eventTypes, in the commit transaction
+func (c *cataloger) commit(ctx context.Context, repository, branch string, message string, committer string, metadata Metadata, eventTypes ...EventType) (*CommitLog, error) {
 	if err := Validate(ValidateFields{
 		{Name: "branch", IsValid: ValidateBranchName(branch)},
 		{Name: "message", IsValid: ValidateCommitMessage(message)},
@@ -17,11 +28,35 @@ func (c *cataloger) Commit(ctx context.Context, repository, branch string, messa
 		return nil, err
 	}
 
+	approvedState, err := c.runCommitHooks(ctx, &HookEvent{
+		Type:       HookTypePreCommit,
+		Repository: repository,
+		Branch:     branch,
+		Committer:  committer,
+		Message:    message,
+		Metadata:   metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
 		branchID, err := getBranchID(tx, repository, branch, LockTypeUpdate)
 		if err != nil {
 			return nil, fmt.Errorf("get branch id: %w", err)
 		}
+		if err := checkBranchProtection(tx, repository, branch); err != nil {
+			return nil, err
+		}
+		if approvedState != nil {
+			state, err := getBranchState(tx, branchID)
+			if err != nil {
+				return nil, err
+			}
+			if state != *approvedState {
+				return nil, ErrBranchChangedDuringHooks
+			}
+		}
 
 		lastCommitID, err := getLastCommitIDByBranchID(tx, branchID)
 		if err != nil {
@@ -74,6 +109,20 @@ func (c *cataloger) Commit(ctx context.Context, repository, branch string, messa
 			Reference:    reference,
 			Parents:      []string{parentReference},
 		}
+		for _, eventType := range append([]EventType{EventTypeCommit}, eventTypes...) {
+			err = c.addEvent(tx, &Event{
+				Type:       eventType,
+				Repository: repository,
+				Branch:     branch,
+				Reference:  reference,
+				Committer:  committer,
+				Message:    message,
+				Metadata:   metadata,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
 		return commitLog, nil
 	}, c.txOpts(ctx)...)
 	if err != nil {
@@ -82,6 +131,43 @@ func (c *cataloger) Commit(ctx context.Context, repository, branch string, messa
 	return res.(*CommitLog), nil
 }
 
+// runCommitHooks calls the pre-commit hooks with the uncommitted changes of the branch, outside of the commit
+// transaction.  It returns the branch state the hooks approved, or nil when there are no hooks to run.
+func (c *cataloger) runCommitHooks(ctx context.Context, event *HookEvent) (*branchState, error) {
+	var hooks []*Hook
+	var state branchState
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		var err error
+		hooks, err = c.getHooksToRun(tx, event.Repository, event.Type)
+		if err != nil || len(hooks) == 0 {
+			return nil, err
+		}
+		branchID, err := getBranchID(tx, event.Repository, event.Branch, LockTypeNone)
+		if err != nil {
+			return nil, fmt.Errorf("get branch id: %w", err)
+		}
+		if err := checkBranchProtection(tx, event.Repository, event.Branch); err != nil {
+			return nil, err
+		}
+		event.Differences, err = diffUncommittedDifferences(tx, branchID)
+		if err != nil {
+			return nil, fmt.Errorf("hook differences: %w", err)
+		}
+		if len(event.Differences) == 0 {
+			return nil, ErrNothingToCommit
+		}
+		state, err = getBranchState(tx, branchID)
+		return nil, err
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil || len(hooks) == 0 {
+		return nil, err
+	}
+	if err := c.hookRunner.Run(ctx, hooks, event); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
 func commitUpdateCommittedEntriesWithMaxCommit(tx sqlx.Execer, branchID int64, commitID CommitID) (int64, error) {
 	res, err := tx.Exec(`UPDATE catalog_entries_v SET max_commit = $2
 			WHERE branch_id = $1 AND is_committed
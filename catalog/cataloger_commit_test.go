@@ -13,6 +13,8 @@ import (
 	"github.com/davecgh/go-spew/spew"
 
 	"github.com/benbjohnson/clock"
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/db"
 	"github.com/treeverse/lakefs/testutil"
 )
 
@@ -242,3 +244,136 @@ func TestCataloger_Commit_Scenario(t *testing.T) {
 	})
 
 }
+
+func TestCataloger_Commit_Hooks(t *testing.T) {
+	ctx := context.Background()
+	runner := &testHookRunner{}
+	c := testCataloger(t, WithHookRunner(runner))
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+
+	// no hooks - runner is not called
+	_, err := c.Commit(ctx, repository, "master", "commit without hooks", "tester", nil)
+	testutil.MustDo(t, "commit without hooks", err)
+	if len(runner.Events) != 0 {
+		t.Fatalf("Commit() without hooks ran %d events, expected none", len(runner.Events))
+	}
+
+	err = c.CreateHook(ctx, repository, &Hook{Name: "check", Type: HookTypePreCommit, URL: "http://localhost/check"})
+	testutil.MustDo(t, "create hook", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file2", nil, "")
+	testutil.MustDo(t, "delete file1", c.DeleteEntry(ctx, repository, "master", "file1"))
+	meta := Metadata{"key": "value"}
+	_, err = c.Commit(ctx, repository, "master", "commit with hooks", "tester", meta)
+	testutil.MustDo(t, "commit with hooks", err)
+	expectedEvent := &HookEvent{
+		Type:       HookTypePreCommit,
+		Repository: repository,
+		Branch:     "master",
+		Committer:  "tester",
+		Message:    "commit with hooks",
+		Metadata:   meta,
+		Differences: Differences{
+			{Type: DifferenceTypeRemoved, Path: "file1"},
+			{Type: DifferenceTypeAdded, Path: "file2"},
+		},
+	}
+	if diff := deep.Equal(runner.Events, []*HookEvent{expectedEvent}); diff != nil {
+		t.Fatal("Commit() hook events", diff)
+	}
+
+	// rejected by hook - nothing committed
+	runner.RejectType = HookTypePreCommit
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file3", nil, "")
+	_, err = c.Commit(ctx, repository, "master", "rejected commit", "tester", nil)
+	if !errors.Is(err, ErrHookRejected) {
+		t.Fatalf("Commit() rejected by hook err = %v, expected %s", err, ErrHookRejected)
+	}
+	_, err = c.GetEntry(ctx, repository, MakeReference("master", CommittedID), "file3", GetEntryParams{})
+	if !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("GetEntry() of rejected commit entry err = %v, expected not found", err)
+	}
+}
+
+func TestCataloger_CommitImport(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := testutil.GetDB(t, databaseURI)
+	c := NewCataloger(conn, WithEvents(true))
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	commitLog, err := c.CommitImport(ctx, repository, "master", "import", "tester", nil)
+	testutil.MustDo(t, "commit import", err)
+	if len(commitLog.Parents) != 1 {
+		t.Fatalf("import commit parents %v, expected one", commitLog.Parents)
+	}
+
+	// nothing to commit - no events
+	_, err = c.CommitImport(ctx, repository, "master", "empty import", "tester", nil)
+	if !errors.Is(err, ErrNothingToCommit) {
+		t.Fatalf("CommitImport() err = %v, expected %s", err, ErrNothingToCommit)
+	}
+	expected := []EventType{EventTypeRepositoryCreate, EventTypeCommit, EventTypeImport}
+	if diff := deep.Equal(testCatalogerEventTypes(t, conn, repository), expected); diff != nil {
+		t.Fatal("events", diff)
+	}
+}
+
+func TestCataloger_Commit_HooksWithoutRunner(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	err := c.CreateHook(ctx, repository, &Hook{Name: "check", Type: HookTypePreCommit, URL: "http://localhost/check"})
+	testutil.MustDo(t, "create hook", err)
+
+	_, err = c.Commit(ctx, repository, "master", "commit without runner", "tester", nil)
+	if !errors.Is(err, ErrNoHookRunner) {
+		t.Fatalf("Commit() without runner err = %v, expected %s", err, ErrNoHookRunner)
+	}
+	_, err = c.GetEntry(ctx, repository, MakeReference("master", CommittedID), "file1", GetEntryParams{})
+	if !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("GetEntry() of skipped hook commit entry err = %v, expected not found", err)
+	}
+}
+
+func TestCataloger_Commit_HooksOutsideTransaction(t *testing.T) {
+	ctx := context.Background()
+	runner := &testHookRunner{}
+	c := testCataloger(t, WithHookRunner(runner))
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	err := c.CreateHook(ctx, repository, &Hook{Name: "check", Type: HookTypePreCommit, URL: "http://localhost/check"})
+	testutil.MustDo(t, "create hook", err)
+
+	// nothing to commit is reported before calling the hooks
+	_, err = c.Commit(ctx, repository, "master", "empty commit", "tester", nil)
+	if !errors.Is(err, ErrNothingToCommit) {
+		t.Fatalf("Commit() without changes err = %v, expected %s", err, ErrNothingToCommit)
+	}
+	if len(runner.Events) != 0 {
+		t.Fatalf("Commit() without changes ran %d events, expected none", len(runner.Events))
+	}
+
+	// a write while the hooks run is not blocked by the commit, and fails the commit the hooks approved
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	runner.OnRun = func(_ *HookEvent) {
+		err := c.CreateEntry(ctx, repository, "master", Entry{Path: "file2", PhysicalAddress: "addr2"}, CreateEntryParams{IfAbsent: true})
+		testutil.MustDo(t, "create entry while hooks run", err)
+	}
+	_, err = c.Commit(ctx, repository, "master", "changed commit", "tester", nil)
+	if !errors.Is(err, ErrBranchChangedDuringHooks) {
+		t.Fatalf("Commit() changed during hooks err = %v, expected %s", err, ErrBranchChangedDuringHooks)
+	}
+	_, err = c.GetEntry(ctx, repository, MakeReference("master", CommittedID), "file1", GetEntryParams{})
+	if !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("GetEntry() of failed commit entry err = %v, expected not found", err)
+	}
+
+	// the hooks approve the current changes
+	runner.OnRun = nil
+	runner.Events = nil
+	_, err = c.Commit(ctx, repository, "master", "commit", "tester", nil)
+	testutil.MustDo(t, "commit", err)
+	if len(runner.Events) != 1 || len(runner.Events[0].Differences) != 2 {
+		t.Fatalf("Commit() hook events = %v, expected one event with two differences", runner.Events)
+	}
+}
@@ -31,6 +31,16 @@ func (c *cataloger) CreateBranch(ctx context.Context, repository, branch string,
 			return nil, err
 		}
 
+		// branch name can't be used by a tag
+		var tagsCount int
+		if err := tx.Get(&tagsCount, `SELECT COUNT(*) FROM catalog_tags WHERE repository_id=$1 AND name=$2`,
+			repoID, branch); err != nil {
+			return nil, fmt.Errorf("tag name check: %w", err)
+		}
+		if tagsCount > 0 {
+			return nil, fmt.Errorf("branch name used by tag: %w", ErrOperationNotPermitted)
+		}
+
 		// get source branch id and
 		var sourceBranchID int
 		if err := tx.Get(&sourceBranchID, `SELECT id FROM catalog_branches WHERE repository_id=$1 AND name=$2`,
@@ -73,6 +83,16 @@ func (c *cataloger) CreateBranch(ctx context.Context, repository, branch string,
 		}
 		reference := MakeReference(branch, insertReturns.CommitID)
 		parentReference := MakeReference(sourceBranch, insertReturns.MergeSourceCommit)
+		err = c.addEvent(tx, &Event{
+			Type:         EventTypeBranchCreate,
+			Repository:   repository,
+			Branch:       branch,
+			SourceBranch: sourceBranch,
+			Reference:    reference,
+		})
+		if err != nil {
+			return nil, err
+		}
 
 		commitLog := &CommitLog{
 			Committer:    CatalogerCommitter,
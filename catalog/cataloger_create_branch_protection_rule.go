@@ -0,0 +1,34 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+func (c *cataloger) CreateBranchProtectionRule(ctx context.Context, repository, pattern string) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "pattern", IsValid: ValidateBranchProtectionPattern(pattern)},
+	}); err != nil {
+		return err
+	}
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		repoID, err := c.getRepositoryIDCache(tx, repository)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.Exec(`INSERT INTO catalog_branch_protection_rules (repository_id, pattern, creation_date)
+			VALUES ($1, $2, $3)`,
+			repoID, pattern, c.clock.Now())
+		if db.IsUniqueViolation(err) {
+			return nil, ErrBranchProtectionRuleAlreadyExists
+		}
+		if err != nil {
+			return nil, fmt.Errorf("insert branch protection rule: %w", err)
+		}
+		return nil, nil
+	}, c.txOpts(ctx)...)
+	return err
+}
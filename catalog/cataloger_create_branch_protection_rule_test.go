@@ -0,0 +1,106 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_CreateBranchProtectionRule(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	tests := []struct {
+		name    string
+		pattern string
+		wantErr error
+	}{
+		{name: "branch name", pattern: "master", wantErr: nil},
+		{name: "glob", pattern: "release-*", wantErr: nil},
+		{name: "already exists", pattern: "master", wantErr: db.ErrAlreadyExists},
+		{name: "empty", pattern: "", wantErr: ErrInvalidValue},
+		{name: "bad pattern", pattern: "release-[", wantErr: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.CreateBranchProtectionRule(ctx, repository, tt.pattern)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateBranchProtectionRule() err = %v, expected %v", err, tt.wantErr)
+			}
+		})
+	}
+	if err := c.CreateBranchProtectionRule(ctx, "no-repository", "master"); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("CreateBranchProtectionRule() on missing repository err = %v, expected not found", err)
+	}
+}
+
+func TestCataloger_CreateBranchProtectionRule_Enforce(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file0", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	testCatalogerBranch(t, ctx, c, repository, "release-1", "master")
+	testCatalogerBranch(t, ctx, c, repository, "feature", "master")
+	testutil.MustDo(t, "protect master", c.CreateBranchProtectionRule(ctx, repository, "master"))
+	testutil.MustDo(t, "protect releases", c.CreateBranchProtectionRule(ctx, repository, "release-*"))
+
+	for _, branch := range []string{"master", "release-1"} {
+		err := c.CreateEntry(ctx, repository, branch, Entry{Path: "file1", PhysicalAddress: "addr1"}, CreateEntryParams{})
+		if !errors.Is(err, ErrBranchProtected) {
+			t.Errorf("CreateEntry() on %s err = %v, expected %s", branch, err, ErrBranchProtected)
+		}
+		err = c.CreateEntries(ctx, repository, branch, []Entry{{Path: "file1", PhysicalAddress: "addr1"}})
+		if !errors.Is(err, ErrBranchProtected) {
+			t.Errorf("CreateEntries() on %s err = %v, expected %s", branch, err, ErrBranchProtected)
+		}
+		if err := c.DeleteEntry(ctx, repository, branch, "file0"); !errors.Is(err, ErrBranchProtected) {
+			t.Errorf("DeleteEntry() on %s err = %v, expected %s", branch, err, ErrBranchProtected)
+		}
+		if _, err := c.Commit(ctx, repository, branch, "commit", "tester", nil); !errors.Is(err, ErrBranchProtected) {
+			t.Errorf("Commit() on %s err = %v, expected %s", branch, err, ErrBranchProtected)
+		}
+		if err := c.ResetBranch(ctx, repository, branch); !errors.Is(err, ErrBranchProtected) {
+			t.Errorf("ResetBranch() on %s err = %v, expected %s", branch, err, ErrBranchProtected)
+		}
+		if err := c.ResetEntry(ctx, repository, branch, "file0"); !errors.Is(err, ErrBranchProtected) {
+			t.Errorf("ResetEntry() on %s err = %v, expected %s", branch, err, ErrBranchProtected)
+		}
+		if err := c.ResetEntries(ctx, repository, branch, "file"); !errors.Is(err, ErrBranchProtected) {
+			t.Errorf("ResetEntries() on %s err = %v, expected %s", branch, err, ErrBranchProtected)
+		}
+		err = c.CreateMultipartUpload(ctx, repository, branch, "upload-"+branch, "file1", "addr1", time.Now(), nil)
+		if !errors.Is(err, ErrBranchProtected) {
+			t.Errorf("CreateMultipartUpload() on %s err = %v, expected %s", branch, err, ErrBranchProtected)
+		}
+		if err := c.CheckBranchProtection(ctx, repository, branch); !errors.Is(err, ErrBranchProtected) {
+			t.Errorf("CheckBranchProtection() on %s err = %v, expected %s", branch, err, ErrBranchProtected)
+		}
+	}
+	if err := c.CheckBranchProtection(ctx, repository, "feature"); err != nil {
+		t.Errorf("CheckBranchProtection() on feature err = %v, expected none", err)
+	}
+	if err := c.DeleteBranch(ctx, repository, "release-1"); !errors.Is(err, ErrBranchProtected) {
+		t.Errorf("DeleteBranch() err = %v, expected %s", err, ErrBranchProtected)
+	}
+
+	// changes reach a protected branch by merge
+	testCatalogerCreateEntry(t, ctx, c, repository, "feature", "file1", nil, "")
+	_, err = c.Commit(ctx, repository, "feature", "feature commit", "tester", nil)
+	testutil.MustDo(t, "feature commit", err)
+	_, err = c.Merge(ctx, repository, "feature", "master", "tester", "merge feature", nil, MergeParams{})
+	testutil.MustDo(t, "merge feature to master", err)
+	if _, err := c.GetEntry(ctx, repository, MakeReference("master", CommittedID), "file1", GetEntryParams{}); err != nil {
+		t.Fatalf("GetEntry() after merge err = %s, expected entry", err)
+	}
+
+	// unprotected after the rule is removed
+	testutil.MustDo(t, "unprotect releases", c.DeleteBranchProtectionRule(ctx, repository, "release-*"))
+	testutil.MustDo(t, "delete release branch", c.DeleteBranch(ctx, repository, "release-1"))
+}
@@ -33,6 +33,16 @@ func (c *cataloger) CreateEntries(ctx context.Context, repository, branch string
 		if err != nil {
 			return nil, err
 		}
+		if err := checkBranchProtection(tx, repository, branch); err != nil {
+			return nil, err
+		}
+		paths := make([]string, len(entries))
+		for i := range entries {
+			paths[i] = entries[i].Path
+		}
+		if err := lockEntries(tx, branchID, paths...); err != nil {
+			return nil, err
+		}
 		for i := range entries {
 			if _, err := insertEntry(tx, branchID, &entries[i]); err != nil {
 				return nil, fmt.Errorf("entry at %d: %w", i, err)
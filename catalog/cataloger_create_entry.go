@@ -3,6 +3,7 @@ package catalog
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/treeverse/lakefs/db"
@@ -22,6 +23,17 @@ func (c *cataloger) CreateEntry(ctx context.Context, repository, branch string,
 		if err != nil {
 			return nil, err
 		}
+		if err := checkBranchProtection(tx, repository, branch); err != nil {
+			return nil, err
+		}
+		if err := lockEntries(tx, branchID, entry.Path); err != nil {
+			return nil, err
+		}
+		if params.IfAbsent || params.IfChecksum != "" {
+			if err := checkEntryPrecondition(tx, branchID, entry.Path, params); err != nil {
+				return nil, err
+			}
+		}
 		return insertEntry(tx, branchID, &entry)
 	}, c.txOpts(ctx)...)
 	if err != nil {
@@ -41,6 +53,23 @@ func (c *cataloger) CreateEntry(ctx context.Context, repository, branch string,
 	return nil
 }
 
+// checkEntryPrecondition verifies the current entry of path matches the IfAbsent and IfChecksum params
+func checkEntryPrecondition(tx db.Tx, branchID int64, path string, params CreateEntryParams) error {
+	current, err := getEntry(tx, branchID, UncommittedID, path)
+	if errors.Is(err, db.ErrNotFound) {
+		current = nil
+	} else if err != nil {
+		return fmt.Errorf("get current entry: %w", err)
+	}
+	if params.IfAbsent && current != nil {
+		return fmt.Errorf("%w: entry exists", ErrPreconditionFailed)
+	}
+	if params.IfChecksum != "" && (current == nil || current.Checksum != params.IfChecksum) {
+		return fmt.Errorf("%w: entry checksum mismatch", ErrPreconditionFailed)
+	}
+	return nil
+}
+
 func insertEntry(tx db.Tx, branchID int64, entry *Entry) (string, error) {
 	var (
 		ctid   string
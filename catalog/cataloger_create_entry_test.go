@@ -2,13 +2,18 @@ package catalog
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/treeverse/lakefs/db"
 	"github.com/treeverse/lakefs/testutil"
 )
 
@@ -190,6 +195,140 @@ func TestCataloger_CreateEntry(t *testing.T) {
 	}
 }
 
+func TestCataloger_CreateEntry_Precondition(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repo := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testutil.MustDo(t, "create committed entry",
+		c.CreateEntry(ctx, repo, "master", Entry{Path: "/committed", Checksum: "c1", PhysicalAddress: "a1"}, CreateEntryParams{}))
+	_, err := c.Commit(ctx, repo, "master", "commit entry", "tester", nil)
+	testutil.MustDo(t, "commit", err)
+	testutil.MustDo(t, "create uncommitted entry",
+		c.CreateEntry(ctx, repo, "master", Entry{Path: "/uncommitted", Checksum: "u1", PhysicalAddress: "a2"}, CreateEntryParams{}))
+
+	tests := []struct {
+		name    string
+		path    string
+		params  CreateEntryParams
+		wantErr error
+	}{
+		{name: "if absent new", path: "/new", params: CreateEntryParams{IfAbsent: true}},
+		{name: "if absent committed", path: "/committed", params: CreateEntryParams{IfAbsent: true}, wantErr: ErrPreconditionFailed},
+		{name: "if absent uncommitted", path: "/uncommitted", params: CreateEntryParams{IfAbsent: true}, wantErr: ErrPreconditionFailed},
+		{name: "if checksum committed", path: "/committed", params: CreateEntryParams{IfChecksum: "c1"}},
+		{name: "if checksum uncommitted", path: "/uncommitted", params: CreateEntryParams{IfChecksum: "u1"}},
+		{name: "if checksum mismatch", path: "/committed", params: CreateEntryParams{IfChecksum: "u1"}, wantErr: ErrPreconditionFailed},
+		{name: "if checksum missing", path: "/missing", params: CreateEntryParams{IfChecksum: "c1"}, wantErr: ErrPreconditionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.CreateEntry(ctx, repo, "master", Entry{Path: tt.path, Checksum: "new", PhysicalAddress: "new"}, tt.params)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateEntry() error = %v, expected %v", err, tt.wantErr)
+			}
+			ent, err := c.GetEntry(ctx, repo, "master", tt.path, GetEntryParams{})
+			if tt.wantErr != nil {
+				if err == nil && ent.Checksum == "new" {
+					t.Fatal("CreateEntry() failed precondition wrote the entry")
+				}
+				return
+			}
+			testutil.MustDo(t, "get entry", err)
+			if ent.Checksum != "new" {
+				t.Fatalf("entry checksum %s, expected new", ent.Checksum)
+			}
+		})
+	}
+
+	t.Run("concurrent if absent", func(t *testing.T) {
+		const workers = 10
+		var (
+			wg      sync.WaitGroup
+			created int32
+		)
+		wg.Add(workers)
+		for i := 0; i < workers; i++ {
+			go func(id int) {
+				defer wg.Done()
+				err := c.CreateEntry(ctx, repo, "master", Entry{Path: "/race", Checksum: strconv.Itoa(id), PhysicalAddress: strconv.Itoa(id)},
+					CreateEntryParams{IfAbsent: true})
+				if err == nil {
+					atomic.AddInt32(&created, 1)
+				} else if !errors.Is(err, ErrPreconditionFailed) {
+					t.Error("CreateEntry() unexpected error:", err)
+				}
+			}(i)
+		}
+		wg.Wait()
+		if created != 1 {
+			t.Fatalf("CreateEntry() if absent created %d times, expected once", created)
+		}
+	})
+}
+
+func TestCataloger_CreateEntry_EntryLock(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := testutil.GetDB(t, databaseURI)
+	c := NewCataloger(conn)
+	defer func() { _ = c.Close() }()
+	repo := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	// hold the entry lock, like a conditional write between its check and its write
+	locked := make(chan struct{})
+	release := make(chan struct{})
+	lockErr := make(chan error, 1)
+	go func() {
+		_, err := conn.Transact(func(tx db.Tx) (interface{}, error) {
+			branchID, err := getBranchID(tx, repo, "master", LockTypeNone)
+			if err != nil {
+				return nil, err
+			}
+			if err := lockEntries(tx, branchID, "/file1"); err != nil {
+				return nil, err
+			}
+			close(locked)
+			<-release
+			return nil, nil
+		})
+		lockErr <- err
+	}()
+	select {
+	case <-locked:
+	case err := <-lockErr:
+		t.Fatal("lock entry:", err)
+	}
+
+	// an unconditional write waits for the lock
+	done := make(chan error, 1)
+	go func() {
+		done <- c.CreateEntry(ctx, repo, "master", Entry{Path: "/file1", Checksum: "c1", PhysicalAddress: "a1"}, CreateEntryParams{})
+	}()
+	waiting := 0
+	for start := time.Now(); waiting == 0 && time.Since(start) < 3*time.Second; time.Sleep(10 * time.Millisecond) {
+		_, err := conn.Transact(func(tx db.Tx) (interface{}, error) {
+			return nil, tx.Get(&waiting, `SELECT COUNT(*) FROM pg_locks WHERE locktype='advisory' AND NOT granted`)
+		})
+		testutil.MustDo(t, "count waiting locks", err)
+	}
+	select {
+	case err := <-done:
+		t.Fatalf("CreateEntry() completed while the entry is locked, err = %v", err)
+	default:
+	}
+	if waiting == 0 {
+		t.Fatal("CreateEntry() is not waiting for the entry lock")
+	}
+
+	close(release)
+	testutil.MustDo(t, "lock entry", <-lockErr)
+	select {
+	case err := <-done:
+		testutil.MustDo(t, "create entry", err)
+	case <-time.After(3 * time.Second):
+		t.Fatal("timeout waiting for CreateEntry()")
+	}
+}
+
 func TestCataloger_CreateEntry_Dedup(t *testing.T) {
 	ctx := context.Background()
 	c := testCataloger(t)
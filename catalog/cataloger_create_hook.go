@@ -0,0 +1,45 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+func (c *cataloger) CreateHook(ctx context.Context, repository string, hook *Hook) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "name", IsValid: ValidateHookName(hook.Name)},
+		{Name: "type", IsValid: ValidateHookType(hook.Type)},
+		{Name: "url", IsValid: ValidateHookURL(hook.URL)},
+		{Name: "timeout", IsValid: ValidateHookTimeout(hook.Timeout)},
+	}); err != nil {
+		return err
+	}
+	timeout := hook.Timeout
+	if timeout == 0 {
+		timeout = DefaultHookTimeout
+	}
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		repoID, err := c.getRepositoryIDCache(tx, repository)
+		if err != nil {
+			return nil, err
+		}
+		creationDate := c.clock.Now()
+		_, err = tx.Exec(`INSERT INTO catalog_hooks (repository_id, name, hook_type, url, timeout_ms, creation_date)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			repoID, hook.Name, hook.Type, hook.URL, timeout.Milliseconds(), creationDate)
+		if db.IsUniqueViolation(err) {
+			return nil, ErrHookAlreadyExists
+		}
+		if err != nil {
+			return nil, fmt.Errorf("insert hook: %w", err)
+		}
+		hook.Repository = repository
+		hook.Timeout = timeout
+		hook.CreationDate = creationDate
+		return nil, nil
+	}, c.txOpts(ctx)...)
+	return err
+}
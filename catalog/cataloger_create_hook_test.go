@@ -0,0 +1,66 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+func TestCataloger_CreateHook(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	tests := []struct {
+		name        string
+		hook        Hook
+		wantTimeout time.Duration
+		wantErr     error
+	}{
+		{
+			name:        "pre-commit",
+			hook:        Hook{Name: "schema", Type: HookTypePreCommit, URL: "http://localhost:8080/schema", Timeout: 5 * time.Second},
+			wantTimeout: 5 * time.Second,
+		},
+		{
+			name:        "default timeout",
+			hook:        Hook{Name: "rows", Type: HookTypePreMerge, URL: "https://checks.example.com/rows"},
+			wantTimeout: DefaultHookTimeout,
+		},
+		{
+			name:    "already exists",
+			hook:    Hook{Name: "schema", Type: HookTypePreMerge, URL: "http://localhost:8080/schema"},
+			wantErr: db.ErrAlreadyExists,
+		},
+		{
+			name:    "unknown type",
+			hook:    Hook{Name: "post", Type: "post-commit", URL: "http://localhost:8080/post"},
+			wantErr: ErrInvalidValue,
+		},
+		{
+			name:    "bad url",
+			hook:    Hook{Name: "bad", Type: HookTypePreCommit, URL: "localhost:8080"},
+			wantErr: ErrInvalidValue,
+		},
+		{
+			name:    "negative timeout",
+			hook:    Hook{Name: "negative", Type: HookTypePreCommit, URL: "http://localhost", Timeout: -time.Second},
+			wantErr: ErrInvalidValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := tt.hook
+			err := c.CreateHook(ctx, repository, &hook)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateHook() err = %v, expected %v", err, tt.wantErr)
+			}
+			if err == nil && hook.Timeout != tt.wantTimeout {
+				t.Fatalf("CreateHook() timeout %s, expected %s", hook.Timeout, tt.wantTimeout)
+			}
+		})
+	}
+}
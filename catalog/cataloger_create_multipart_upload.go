@@ -7,9 +7,10 @@ import (
 	"github.com/treeverse/lakefs/db"
 )
 
-func (c *cataloger) CreateMultipartUpload(ctx context.Context, repository string, uploadID, path, physicalAddress string, creationTime time.Time) error {
+func (c *cataloger) CreateMultipartUpload(ctx context.Context, repository, branch string, uploadID, path, physicalAddress string, creationTime time.Time, metadata Metadata) error {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", IsValid: ValidateBranchName(branch)},
 		{Name: "uploadID", IsValid: ValidateUploadID(uploadID)},
 		{Name: "path", IsValid: ValidatePath(path)},
 		{Name: "physicalAddress", IsValid: ValidatePhysicalAddress(physicalAddress)},
@@ -22,9 +23,16 @@ func (c *cataloger) CreateMultipartUpload(ctx context.Context, repository string
 		if err != nil {
 			return nil, err
 		}
-		_, err = tx.Exec(`INSERT INTO catalog_multipart_uploads (repository_id,upload_id,path,creation_date,physical_address)
-			VALUES ($1, $2, $3, $4, $5)`,
-			repoID, uploadID, path, creationTime, physicalAddress)
+		branchID, err := c.getBranchIDCache(tx, repository, branch)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkBranchProtection(tx, repository, branch); err != nil {
+			return nil, err
+		}
+		_, err = tx.Exec(`INSERT INTO catalog_multipart_uploads (repository_id,branch_id,upload_id,path,creation_date,physical_address,metadata)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			repoID, branchID, uploadID, path, creationTime, physicalAddress, metadata)
 		return nil, err
 	}, c.txOpts(ctx)...)
 	return err
@@ -56,6 +56,14 @@ func (c *cataloger) CreateRepository(ctx context.Context, repository string, sto
 		if err != nil {
 			return nil, fmt.Errorf("insert commit: %w", err)
 		}
+		err = c.addEvent(tx, &Event{
+			Type:       EventTypeRepositoryCreate,
+			Repository: repository,
+			Branch:     branch,
+		})
+		if err != nil {
+			return nil, err
+		}
 		return repoID, nil
 	}, c.txOpts(ctx)...)
 	return err
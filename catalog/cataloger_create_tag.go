@@ -0,0 +1,79 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+func (c *cataloger) CreateTag(ctx context.Context, repository, tag string, reference string) (*Tag, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "tag", IsValid: ValidateTagName(tag)},
+		{Name: "reference", IsValid: ValidateReference(reference)},
+	}); err != nil {
+		return nil, err
+	}
+	ref, err := ParseRef(reference)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		repoID, err := c.getRepositoryIDCache(tx, repository)
+		if err != nil {
+			return nil, err
+		}
+
+		// tag name can't be used by a branch - references are resolved to branches first
+		var branchCount int
+		err = tx.Get(&branchCount, `SELECT COUNT(*) FROM catalog_branches WHERE repository_id=$1 AND name=$2`,
+			repoID, tag)
+		if err != nil {
+			return nil, fmt.Errorf("branch name check: %w", err)
+		}
+		if branchCount > 0 {
+			return nil, fmt.Errorf("tag name used by branch: %w", ErrOperationNotPermitted)
+		}
+
+		ref, branchID, err := c.getRefBranchIDCache(tx, repository, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		// tag a branch reference by its last commit
+		commitID := ref.CommitID
+		if commitID <= UncommittedID {
+			commitID, err = getLastCommitIDByBranchID(tx, branchID)
+			if err != nil {
+				return nil, fmt.Errorf("last commit id: %w", err)
+			}
+		}
+		creationDate := c.clock.Now()
+		res, err := tx.Exec(`INSERT INTO catalog_tags (repository_id, name, branch_id, commit_id, creation_date)
+			SELECT $1, $2, branch_id, commit_id, $5
+			FROM catalog_commits WHERE branch_id=$3 AND commit_id=$4`,
+			repoID, tag, branchID, commitID, creationDate)
+		if db.IsUniqueViolation(err) {
+			return nil, ErrTagAlreadyExists
+		}
+		if err != nil {
+			return nil, fmt.Errorf("insert tag: %w", err)
+		}
+		if affected, err := res.RowsAffected(); err != nil {
+			return nil, err
+		} else if affected != 1 {
+			return nil, ErrCommitNotFound
+		}
+		return &Tag{
+			Repository:   repository,
+			Name:         tag,
+			Reference:    MakeReference(ref.Branch, commitID),
+			CreationDate: creationDate,
+		}, nil
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*Tag), nil
+}
@@ -0,0 +1,132 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_CreateTag(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+
+	type args struct {
+		repository string
+		tag        string
+		reference  string
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantReference string
+		wantErr       error
+	}{
+		{
+			name:          "commit reference",
+			args:          args{repository: repository, tag: "v1", reference: commitLog.Reference},
+			wantReference: commitLog.Reference,
+		},
+		{
+			name:          "branch",
+			args:          args{repository: repository, tag: "v2", reference: "master"},
+			wantReference: commitLog.Reference,
+		},
+		{
+			name:          "tag",
+			args:          args{repository: repository, tag: "v3", reference: "v1"},
+			wantReference: commitLog.Reference,
+		},
+		{
+			name:    "exists",
+			args:    args{repository: repository, tag: "v1", reference: "master"},
+			wantErr: ErrTagAlreadyExists,
+		},
+		{
+			name:    "branch name",
+			args:    args{repository: repository, tag: "branch1", reference: "master"},
+			wantErr: ErrOperationNotPermitted,
+		},
+		{
+			name:    "unknown reference",
+			args:    args{repository: repository, tag: "v4", reference: "no-branch"},
+			wantErr: db.ErrNotFound,
+		},
+		{
+			name:    "unknown commit",
+			args:    args{repository: repository, tag: "v4", reference: MakeReference("master", 9999)},
+			wantErr: ErrCommitNotFound,
+		},
+		{
+			name:    "invalid tag",
+			args:    args{repository: repository, tag: "v4/1", reference: "master"},
+			wantErr: ErrInvalidValue,
+		},
+		{
+			name:    "unknown repository",
+			args:    args{repository: "repo2", tag: "v4", reference: "master"},
+			wantErr: db.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.CreateTag(ctx, tt.args.repository, tt.args.tag, tt.args.reference)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Name != tt.args.tag {
+				t.Errorf("CreateTag() name = %s, want %s", got.Name, tt.args.tag)
+			}
+			if got.Reference != tt.wantReference {
+				t.Errorf("CreateTag() reference = %s, want %s", got.Reference, tt.wantReference)
+			}
+		})
+	}
+}
+
+func TestCataloger_CreateTag_ResolveReference(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	_, err = c.CreateTag(ctx, repository, "v1", "master")
+	testutil.MustDo(t, "create tag", err)
+
+	// changes after the tag are not visible through the tag
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "seed1")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file2", nil, "")
+	_, err = c.Commit(ctx, repository, "master", "second commit", "tester", nil)
+	testutil.MustDo(t, "second commit", err)
+
+	testVerifyEntries(t, ctx, c, repository, "v1", []testEntryInfo{
+		{Path: "/file1"},
+		{Path: "/file2", Deleted: true},
+	})
+	entries, _, err := c.ListEntries(ctx, repository, "v1", "", "", "", -1)
+	testutil.MustDo(t, "list entries by tag", err)
+	if len(entries) != 1 || entries[0].Path != "/file1" {
+		t.Fatalf("ListEntries by tag = %+v, expected /file1", entries)
+	}
+	entries, _, err = c.ListEntries(ctx, repository, "v1", "", "", DefaultPathDelimiter, -1)
+	testutil.MustDo(t, "list entries by level by tag", err)
+	if len(entries) != 1 || entries[0].Path != "/file1" {
+		t.Fatalf("ListEntries by level by tag = %+v, expected /file1", entries)
+	}
+	commit, err := c.GetCommit(ctx, repository, "v1")
+	testutil.MustDo(t, "get commit by tag", err)
+	if commit.Reference != commitLog.Reference {
+		t.Fatalf("GetCommit by tag reference = %s, expected %s", commit.Reference, commitLog.Reference)
+	}
+}
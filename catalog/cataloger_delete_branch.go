@@ -20,14 +20,17 @@ func (c *cataloger) DeleteBranch(ctx context.Context, repository, branch string)
 		if err != nil {
 			return nil, err
 		}
+		if err := checkBranchProtection(tx, repository, branch); err != nil {
+			return nil, err
+		}
 
-		// default branch doesn't have parents
-		var legacyCount int
-		err = tx.Get(&legacyCount, `SELECT array_length(lineage,1) FROM catalog_branches WHERE id=$1`, branchID)
+		// default branch can be changed, but not deleted
+		var defaultCount int
+		err = tx.Get(&defaultCount, `SELECT count(*) FROM catalog_repositories WHERE default_branch=$1`, branchID)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("default branch check: %w", err)
 		}
-		if legacyCount == 0 {
+		if defaultCount > 0 {
 			return nil, fmt.Errorf("delete default branch: %w", ErrOperationNotPermitted)
 		}
 
@@ -43,6 +46,16 @@ func (c *cataloger) DeleteBranch(ctx context.Context, repository, branch string)
 			return nil, fmt.Errorf("branch has dependent branch: %w", ErrOperationNotPermitted)
 		}
 
+		// tags point to commits on the branch
+		var tagsCount int
+		err = tx.Get(&tagsCount, `SELECT count(*) FROM catalog_tags WHERE branch_id=$1`, branchID)
+		if err != nil {
+			return nil, fmt.Errorf("tags check: %w", err)
+		}
+		if tagsCount > 0 {
+			return nil, fmt.Errorf("branch has tags: %w", ErrOperationNotPermitted)
+		}
+
 		// delete branch entries
 		_, err = tx.Exec(`DELETE FROM catalog_entries WHERE branch_id=$1`, branchID)
 		if err != nil {
@@ -59,7 +72,11 @@ func (c *cataloger) DeleteBranch(ctx context.Context, repository, branch string)
 		} else if affected != 1 {
 			return nil, ErrBranchNotFound
 		}
-		return nil, nil
+		return nil, c.addEvent(tx, &Event{
+			Type:       EventTypeBranchDelete,
+			Repository: repository,
+			Branch:     branch,
+		})
 	}, c.txOpts(ctx)...)
 	return err
 }
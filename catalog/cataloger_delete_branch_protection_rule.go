@@ -0,0 +1,34 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+func (c *cataloger) DeleteBranchProtectionRule(ctx context.Context, repository, pattern string) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "pattern", IsValid: ValidateBranchProtectionPattern(pattern)},
+	}); err != nil {
+		return err
+	}
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		repoID, err := c.getRepositoryIDCache(tx, repository)
+		if err != nil {
+			return nil, err
+		}
+		res, err := tx.Exec(`DELETE FROM catalog_branch_protection_rules WHERE repository_id=$1 AND pattern=$2`,
+			repoID, pattern)
+		if err != nil {
+			return nil, err
+		}
+		if affected, err := res.RowsAffected(); err != nil {
+			return nil, err
+		} else if affected != 1 {
+			return nil, ErrBranchProtectionRuleNotFound
+		}
+		return nil, nil
+	}, c.txOpts(ctx)...)
+	return err
+}
@@ -0,0 +1,27 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_DeleteBranchProtectionRule(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testutil.MustDo(t, "create rule", c.CreateBranchProtectionRule(ctx, repository, "master"))
+
+	testutil.MustDo(t, "delete rule", c.DeleteBranchProtectionRule(ctx, repository, "master"))
+	if err := c.DeleteBranchProtectionRule(ctx, repository, "master"); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("DeleteBranchProtectionRule() of deleted rule err = %v, expected not found", err)
+	}
+	rules, err := c.ListBranchProtectionRules(ctx, repository)
+	testutil.MustDo(t, "list rules", err)
+	if len(rules) != 0 {
+		t.Fatalf("ListBranchProtectionRules() after delete got %d rules, expected none", len(rules))
+	}
+}
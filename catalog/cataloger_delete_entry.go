@@ -24,55 +24,66 @@ func (c *cataloger) DeleteEntry(ctx context.Context, repository, branch string,
 		if err != nil {
 			return nil, err
 		}
-
-		// delete uncommitted entry, if found first
-		res, err := tx.Exec("DELETE FROM catalog_entries WHERE branch_id=$1 AND path=$2 AND min_commit=0 AND max_commit=catalog_max_commit_id()",
-			branchID, path)
-		if err != nil {
-			return nil, fmt.Errorf("uncommitted: %w", err)
-		}
-		deletedUncommittedCount, err := res.RowsAffected()
-		if err != nil {
-			return nil, fmt.Errorf("rows affected: %w", err)
-		}
-
-		// get uncommitted entry based on path
-		lineage, err := getLineage(tx, branchID, UncommittedID)
-		if err != nil {
-			return nil, fmt.Errorf("get lineage: %w", err)
-		}
-		sql, args, err := psql.
-			Select("is_committed").
-			FromSelect(sqEntriesLineage(branchID, UncommittedID, lineage), "entries").
-			// Expired objects *can* be successfully deleted!
-			Where(sq.Eq{"path": path, "is_deleted": false}).
-			ToSql()
-		if err != nil {
-			return nil, fmt.Errorf("build sql: %w", err)
-		}
-		var isCommitted bool
-		err = tx.Get(&isCommitted, sql, args...)
-		committedNotFound := errors.Is(err, db.ErrNotFound)
-		if err != nil && !committedNotFound {
+		if err := checkBranchProtection(tx, repository, branch); err != nil {
 			return nil, err
 		}
-		// 1. found committed record - add tombstone and return success
-		// 2. not found committed record:
-		//    - if we deleted uncommitted - return success
-		//    - if we didn't delete uncommitted - return not found
-		if isCommitted {
-			_, err = tx.Exec(`INSERT INTO catalog_entries (branch_id,path,physical_address,checksum,size,metadata,min_commit,max_commit)
-					VALUES ($1,$2,'','',0,'{}',0,0)`,
-				branchID, path)
-			if err != nil {
-				return nil, fmt.Errorf("tombstone: %w", err)
-			}
-			return nil, nil
-		}
-		if deletedUncommittedCount == 0 {
-			return nil, ErrEntryNotFound
+		if err := lockEntries(tx, branchID, path); err != nil {
+			return nil, err
 		}
-		return nil, nil
+		return nil, deleteEntry(tx, branchID, path)
 	}, c.txOpts(ctx)...)
 	return err
 }
+
+// deleteEntry deletes the uncommitted entry of path, and hides the committed entry of path with a tombstone.
+// Returns ErrEntryNotFound when path has no entry to delete.
+func deleteEntry(tx db.Tx, branchID int64, path string) error {
+	// delete uncommitted entry, if found first
+	res, err := tx.Exec("DELETE FROM catalog_entries WHERE branch_id=$1 AND path=$2 AND min_commit=0 AND max_commit=catalog_max_commit_id()",
+		branchID, path)
+	if err != nil {
+		return fmt.Errorf("uncommitted: %w", err)
+	}
+	deletedUncommittedCount, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+
+	// get uncommitted entry based on path
+	lineage, err := getLineage(tx, branchID, UncommittedID)
+	if err != nil {
+		return fmt.Errorf("get lineage: %w", err)
+	}
+	sql, args, err := psql.
+		Select("is_committed").
+		FromSelect(sqEntriesLineage(branchID, UncommittedID, lineage), "entries").
+		// Expired objects *can* be successfully deleted!
+		Where(sq.Eq{"path": path, "is_deleted": false}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build sql: %w", err)
+	}
+	var isCommitted bool
+	err = tx.Get(&isCommitted, sql, args...)
+	committedNotFound := errors.Is(err, db.ErrNotFound)
+	if err != nil && !committedNotFound {
+		return err
+	}
+	// 1. found committed record - add tombstone and return success
+	// 2. not found committed record:
+	//    - if we deleted uncommitted - return success
+	//    - if we didn't delete uncommitted - return not found
+	if isCommitted {
+		_, err = tx.Exec(`INSERT INTO catalog_entries (branch_id,path,physical_address,checksum,size,metadata,min_commit,max_commit)
+				VALUES ($1,$2,'','',0,'{}',0,0)`,
+			branchID, path)
+		if err != nil {
+			return fmt.Errorf("tombstone: %w", err)
+		}
+		return nil
+	}
+	if deletedUncommittedCount == 0 {
+		return ErrEntryNotFound
+	}
+	return nil
+}
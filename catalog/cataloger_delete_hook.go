@@ -0,0 +1,33 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+func (c *cataloger) DeleteHook(ctx context.Context, repository, name string) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "name", IsValid: ValidateHookName(name)},
+	}); err != nil {
+		return err
+	}
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		repoID, err := c.getRepositoryIDCache(tx, repository)
+		if err != nil {
+			return nil, err
+		}
+		res, err := tx.Exec(`DELETE FROM catalog_hooks WHERE repository_id=$1 AND name=$2`, repoID, name)
+		if err != nil {
+			return nil, err
+		}
+		if affected, err := res.RowsAffected(); err != nil {
+			return nil, err
+		} else if affected != 1 {
+			return nil, ErrHookNotFound
+		}
+		return nil, nil
+	}, c.txOpts(ctx)...)
+	return err
+}
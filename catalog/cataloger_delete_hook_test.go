@@ -0,0 +1,28 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_DeleteHook(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	err := c.CreateHook(ctx, repository, &Hook{Name: "check", Type: HookTypePreCommit, URL: "http://localhost/check"})
+	testutil.MustDo(t, "create hook", err)
+
+	testutil.MustDo(t, "delete hook", c.DeleteHook(ctx, repository, "check"))
+	if err := c.DeleteHook(ctx, repository, "check"); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("DeleteHook() of deleted hook err = %v, expected not found", err)
+	}
+	hooks, err := c.ListHooks(ctx, repository)
+	testutil.MustDo(t, "list hooks", err)
+	if len(hooks) != 0 {
+		t.Fatalf("ListHooks() after delete got %d hooks, expected none", len(hooks))
+	}
+}
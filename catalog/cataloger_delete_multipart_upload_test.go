@@ -14,7 +14,7 @@ func TestCataloger_DeleteMultipartUpload(t *testing.T) {
 	if err := c.CreateRepository(ctx, "repo1", "s3://bucket1", "master"); err != nil {
 		t.Fatal("create repository for testing", err)
 	}
-	if err := c.CreateMultipartUpload(ctx, "repo1", "uploadX", "/pathX", "/fileX", time.Now()); err != nil {
+	if err := c.CreateMultipartUpload(ctx, "repo1", "master", "uploadX", "/pathX", "/fileX", time.Now(), nil); err != nil {
 		t.Fatal("create multipart upload for testing", err)
 	}
 
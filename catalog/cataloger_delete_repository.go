@@ -23,7 +23,10 @@ func (c *cataloger) DeleteRepository(ctx context.Context, repository string) err
 		} else if affected != 1 {
 			return nil, ErrRepositoryNotFound
 		}
-		return nil, nil
+		return nil, c.addEvent(tx, &Event{
+			Type:       EventTypeRepositoryDelete,
+			Repository: repository,
+		})
 	}, c.txOpts(ctx)...)
 	return err
 }
@@ -0,0 +1,48 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_DeleteTag(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	_, err = c.CreateTag(ctx, repository, "v1", "master")
+	testutil.MustDo(t, "create tag", err)
+
+	testutil.MustDo(t, "delete tag", c.DeleteTag(ctx, repository, "v1"))
+	if _, err := c.GetTag(ctx, repository, "v1"); !errors.Is(err, ErrTagNotFound) {
+		t.Fatalf("GetTag() after delete err = %v, expected %s", err, ErrTagNotFound)
+	}
+	if err := c.DeleteTag(ctx, repository, "v1"); !errors.Is(err, db.ErrNotFound) {
+		t.Fatalf("DeleteTag() of deleted tag err = %v, expected not found", err)
+	}
+	// tag name can be reused after delete
+	_, err = c.CreateTag(ctx, repository, "v1", "master")
+	testutil.MustDo(t, "create tag after delete", err)
+}
+
+func TestCataloger_DeleteTag_BranchWithTag(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	_, err := c.CreateTag(ctx, repository, "v1", "branch1")
+	testutil.MustDo(t, "create tag", err)
+
+	// branch can't be deleted while it has tags
+	if err := c.DeleteBranch(ctx, repository, "branch1"); !errors.Is(err, ErrOperationNotPermitted) {
+		t.Fatalf("DeleteBranch() with tag err = %v, expected %s", err, ErrOperationNotPermitted)
+	}
+	testutil.MustDo(t, "delete tag", c.DeleteTag(ctx, repository, "v1"))
+	testutil.MustDo(t, "delete branch", c.DeleteBranch(ctx, repository, "branch1"))
+}
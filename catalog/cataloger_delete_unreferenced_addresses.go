@@ -0,0 +1,74 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/treeverse/lakefs/db"
+)
+
+// deleteDedupBatchSize is the number of dedup records deleted by a single statement
+const deleteDedupBatchSize = 1000
+
+func (c *cataloger) DeleteUnreferencedAddresses(ctx context.Context, since time.Time, addresses []string) ([]string, error) {
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		// dedup inserts into catalog_object_dedup before it points an entry to an existing address.  Taking a
+		// lock that conflicts with its row exclusive lock, before the first query, makes every dedup either
+		// visible to the references check below or run after the dedup records are deleted.
+		if _, err := tx.Exec(`LOCK TABLE catalog_object_dedup IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+			return nil, fmt.Errorf("lock dedup: %w", err)
+		}
+		unreferenced := make(map[string]struct{}, len(addresses))
+		for _, address := range addresses {
+			unreferenced[address] = struct{}{}
+		}
+		var refs []ReferencedAddress
+		if err := tx.Select(&refs, referencedAddressesQuery, MaxCommitID, since); err != nil {
+			return nil, fmt.Errorf("referenced addresses: %w", err)
+		}
+		for _, ref := range refs {
+			delete(unreferenced, ref.PhysicalAddress)
+			if ref.UploadID == "" {
+				continue
+			}
+			// parts of a multipart upload in progress
+			for address := range unreferenced {
+				if strings.HasPrefix(address, ref.UploadID) {
+					delete(unreferenced, address)
+				}
+			}
+		}
+		result := make([]string, 0, len(unreferenced))
+		for _, address := range addresses {
+			if _, ok := unreferenced[address]; ok {
+				result = append(result, address)
+			}
+		}
+		for i := 0; i < len(result); i += deleteDedupBatchSize {
+			end := i + deleteDedupBatchSize
+			if end > len(result) {
+				end = len(result)
+			}
+			sql, args, err := psql.Delete("catalog_object_dedup").
+				Where(sq.Eq{"physical_address": result[i:end]}).
+				ToSql()
+			if err != nil {
+				return nil, fmt.Errorf("build sql: %w", err)
+			}
+			if _, err := tx.Exec(sql, args...); err != nil {
+				return nil, fmt.Errorf("delete dedup: %w", err)
+			}
+		}
+		return result, nil
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+	return res.([]string), nil
+}
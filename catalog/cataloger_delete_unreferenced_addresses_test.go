@@ -0,0 +1,62 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_DeleteUnreferencedAddresses(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	defer func() { _ = c.Close() }()
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	// create entries with the same dedup id and return the address the last one was deduplicated to
+	createDedupEntries := func(addresses ...string) string {
+		for _, address := range addresses {
+			err := c.CreateEntry(ctx, repository, "master", Entry{
+				Path:            "file" + address,
+				PhysicalAddress: address,
+				CreationDate:    time.Now(),
+				Checksum:        "aa",
+			}, CreateEntryParams{Dedup: DedupParams{ID: "aa", StorageNamespace: "s1"}})
+			testutil.MustDo(t, "create entry "+address, err)
+		}
+		select {
+		case report := <-c.DedupReportChannel():
+			return report.NewPhysicalAddress
+		case <-time.After(3 * time.Second):
+			t.Fatal("timeout waiting for dedup report")
+		}
+		return ""
+	}
+
+	if address := createDedupEntries("1", "2"); address != "1" {
+		t.Fatalf("dedup to address %s, expected 1", address)
+	}
+	// address 1 is referenced, its dedup record is kept
+	addresses, err := c.DeleteUnreferencedAddresses(ctx, time.Now(), []string{"1", "x"})
+	testutil.MustDo(t, "delete unreferenced addresses", err)
+	if diff := deep.Equal(addresses, []string{"x"}); diff != nil {
+		t.Fatal("unreferenced addresses", diff)
+	}
+	if address := createDedupEntries("3"); address != "1" {
+		t.Fatalf("dedup to address %s, expected 1", address)
+	}
+
+	for _, path := range []string{"file1", "file2", "file3"} {
+		testutil.MustDo(t, "delete "+path, c.DeleteEntry(ctx, repository, "master", path))
+	}
+	addresses, err = c.DeleteUnreferencedAddresses(ctx, time.Now(), []string{"1", "x"})
+	testutil.MustDo(t, "delete unreferenced addresses", err)
+	if diff := deep.Equal(addresses, []string{"1", "x"}); diff != nil {
+		t.Fatal("unreferenced addresses after delete", diff)
+	}
+	if address := createDedupEntries("4", "5"); address != "4" {
+		t.Fatalf("dedup to address %s after delete, expected 4", address)
+	}
+}
@@ -10,42 +10,204 @@ import (
 	"github.com/treeverse/lakefs/logging"
 )
 
-const diffResultsTableName = "catalog_diff_results"
+const (
+	diffResultsTableName = "catalog_diff_results"
+	DiffMaxLimit         = 10000
+)
 
-func (c *cataloger) Diff(ctx context.Context, repository string, leftBranch string, rightBranch string) (Differences, error) {
+// Diff returns a page of the changes on the left reference compared to the right reference.  When both references
+// are branches, the left entries are read from the left branch last commit, the right entries from the right branch
+// including uncommitted changes, and the branches relation decides what is compared.  A reference to a commit, such
+// as a tag, is compared by the entries of that commit.
+func (c *cataloger) Diff(ctx context.Context, repository string, leftReference string, rightReference string, params DiffParams) (*DiffResult, error) {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "leftBranch", IsValid: ValidateBranchName(leftBranch)},
-		{Name: "rightBranch", IsValid: ValidateBranchName(rightBranch)},
+		{Name: "leftReference", IsValid: ValidateReference(leftReference)},
+		{Name: "rightReference", IsValid: ValidateReference(rightReference)},
 	}); err != nil {
 		return nil, err
 	}
-	differences, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
-		leftID, err := c.getBranchIDCache(tx, repository, leftBranch)
+	leftRef, err := ParseRef(leftReference)
+	if err != nil {
+		return nil, fmt.Errorf("left reference: %w", err)
+	}
+	rightRef, err := ParseRef(rightReference)
+	if err != nil {
+		return nil, fmt.Errorf("right reference: %w", err)
+	}
+	limit := diffLimit(params.Limit)
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		leftRef, leftID, err := c.getRefBranchIDCache(tx, repository, leftRef)
+		if err != nil {
+			return nil, fmt.Errorf("left reference: %w", err)
+		}
+		rightRef, rightID, err := c.getRefBranchIDCache(tx, repository, rightRef)
 		if err != nil {
-			return nil, fmt.Errorf("left branch: %w", err)
+			return nil, fmt.Errorf("right reference: %w", err)
 		}
-		rightID, err := c.getBranchIDCache(tx, repository, rightBranch)
+		leftCommitID := CommittedID
+		if leftRef.CommitID > UncommittedID {
+			leftCommitID = leftRef.CommitID
+		}
+		rightCommitID := rightRef.CommitID
+		leftLineage, err := getLineage(tx, leftID, leftCommitID)
+		if err != nil {
+			return nil, fmt.Errorf("left lineage failed: %w", err)
+		}
+		rightLineage, err := getLineage(tx, rightID, rightCommitID)
 		if err != nil {
-			return nil, fmt.Errorf("right branch: %w", err)
+			return nil, fmt.Errorf("right lineage failed: %w", err)
+		}
+		leftQ := sqEntriesLineage(leftID, leftCommitID, leftLineage)
+		rightQ := sqEntriesLineage(rightID, rightCommitID, rightLineage)
+		var diffQ diffQueryFunc
+		if leftRef.CommitID == UncommittedID && rightRef.CommitID == UncommittedID {
+			relation, err := getBranchesRelationType(tx, leftID, rightID)
+			if err != nil {
+				return nil, err
+			}
+			diffQ, err = c.diffQueryByRelation(tx, relation, leftID, rightID)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			diffQ = func(filter diffPathFilter) sq.SelectBuilder {
+				return sqDiffSnapshotsV(leftQ, rightQ, filter)
+			}
 		}
-		return c.doDiff(tx, leftID, rightID)
-	}, c.txOpts(ctx)...)
+		return diffReadResult(tx, diffQ, leftQ, rightQ, params, limit)
+	}, c.txOpts(ctx, db.ReadOnly())...)
 	if err != nil {
 		return nil, err
 	}
-	return differences.(Differences), nil
+	return res.(*DiffResult), nil
+}
+
+// diffPathFilter limits the entries compared by a diff query to the paths that start with Prefix and come after
+// After.  The filter is applied on each set of entries before they are compared, so the database reads only the
+// entries of the requested page.
+type diffPathFilter struct {
+	Prefix string
+	After  string
+}
+
+// where adds the filter conditions on the path column to q
+func (f diffPathFilter) where(q sq.SelectBuilder, column string) sq.SelectBuilder {
+	if f.Prefix != "" {
+		q = q.Where(column+" LIKE ?", db.Prefix(f.Prefix))
+	}
+	if f.After != "" {
+		q = q.Where(sq.Gt{column: f.After})
+	}
+	return q
 }
 
-func (c *cataloger) doDiff(tx db.Tx, leftID, rightID int64) (Differences, error) {
-	relation, err := getBranchesRelationType(tx, leftID, rightID)
+// diffQueryFunc returns a query that selects the diff_type and path of the differences on the paths that match
+// the filter
+type diffQueryFunc func(filter diffPathFilter) sq.SelectBuilder
+
+// createDiffResults fills the diff results table with the differences selected by diffQ
+func createDiffResults(tx db.Tx, diffQ sq.SelectBuilder) error {
+	diffSQL, args, err := diffQ.
+		Prefix("CREATE TEMP TABLE " + diffResultsTableName + " ON COMMIT DROP AS").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("diff results sql: %w", err)
+	}
+	if _, err := tx.Exec(diffSQL, args...); err != nil {
+		return fmt.Errorf("create diff results: %w", err)
 	}
-	return c.doDiffByRelation(tx, relation, leftID, rightID)
+	return nil
 }
 
-func (c *cataloger) doDiffByRelation(tx db.Tx, relation RelationType, leftID, rightID int64) (Differences, error) {
+// diffLimit returns the page size used by Diff and DiffUncommitted for the requested limit
+func diffLimit(limit int) int {
+	if limit < 0 || limit > DiffMaxLimit {
+		return DiffMaxLimit
+	}
+	return limit
+}
+
+// diffReadResult reads a single page of differences, ordered by path, from the diff query. Each difference is
+// completed with the path's entry from the left and right lineage queries.  When requested, the summary is
+// calculated over all the differences that match the params filter.
+func diffReadResult(tx db.Tx, diffQ diffQueryFunc, leftQ, rightQ sq.SelectBuilder, params DiffParams, limit int) (*DiffResult, error) {
+	typesFilter := func(q sq.SelectBuilder) sq.SelectBuilder {
+		q = sq.Select("diff_type", "path").FromSelect(q, "d")
+		if len(params.DifferenceTypes) > 0 {
+			q = q.Where(sq.Eq{"diff_type": params.DifferenceTypes})
+		}
+		return q
+	}
+	pageQ := typesFilter(diffQ(diffPathFilter{Prefix: params.Prefix, After: params.After}))
+	pageSQL, args, err := psql.Select("diff_type", "path").
+		FromSelect(pageQ, "f").
+		OrderBy("path").
+		Limit(uint64(limit) + 1).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build page sql: %w", err)
+	}
+	result := &DiffResult{}
+	if err := tx.Select(&result.Differences, pageSQL, args...); err != nil {
+		return nil, fmt.Errorf("select diff results: %w", err)
+	}
+	result.HasMore = paginateSlice(&result.Differences, limit)
+
+	paths := make([]string, len(result.Differences))
+	for i, d := range result.Differences {
+		paths[i] = d.Path
+	}
+	leftEntries, err := selectLineageEntries(tx, leftQ, sq.Eq{"path": paths})
+	if err != nil {
+		return nil, fmt.Errorf("left entries: %w", err)
+	}
+	rightEntries, err := selectLineageEntries(tx, rightQ, sq.Eq{"path": paths})
+	if err != nil {
+		return nil, fmt.Errorf("right entries: %w", err)
+	}
+	for i := range result.Differences {
+		result.Differences[i].LeftEntry = leftEntries[result.Differences[i].Path]
+		result.Differences[i].RightEntry = rightEntries[result.Differences[i].Path]
+	}
+
+	if !params.WithSummary {
+		return result, nil
+	}
+	summaryFilter := diffPathFilter{Prefix: params.Prefix}
+	summarySQL, args, err := psql.Select("COUNT(*) AS files").
+		Column("COALESCE(SUM(CASE WHEN f.diff_type IN (?, ?) THEN l.size END), 0) AS bytes_added",
+			DifferenceTypeAdded, DifferenceTypeChanged).
+		Column("COALESCE(SUM(CASE WHEN f.diff_type IN (?, ?) THEN r.size END), 0) AS bytes_removed",
+			DifferenceTypeRemoved, DifferenceTypeChanged).
+		FromSelect(typesFilter(diffQ(summaryFilter)), "f").
+		JoinClause(sq.Select("path", "size").FromSelect(summaryFilter.where(leftQ, "e.path"), "e").Where("NOT is_deleted").
+			Prefix("LEFT JOIN (").Suffix(") AS l ON l.path = f.path")).
+		JoinClause(sq.Select("path", "size").FromSelect(summaryFilter.where(rightQ, "e.path"), "e").Where("NOT is_deleted").
+			Prefix("LEFT JOIN (").Suffix(") AS r ON r.path = f.path")).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build summary sql: %w", err)
+	}
+	result.Summary = &DiffSummary{}
+	if err := tx.Get(result.Summary, summarySQL, args...); err != nil {
+		return nil, fmt.Errorf("diff summary: %w", err)
+	}
+	return result, nil
+}
+
+// diffByRelation fills the diff results table with the differences between the branches, based on their relation
+func (c *cataloger) diffByRelation(tx db.Tx, relation RelationType, leftID, rightID int64) error {
+	diffQ, err := c.diffQueryByRelation(tx, relation, leftID, rightID)
+	if err != nil {
+		return err
+	}
+	return createDiffResults(tx, diffQ(diffPathFilter{}))
+}
+
+// diffQueryByRelation returns the query of the differences between the branches, based on their relation
+func (c *cataloger) diffQueryByRelation(tx db.Tx, relation RelationType, leftID, rightID int64) (diffQueryFunc, error) {
 	switch relation {
 	case RelationTypeFromFather:
 		return c.diffFromFather(tx, leftID, rightID)
@@ -63,7 +225,7 @@ func (c *cataloger) doDiffByRelation(tx db.Tx, relation RelationType, leftID, ri
 	}
 }
 
-func (c *cataloger) diffFromFather(tx db.Tx, fatherID, sonID int64) (Differences, error) {
+func (c *cataloger) diffFromFather(tx db.Tx, fatherID, sonID int64) (diffQueryFunc, error) {
 	// get the last son commit number of the last father merge
 	// if there is none - then it is  the first merge
 	var maxSonMerge CommitID
@@ -87,17 +249,9 @@ func (c *cataloger) diffFromFather(tx db.Tx, fatherID, sonID int64) (Differences
 	if err != nil {
 		return nil, fmt.Errorf("get son last commit failed: %w", err)
 	}
-	diffFromFatherSQL, args, err := sqDiffFromFatherV(fatherID, sonID, maxSonMerge, fatherLineage, sonLineage).
-		Prefix(`CREATE TEMP TABLE ` + diffResultsTableName + " ON COMMIT DROP AS ").
-		PlaceholderFormat(sq.Dollar).
-		ToSql()
-	if err != nil {
-		return nil, fmt.Errorf("diff from father sql: %w", err)
-	}
-	if _, err := tx.Exec(diffFromFatherSQL, args...); err != nil {
-		return nil, fmt.Errorf("select diff from father: %w", err)
-	}
-	return diffReadDifferences(tx)
+	return func(filter diffPathFilter) sq.SelectBuilder {
+		return sqDiffFromFatherV(fatherID, sonID, maxSonMerge, fatherLineage, sonLineage, filter)
+	}, nil
 }
 
 func diffReadDifferences(tx db.Tx) (Differences, error) {
@@ -108,7 +262,7 @@ func diffReadDifferences(tx db.Tx) (Differences, error) {
 	return result, nil
 }
 
-func (c *cataloger) diffFromSon(tx db.Tx, sonID, fatherID int64) (Differences, error) {
+func (c *cataloger) diffFromSon(tx db.Tx, sonID, fatherID int64) (diffQueryFunc, error) {
 	// read last merge commit numbers from commit table
 	// if it is the first son-to-father commit, than those commit numbers are calculated as follows:
 	// the son is 0, as any change in the some was never merged to the father.
@@ -161,24 +315,134 @@ func (c *cataloger) diffFromSon(tx db.Tx, sonID, fatherID int64) (Differences, e
 	}
 
 	sonLineageValues := getLineageAsValues(sonLineage, sonID)
-	mainDiffFromSon := sqDiffFromSonV(fatherID, sonID, effectiveCommits.FatherEffectiveCommit, effectiveCommits.SonEffectiveCommit, fatherLineage, sonLineageValues)
-	diffFromSonSQL, args, err := mainDiffFromSon.
-		Prefix("CREATE TEMP TABLE " + diffResultsTableName + " ON COMMIT DROP AS").
-		PlaceholderFormat(sq.Dollar).
-		ToSql()
+	return func(filter diffPathFilter) sq.SelectBuilder {
+		return sqDiffFromSonV(fatherID, sonID, effectiveCommits.FatherEffectiveCommit, effectiveCommits.SonEffectiveCommit, fatherLineage, sonLineageValues, filter)
+	}, nil
+}
+
+func (c *cataloger) diffNonDirect(tx db.Tx, leftID, rightID int64) (diffQueryFunc, error) {
+	base, err := getNonDirectMergeBase(tx, leftID, rightID)
 	if err != nil {
-		return nil, fmt.Errorf("diff from son sql: %w", err)
+		return nil, err
 	}
-	if _, err := tx.Exec(diffFromSonSQL, args...); err != nil {
-		return nil, fmt.Errorf("exec diff from son: %w", err)
+	baseLineage, err := getLineage(tx, base.BranchID, base.CommitID)
+	if err != nil {
+		return nil, fmt.Errorf("base lineage failed: %w", err)
 	}
-	return diffReadDifferences(tx)
+	leftLineage, err := getLineage(tx, leftID, CommittedID)
+	if err != nil {
+		return nil, fmt.Errorf("left lineage failed: %w", err)
+	}
+	rightLineage, err := getLineage(tx, rightID, UncommittedID)
+	if err != nil {
+		return nil, fmt.Errorf("right lineage failed: %w", err)
+	}
+	return func(filter diffPathFilter) sq.SelectBuilder {
+		return sqDiffNonDirectV(leftID, rightID, leftLineage, rightLineage, base, baseLineage, filter)
+	}, nil
+}
+
+// getNonDirectMergeBase returns the branch and commit that represents the common state of two branches that are
+// not in a direct father/son relation.
+// If the branches were already merged (in any direction), the source commit of the last merge is used.
+// Otherwise the base is the closest branch found in both lineages, at the oldest commit observed by any of them.
+func getNonDirectMergeBase(tx db.Tx, leftID, rightID int64) (lineageCommit, error) {
+	var base lineageCommit
+	err := tx.Get(&base, `SELECT merge_source_branch AS branch_id, merge_source_commit AS commit_id
+		FROM catalog_commits
+		WHERE merge_type = 'non_direct' AND
+			((branch_id = $1 AND merge_source_branch = $2) OR (branch_id = $2 AND merge_source_branch = $1))
+		ORDER BY commit_id DESC
+		LIMIT 1`, rightID, leftID)
+	if err == nil {
+		return base, nil
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return base, fmt.Errorf("last non direct merge: %w", err)
+	}
+
+	leftChain, err := getBranchChain(tx, leftID, CommittedID)
+	if err != nil {
+		return base, fmt.Errorf("left lineage: %w", err)
+	}
+	rightChain, err := getBranchChain(tx, rightID, UncommittedID)
+	if err != nil {
+		return base, fmt.Errorf("right lineage: %w", err)
+	}
+	for _, l := range leftChain {
+		for _, r := range rightChain {
+			if l.BranchID != r.BranchID {
+				continue
+			}
+			base.BranchID = l.BranchID
+			base.CommitID = l.CommitID
+			if r.CommitID < base.CommitID {
+				base.CommitID = r.CommitID
+			}
+			return base, nil
+		}
+	}
+	return base, ErrUnsupportedRelation
 }
 
-func (c *cataloger) diffNonDirect(_ db.Tx, leftID, rightID int64) (Differences, error) {
-	c.log.WithFields(logging.Fields{
-		"left_id":  leftID,
-		"right_id": rightID,
-	}).Debug("Diff not direct - feature not supported")
-	return nil, ErrFeatureNotSupported
+// getBranchChain returns the branch with its last commit, followed by the branch lineage
+func getBranchChain(tx db.Tx, branchID int64, commitID CommitID) ([]lineageCommit, error) {
+	lastCommitID, err := getLastCommitIDByBranchID(tx, branchID)
+	if err != nil {
+		return nil, err
+	}
+	lineage, err := getLineage(tx, branchID, commitID)
+	if err != nil {
+		return nil, err
+	}
+	return append([]lineageCommit{{BranchID: branchID, CommitID: lastCommitID}}, lineage...), nil
+}
+
+// getRefCommit returns the branch id and the commit of a reference. A branch reference resolves to the last commit
+// of the branch.
+func (c *cataloger) getRefCommit(tx db.Tx, repository string, ref *Ref) (int64, *commitLogRaw, error) {
+	ref, branchID, err := c.getRefBranchIDCache(tx, repository, ref)
+	if err != nil {
+		return 0, nil, fmt.Errorf("reference branch: %w", err)
+	}
+	commitID := ref.CommitID
+	if commitID <= UncommittedID {
+		commitID, err = getLastCommitIDByBranchID(tx, branchID)
+		if err != nil {
+			return 0, nil, fmt.Errorf("last commit id: %w", err)
+		}
+	}
+	var commit commitLogRaw
+	err = tx.Get(&commit, `SELECT commit_id, previous_commit_id, message, metadata
+		FROM catalog_commits WHERE branch_id=$1 AND commit_id=$2`,
+		branchID, commitID)
+	if errors.Is(err, db.ErrNotFound) {
+		return 0, nil, ErrCommitNotFound
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("get commit: %w", err)
+	}
+	return branchID, &commit, nil
+}
+
+// diffCommits fills the diff results table with the changes made on the source branch from fromCommitID to
+// toCommitID, as they should be applied on the destination branch
+func diffCommits(tx db.Tx, sourceID int64, fromCommitID, toCommitID CommitID, destinationID int64) (Differences, error) {
+	fromLineage, err := getLineage(tx, sourceID, fromCommitID)
+	if err != nil {
+		return nil, fmt.Errorf("from commit lineage failed: %w", err)
+	}
+	toLineage, err := getLineage(tx, sourceID, toCommitID)
+	if err != nil {
+		return nil, fmt.Errorf("to commit lineage failed: %w", err)
+	}
+	destinationLineage, err := getLineage(tx, destinationID, UncommittedID)
+	if err != nil {
+		return nil, fmt.Errorf("destination lineage failed: %w", err)
+	}
+	diffQ := sqDiffCommitsV(sourceID, fromCommitID, toCommitID, fromLineage, toLineage, destinationID, destinationLineage)
+	if err := createDiffResults(tx, diffQ); err != nil {
+		return nil, err
+	}
+	return diffReadDifferences(tx)
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/go-test/deep"
 	"github.com/treeverse/lakefs/testutil"
 )
 
@@ -46,10 +47,10 @@ func TestCataloger_Diff_FromSonThreeBranches(t *testing.T) {
 	testutil.MustDo(t, "second commit to branch2", err)
 
 	// merge the above up to master (from branch2)
-	_, err = c.Merge(ctx, repository, "branch2", "branch1", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "branch2", "branch1", "tester", "", nil, MergeParams{})
 	testutil.MustDo(t, "Merge changes from branch2 to branch1", err)
 	// merge the changes from branch1 to master
-	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{})
 	testutil.MustDo(t, "Merge changes from branch1 to master", err)
 
 	if !IsValidReference(res.Reference) {
@@ -81,3 +82,232 @@ func TestCataloger_Diff_FromSonThreeBranches(t *testing.T) {
 		{Path: "/file8"},
 	})
 }
+
+func TestCataloger_Diff_Pagination(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	for i := 0; i < 3; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+
+	// changes on branch1 - add, change and delete
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/dir/file3", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/dir/file4", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file1", nil, "seed1")
+	testutil.MustDo(t, "delete file2", c.DeleteEntry(ctx, repository, "branch1", "/file2"))
+	_, err = c.Commit(ctx, repository, "branch1", "commit to branch1", "tester", nil)
+	testutil.MustDo(t, "commit to branch1", err)
+
+	tests := []struct {
+		name     string
+		params   DiffParams
+		expected Differences
+		hasMore  bool
+	}{
+		{
+			name:   "all",
+			params: DiffParams{Limit: -1},
+			expected: Differences{
+				{Type: DifferenceTypeAdded, Path: "/dir/file3"},
+				{Type: DifferenceTypeAdded, Path: "/dir/file4"},
+				{Type: DifferenceTypeChanged, Path: "/file1"},
+				{Type: DifferenceTypeRemoved, Path: "/file2"},
+			},
+		},
+		{
+			name:   "first page",
+			params: DiffParams{Limit: 2},
+			expected: Differences{
+				{Type: DifferenceTypeAdded, Path: "/dir/file3"},
+				{Type: DifferenceTypeAdded, Path: "/dir/file4"},
+			},
+			hasMore: true,
+		},
+		{
+			name:   "last page",
+			params: DiffParams{Limit: 2, After: "/dir/file4"},
+			expected: Differences{
+				{Type: DifferenceTypeChanged, Path: "/file1"},
+				{Type: DifferenceTypeRemoved, Path: "/file2"},
+			},
+		},
+		{
+			name:   "prefix",
+			params: DiffParams{Limit: -1, Prefix: "/dir/"},
+			expected: Differences{
+				{Type: DifferenceTypeAdded, Path: "/dir/file3"},
+				{Type: DifferenceTypeAdded, Path: "/dir/file4"},
+			},
+		},
+		{
+			name:   "type",
+			params: DiffParams{Limit: -1, DifferenceTypes: []DifferenceType{DifferenceTypeChanged, DifferenceTypeRemoved}},
+			expected: Differences{
+				{Type: DifferenceTypeChanged, Path: "/file1"},
+				{Type: DifferenceTypeRemoved, Path: "/file2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := c.Diff(ctx, repository, "branch1", "master", tt.params)
+			testutil.MustDo(t, "diff", err)
+			if diff := deep.Equal(testDiffWithoutEntries(res.Differences), tt.expected); diff != nil {
+				t.Fatal("Diff", diff)
+			}
+			if res.HasMore != tt.hasMore {
+				t.Fatalf("Diff hasMore = %t, expected %t", res.HasMore, tt.hasMore)
+			}
+		})
+	}
+}
+
+func TestCataloger_Diff_Entries(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	for i := 0; i < 3; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file1", Metadata{"k": "v"}, "seed1")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file3", nil, "")
+	testutil.MustDo(t, "delete file2", c.DeleteEntry(ctx, repository, "branch1", "/file2"))
+	_, err = c.Commit(ctx, repository, "branch1", "commit to branch1", "tester", nil)
+	testutil.MustDo(t, "commit to branch1", err)
+
+	res, err := c.Diff(ctx, repository, "branch1", "master", DiffParams{Limit: -1, WithSummary: true})
+	testutil.MustDo(t, "diff", err)
+	entries := make(map[string][2]*Entry)
+	for _, d := range res.Differences {
+		entries[d.Path] = [2]*Entry{d.LeftEntry, d.RightEntry}
+	}
+	getEntry := func(reference, path string) *Entry {
+		ent, err := c.GetEntry(ctx, repository, reference, path, GetEntryParams{})
+		testutil.MustDo(t, "get entry "+path, err)
+		return ent
+	}
+	expected := map[string][2]*Entry{
+		"/file1": {getEntry("branch1", "/file1"), getEntry("master", "/file1")},
+		"/file2": {nil, getEntry("master", "/file2")},
+		"/file3": {getEntry("branch1", "/file3"), nil},
+	}
+	if diff := deep.Equal(entries, expected); diff != nil {
+		t.Fatal("Diff entries", diff)
+	}
+	if entries["/file1"][0].Metadata["k"] != "v" {
+		t.Fatalf("Diff left entry metadata = %v, expected k=v", entries["/file1"][0].Metadata)
+	}
+
+	expectedSummary := DiffSummary{
+		Files:        3,
+		BytesAdded:   expected["/file1"][0].Size + expected["/file3"][0].Size,
+		BytesRemoved: expected["/file1"][1].Size + expected["/file2"][1].Size,
+	}
+	if res.Summary == nil || *res.Summary != expectedSummary {
+		t.Fatalf("Diff summary = %+v, expected %+v", res.Summary, expectedSummary)
+	}
+
+	// summary covers the filtered differences, not only the page
+	res, err = c.Diff(ctx, repository, "branch1", "master", DiffParams{Limit: 1, Prefix: "/file", After: "/file1", WithSummary: true})
+	testutil.MustDo(t, "diff with prefix", err)
+	if diff := deep.Equal(testDiffWithoutEntries(res.Differences), Differences{{Type: DifferenceTypeRemoved, Path: "/file2"}}); diff != nil {
+		t.Fatal("Diff with prefix page", diff)
+	}
+	if res.Summary == nil || *res.Summary != expectedSummary {
+		t.Fatalf("Diff with prefix summary = %+v, expected %+v", res.Summary, expectedSummary)
+	}
+
+	res, err = c.Diff(ctx, repository, "branch1", "master", DiffParams{Limit: 1, Prefix: "/file1", WithSummary: true})
+	testutil.MustDo(t, "diff with prefix", err)
+	expectedSummary = DiffSummary{
+		Files:        1,
+		BytesAdded:   expected["/file1"][0].Size,
+		BytesRemoved: expected["/file1"][1].Size,
+	}
+	if res.Summary == nil || *res.Summary != expectedSummary {
+		t.Fatalf("Diff with prefix summary = %+v, expected %+v", res.Summary, expectedSummary)
+	}
+
+	// summary is calculated only when requested
+	res, err = c.Diff(ctx, repository, "branch1", "master", DiffParams{Limit: -1})
+	testutil.MustDo(t, "diff without summary", err)
+	if res.Summary != nil {
+		t.Fatalf("Diff summary = %+v, expected none", res.Summary)
+	}
+}
+
+func TestCataloger_Diff_Tag(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	_, err = c.CreateTag(ctx, repository, "v1.0", "master")
+	testutil.MustDo(t, "create tag v1.0", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "seed1")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file2", nil, "")
+	testutil.MustDo(t, "delete file0", c.DeleteEntry(ctx, repository, "master", "/file0"))
+	_, err = c.Commit(ctx, repository, "master", "second commit", "tester", nil)
+	testutil.MustDo(t, "second commit", err)
+	_, err = c.CreateTag(ctx, repository, "v2.0", "master")
+	testutil.MustDo(t, "create tag v2.0", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file3", nil, "")
+
+	tests := []struct {
+		name  string
+		left  string
+		right string
+		want  Differences
+	}{
+		{
+			name:  "tags",
+			left:  "v2.0",
+			right: "v1.0",
+			want: Differences{
+				Difference{Type: DifferenceTypeRemoved, Path: "/file0"},
+				Difference{Type: DifferenceTypeChanged, Path: "/file1"},
+				Difference{Type: DifferenceTypeAdded, Path: "/file2"},
+			},
+		},
+		{
+			name:  "branch and tag",
+			left:  "master",
+			right: "v2.0",
+			want:  Differences{},
+		},
+		{
+			name:  "tag and branch",
+			left:  "v2.0",
+			right: "master",
+			want: Differences{
+				Difference{Type: DifferenceTypeRemoved, Path: "/file3"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := c.Diff(ctx, repository, tt.left, tt.right, DiffParams{Limit: -1})
+			testutil.MustDo(t, "diff", err)
+			if diff := deep.Equal(testDiffWithoutEntries(res.Differences), tt.want); diff != nil {
+				t.Fatal("Diff", diff)
+			}
+		})
+	}
+}
+
+func testDiffWithoutEntries(differences Differences) Differences {
+	result := make(Differences, len(differences))
+	for i, d := range differences {
+		result[i] = Difference{Type: d.Type, Path: d.Path}
+	}
+	return result
+}
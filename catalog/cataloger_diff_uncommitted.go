@@ -9,14 +9,17 @@ import (
 	"github.com/treeverse/lakefs/db"
 )
 
-func (c *cataloger) DiffUncommitted(ctx context.Context, repository, branch string) (Differences, error) {
+// DiffUncommitted returns a page of the uncommitted changes on a branch.  The left entries are read from the
+// branch including uncommitted changes, the right entries from the branch last commit.
+func (c *cataloger) DiffUncommitted(ctx context.Context, repository, branch string, params DiffParams) (*DiffResult, error) {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
 		{Name: "branch", IsValid: ValidateBranchName(branch)},
 	}); err != nil {
 		return nil, err
 	}
-	differences, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+	limit := diffLimit(params.Limit)
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
 		branchID, err := c.getBranchIDCache(tx, repository, branch)
 		if err != nil {
 			return nil, err
@@ -26,26 +29,52 @@ func (c *cataloger) DiffUncommitted(ctx context.Context, repository, branch stri
 		if err != nil {
 			return nil, fmt.Errorf("get lineage: %w", err)
 		}
-
-		q := psql.Select("CASE WHEN e.max_commit=0 THEN 1 WHEN v.path IS NOT NULL THEN 2 ELSE 0 END AS diff_type", "e.path").
-			FromSelect(sqEntriesV(UncommittedID), "e").
-			JoinClause(
-				sqEntriesLineageV(branchID, CommittedID, lineage).
-					Prefix("LEFT JOIN (").Suffix(") AS v ON v.path=e.path")).
-			Where(sq.Eq{"e.branch_id": branchID, "e.is_committed": false})
-		sql, args, err := q.ToSql()
+		uncommittedLineage, err := getLineage(tx, branchID, UncommittedID)
 		if err != nil {
-			return nil, fmt.Errorf("build sql: %w", err)
+			return nil, fmt.Errorf("get uncommitted lineage: %w", err)
 		}
 
-		var result Differences
-		if err := tx.Select(&result, sql, args...); err != nil {
-			return nil, err
+		diffQ := func(filter diffPathFilter) sq.SelectBuilder {
+			return sqDiffUncommitted(branchID, lineage, filter)
 		}
-		return result, nil
+		return diffReadResult(tx, diffQ,
+			sqEntriesLineage(branchID, UncommittedID, uncommittedLineage),
+			sqEntriesLineage(branchID, CommittedID, lineage),
+			params, limit)
 	}, c.txOpts(ctx, db.ReadOnly())...)
 	if err != nil {
 		return nil, err
 	}
-	return differences.(Differences), nil
+	return res.(*DiffResult), nil
+}
+
+// sqDiffUncommitted selects the diff_type and path of the uncommitted changes on a branch
+func sqDiffUncommitted(branchID int64, lineage []lineageCommit, filter diffPathFilter) sq.SelectBuilder {
+	q := psql.Select("CASE WHEN e.max_commit=0 THEN 1 WHEN v.path IS NOT NULL THEN 2 ELSE 0 END AS diff_type", "e.path").
+		FromSelect(sqEntriesV(UncommittedID), "e").
+		JoinClause(
+			filter.where(sqEntriesLineageV(branchID, CommittedID, lineage), "e.path").
+				Prefix("LEFT JOIN (").Suffix(") AS v ON v.path=e.path")).
+		Where(sq.Eq{"e.branch_id": branchID, "e.is_committed": false})
+	return filter.where(q, "e.path")
+}
+
+// diffUncommittedDifferences returns all the uncommitted changes on a branch
+func diffUncommittedDifferences(tx db.Tx, branchID int64) (Differences, error) {
+	lineage, err := getLineage(tx, branchID, CommittedID)
+	if err != nil {
+		return nil, fmt.Errorf("get lineage: %w", err)
+	}
+	query, args, err := psql.Select("diff_type", "path").
+		FromSelect(sqDiffUncommitted(branchID, lineage, diffPathFilter{}), "d").
+		OrderBy("path").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build sql: %w", err)
+	}
+	var differences Differences
+	if err := tx.Select(&differences, query, args...); err != nil {
+		return nil, err
+	}
+	return differences, nil
 }
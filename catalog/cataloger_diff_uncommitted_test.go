@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/go-test/deep"
 
 	"github.com/treeverse/lakefs/testutil"
 )
@@ -32,10 +33,11 @@ func TestCataloger_DiffUncommitted_Changes(t *testing.T) {
 	testCatalogerCreateEntry(t, ctx, c, repository, "master", overFilename, nil, "seed1")
 
 	// verify that diff uncommitted show the above change
-	differences, err := c.DiffUncommitted(ctx, repository, "master")
+	res, err := c.DiffUncommitted(ctx, repository, "master", DiffParams{Limit: -1})
 	if err != nil {
 		t.Fatalf("DiffUncommitted err = %s, expected none", err)
 	}
+	differences := res.Differences
 
 	changes := Differences{
 		Difference{Type: DifferenceTypeRemoved, Path: "/file1"},
@@ -60,13 +62,70 @@ func TestCataloger_DiffUncommitted_NoChance(t *testing.T) {
 	testutil.MustDo(t, "commit to master", err)
 
 	// verify that diff uncommitted show the above change
-	differences, err := c.DiffUncommitted(ctx, repository, "master")
+	res, err := c.DiffUncommitted(ctx, repository, "master", DiffParams{Limit: -1})
 	if err != nil {
 		t.Fatalf("DiffUncommitted err = %s, expected none", err)
 	}
+	differences := res.Differences
 
 	changes := Differences{}
 	if !changes.Equal(differences) {
 		t.Fatalf("DiffUncommitted differences = %s, expected = %s", spew.Sdump(differences), spew.Sdump(changes))
 	}
 }
+
+func TestCataloger_DiffUncommitted_Pagination(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	for i := 0; i < 3; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/dir/file3", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "seed1")
+	testutil.MustDo(t, "delete file2", c.DeleteEntry(ctx, repository, "master", "/file2"))
+
+	// walk the differences one page at a time
+	var differences Differences
+	params := DiffParams{Limit: 1}
+	for {
+		res, err := c.DiffUncommitted(ctx, repository, "master", params)
+		testutil.MustDo(t, "diff uncommitted", err)
+		page := res.Differences
+		if len(page) != 1 {
+			t.Fatalf("DiffUncommitted page = %s, expected single difference", spew.Sdump(page))
+		}
+		differences = append(differences, page...)
+		if !res.HasMore {
+			break
+		}
+		params.After = page[len(page)-1].Path
+	}
+	expected := Differences{
+		{Type: DifferenceTypeAdded, Path: "/dir/file3"},
+		{Type: DifferenceTypeChanged, Path: "/file1"},
+		{Type: DifferenceTypeRemoved, Path: "/file2"},
+	}
+	if diff := deep.Equal(testDiffWithoutEntries(differences), expected); diff != nil {
+		t.Fatal("DiffUncommitted", diff)
+	}
+
+	// filter by prefix and type
+	res, err := c.DiffUncommitted(ctx, repository, "master", DiffParams{
+		Limit:           -1,
+		Prefix:          "/file",
+		DifferenceTypes: []DifferenceType{DifferenceTypeRemoved},
+	})
+	testutil.MustDo(t, "diff uncommitted with filter", err)
+	expected = Differences{
+		{Type: DifferenceTypeRemoved, Path: "/file2"},
+	}
+	if diff := deep.Equal(testDiffWithoutEntries(res.Differences), expected); diff != nil {
+		t.Fatal("DiffUncommitted with filter", diff)
+	}
+	if res.HasMore {
+		t.Fatal("DiffUncommitted with filter hasMore = true, expected false")
+	}
+}
@@ -20,7 +20,7 @@ func (c *cataloger) GetCommit(ctx context.Context, repository, reference string)
 		return nil, err
 	}
 	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
-		branchID, err := c.getBranchIDCache(tx, repository, ref.Branch)
+		ref, branchID, err := c.getRefBranchIDCache(tx, repository, ref)
 		if err != nil {
 			return nil, err
 		}
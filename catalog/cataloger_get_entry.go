@@ -24,30 +24,11 @@ func (c *cataloger) GetEntryMaybeExpired(ctx context.Context, repository, refere
 		return nil, err
 	}
 	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
-		branchID, err := c.getBranchIDCache(tx, repository, ref.Branch)
+		ref, branchID, err := c.getRefBranchIDCache(tx, repository, ref)
 		if err != nil {
 			return nil, err
 		}
-
-		lineage, err := getLineage(tx, branchID, ref.CommitID)
-		if err != nil {
-			return nil, fmt.Errorf("get lineage: %w", err)
-		}
-
-		sql, args, err := psql.
-			Select("path", "physical_address", "creation_date", "size", "checksum", "metadata", "is_expired").
-			FromSelect(sqEntriesLineage(branchID, ref.CommitID, lineage), "entries").
-			Where(sq.Eq{"path": path, "is_deleted": false}).
-			ToSql()
-		if err != nil {
-			return nil, fmt.Errorf("build sql: %w", err)
-		}
-
-		var ent Entry
-		if err := tx.Get(&ent, sql, args...); err != nil {
-			return nil, err
-		}
-		return &ent, nil
+		return getEntry(tx, branchID, ref.CommitID, path)
 	}, c.txOpts(ctx, db.ReadOnly())...)
 	if err != nil {
 		return nil, err
@@ -62,3 +43,26 @@ func (c *cataloger) GetEntry(ctx context.Context, repository, reference string,
 	}
 	return entry, err
 }
+
+// getEntry returns the entry of path as seen from commitID of branchID
+func getEntry(tx db.Tx, branchID int64, commitID CommitID, path string) (*Entry, error) {
+	lineage, err := getLineage(tx, branchID, commitID)
+	if err != nil {
+		return nil, fmt.Errorf("get lineage: %w", err)
+	}
+
+	sql, args, err := psql.
+		Select("path", "physical_address", "creation_date", "size", "checksum", "metadata", "is_expired").
+		FromSelect(sqEntriesLineage(branchID, commitID, lineage), "entries").
+		Where(sq.Eq{"path": path, "is_deleted": false}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build sql: %w", err)
+	}
+
+	var ent Entry
+	if err := tx.Get(&ent, sql, args...); err != nil {
+		return nil, err
+	}
+	return &ent, nil
+}
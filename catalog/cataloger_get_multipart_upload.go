@@ -21,9 +21,12 @@ func (c *cataloger) GetMultipartUpload(ctx context.Context, repository string, u
 		}
 		var m MultipartUpload
 		if err := tx.Get(&m, `
-			SELECT r.name as repository, m.upload_id, m.path, m.creation_date, m.physical_address 
-			FROM catalog_multipart_uploads m, catalog_repositories r
-			WHERE r.id = m.repository_id AND m.repository_id = $1 AND m.upload_id = $2`,
+			SELECT r.name as repository, COALESCE(b.name, '') as branch, m.upload_id, m.path, m.creation_date,
+				m.physical_address, m.metadata
+			FROM catalog_multipart_uploads m
+				JOIN catalog_repositories r ON r.id = m.repository_id
+				LEFT JOIN catalog_branches b ON b.id = m.branch_id
+			WHERE m.repository_id = $1 AND m.upload_id = $2`,
 			repoID, uploadID); err != nil {
 			return nil, err
 		}
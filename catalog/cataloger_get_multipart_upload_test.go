@@ -12,11 +12,12 @@ func TestCataloger_GetMultipartUpload(t *testing.T) {
 	c := testCataloger(t)
 
 	creationTime := time.Now().Round(time.Second) // round in order to remove the monotonic clock
+	metadata := Metadata{"Content-Type": "text/plain"}
 	// setup test data
 	if err := c.CreateRepository(ctx, "repo1", "s3://bucket1", "master"); err != nil {
 		t.Fatal("create repository for testing failed", err)
 	}
-	if err := c.CreateMultipartUpload(ctx, "repo1", "upload1", "/path1", "/file1", creationTime); err != nil {
+	if err := c.CreateMultipartUpload(ctx, "repo1", "master", "upload1", "/path1", "/file1", creationTime, metadata); err != nil {
 		t.Fatal("create multipart upload for testing", err)
 	}
 
@@ -35,10 +36,12 @@ func TestCataloger_GetMultipartUpload(t *testing.T) {
 			args: args{repository: "repo1", uploadID: "upload1"},
 			want: &MultipartUpload{
 				Repository:      "repo1",
+				Branch:          "master",
 				UploadID:        "upload1",
 				Path:            "/path1",
 				CreationDate:    creationTime,
 				PhysicalAddress: "/file1",
+				Metadata:        metadata,
 			},
 			wantErr: false,
 		},
@@ -0,0 +1,40 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+func (c *cataloger) GetTag(ctx context.Context, repository, tag string) (*Tag, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "tag", IsValid: ValidateTagName(tag)},
+	}); err != nil {
+		return nil, err
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		t, err := getTag(tx, repository, tag)
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrTagNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		return convertRawTag(repository, t), nil
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*Tag), nil
+}
+
+func convertRawTag(repository string, raw *tagRaw) *Tag {
+	return &Tag{
+		Repository:   repository,
+		Name:         raw.Name,
+		Reference:    MakeReference(raw.BranchName, raw.CommitID),
+		CreationDate: raw.CreationDate,
+	}
+}
@@ -0,0 +1,47 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_GetTag(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	_, err = c.CreateTag(ctx, repository, "v1", commitLog.Reference)
+	testutil.MustDo(t, "create tag", err)
+
+	tests := []struct {
+		name       string
+		repository string
+		tag        string
+		wantErr    error
+	}{
+		{name: "exists", repository: repository, tag: "v1"},
+		{name: "unknown tag", repository: repository, tag: "v2", wantErr: ErrTagNotFound},
+		{name: "unknown repository", repository: "repo2", tag: "v1", wantErr: db.ErrNotFound},
+		{name: "invalid tag", repository: repository, tag: "", wantErr: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.GetTag(ctx, tt.repository, tt.tag)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Name != tt.tag || got.Repository != tt.repository || got.Reference != commitLog.Reference {
+				t.Fatalf("GetTag() got = %+v, expected tag %s on %s", got, tt.tag, commitLog.Reference)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+func (c *cataloger) ListBranchProtectionRules(ctx context.Context, repository string) ([]*BranchProtectionRule, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+	}); err != nil {
+		return nil, err
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		repoID, err := c.getRepositoryIDCache(tx, repository)
+		if err != nil {
+			return nil, err
+		}
+		var rules []*BranchProtectionRule
+		err = tx.Select(&rules, `SELECT $2 AS repository, pattern, creation_date
+			FROM catalog_branch_protection_rules
+			WHERE repository_id=$1
+			ORDER BY pattern`,
+			repoID, repository)
+		if err != nil {
+			return nil, err
+		}
+		return rules, nil
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, err
+	}
+	return res.([]*BranchProtectionRule), nil
+}
@@ -0,0 +1,33 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_ListBranchProtectionRules(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	otherRepository := testCatalogerRepo(t, ctx, c, "other", "master")
+	for _, pattern := range []string{"release-*", "master"} {
+		testutil.MustDo(t, "create rule "+pattern, c.CreateBranchProtectionRule(ctx, repository, pattern))
+	}
+	testutil.MustDo(t, "create rule on other", c.CreateBranchProtectionRule(ctx, otherRepository, "dev"))
+
+	rules, err := c.ListBranchProtectionRules(ctx, repository)
+	testutil.MustDo(t, "list rules", err)
+	patterns := make([]string, len(rules))
+	for i, rule := range rules {
+		if rule.Repository != repository {
+			t.Errorf("ListBranchProtectionRules() rule repository %s, expected %s", rule.Repository, repository)
+		}
+		patterns[i] = rule.Pattern
+	}
+	if diff := deep.Equal(patterns, []string{"master", "release-*"}); diff != nil {
+		t.Fatal("ListBranchProtectionRules() patterns", diff)
+	}
+}
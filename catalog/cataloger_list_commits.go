@@ -3,20 +3,28 @@ package catalog
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/treeverse/lakefs/db"
 )
 
 const ListCommitsMaxLimit = 10000
 
-func (c *cataloger) ListCommits(ctx context.Context, repository, branch string, fromReference string, limit int) ([]*CommitLog, bool, error) {
+// ListCommits returns the commits reachable from reference, newest first.  The reference can be a branch, a tag or
+// a commit reference; commits made after a tag or a commit are not listed.
+func (c *cataloger) ListCommits(ctx context.Context, repository, reference string, fromReference string, limit int, params ListCommitsParams) ([]*CommitLog, bool, error) {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
-		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "reference", IsValid: ValidateReference(reference)},
 		{Name: "fromReference", IsValid: ValidateOptionalString(fromReference, IsValidReference)},
 	}); err != nil {
 		return nil, false, err
 	}
+	headRef, err := ParseRef(reference)
+	if err != nil {
+		return nil, false, err
+	}
 	ref, err := ParseRef(fromReference)
 	if err != nil {
 		return nil, false, err
@@ -24,32 +32,47 @@ func (c *cataloger) ListCommits(ctx context.Context, repository, branch string,
 	if limit < 0 || limit > ListCommitsMaxLimit {
 		limit = ListCommitsMaxLimit
 	}
-	// we start from the newest to the oldest
-	fromCommitID := MaxCommitID
-	if ref.CommitID > 0 {
-		fromCommitID = ref.CommitID
-	}
 	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
-		branchID, err := c.getBranchIDCache(tx, repository, branch)
+		headRef, branchID, err := c.getRefBranchIDCache(tx, repository, headRef)
 		if err != nil {
 			return nil, err
 		}
-		lineage, err := getLineage(tx, branchID, CommittedID)
+		// we start from the newest to the oldest
+		fromCommitID := MaxCommitID
+		if ref.IsRelative() {
+			fromRef, _, err := c.getRefBranchIDCache(tx, repository, ref)
+			if err != nil {
+				return nil, err
+			}
+			fromCommitID = fromRef.CommitID
+		} else if ref.CommitID > 0 {
+			fromCommitID = ref.CommitID
+		}
+		headCommitID := CommittedID
+		if headRef.CommitID > UncommittedID {
+			headCommitID = headRef.CommitID
+			if headCommitID+1 < fromCommitID {
+				fromCommitID = headCommitID + 1
+			}
+		}
+		lineage, err := getLineage(tx, branchID, headCommitID)
 		if err != nil {
 			return nil, fmt.Errorf("get lineage: %w", err)
 		}
 		lineageAsValuesTable := getLineageAsValues(lineage, branchID)
+		filter, filterArgs := listCommitsFilter(params)
 		query := `SELECT b_name.name as branch_name,c.commit_id,c.previous_commit_id,c.committer,c.message,c.creation_date,c.metadata,
 				COALESCE(bb.name,'') as merge_source_branch_name,COALESCE(c.merge_source_commit,0) as merge_source_commit
 			FROM catalog_commits c JOIN (SELECT * FROM ` + lineageAsValuesTable + `) l  ON  c.branch_id = l.branch_id and c.commit_id <= l.commit_id
 				JOIN catalog_branches b_name ON c.branch_id = b_name.id
 				LEFT JOIN catalog_branches bb ON bb.id = c.merge_source_branch
-			WHERE c.commit_id < $1
+			WHERE c.commit_id < $1` + filter + `
 			ORDER BY c.commit_id DESC
 			LIMIT $2`
 
 		var rawCommits []*commitLogRaw
-		if err := tx.Select(&rawCommits, query, fromCommitID, limit+1); err != nil {
+		args := append([]interface{}{fromCommitID, limit + 1}, filterArgs...)
+		if err := tx.Select(&rawCommits, query, args...); err != nil {
 			return nil, err
 		}
 		commits := convertRawCommits(rawCommits)
@@ -64,6 +87,41 @@ func (c *cataloger) ListCommits(ctx context.Context, repository, branch string,
 	return commits, hasMore, err
 }
 
+// listCommitsFilter returns the SQL conditions, and their arguments, that select the commits matching params.
+// Arguments are numbered from $3, following the list commits query arguments.
+func listCommitsFilter(params ListCommitsParams) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args)+2)))
+	}
+	if params.Prefix != "" {
+		// a commit touches a path when it creates an entry, or ends the life of an entry by change or delete.
+		// tombstones written by commit start and end on the previous commit, so they match only by their end.
+		addCondition(`EXISTS (SELECT 1 FROM catalog_entries e
+				WHERE e.branch_id = c.branch_id AND e.path LIKE ?
+					AND ((e.min_commit = c.commit_id AND NOT (e.physical_address = '' AND e.min_commit = e.max_commit))
+						OR e.max_commit = c.previous_commit_id))`, db.Prefix(params.Prefix))
+	}
+	if params.Committer != "" {
+		addCondition("c.committer = ?", params.Committer)
+	}
+	if !params.Since.IsZero() {
+		addCondition("c.creation_date >= ?", params.Since)
+	}
+	if !params.Until.IsZero() {
+		addCondition("c.creation_date <= ?", params.Until)
+	}
+	if len(params.Metadata) > 0 {
+		addCondition("c.metadata @> ?", params.Metadata)
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " AND " + strings.Join(conditions, " AND "), args
+}
+
 func convertRawCommits(rawCommits []*commitLogRaw) []*CommitLog {
 	commits := make([]*CommitLog, len(rawCommits))
 	for i, commit := range rawCommits {
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/benbjohnson/clock"
 	"github.com/go-test/deep"
 	"github.com/treeverse/lakefs/testutil"
 )
@@ -135,7 +136,7 @@ func TestCataloger_ListCommits(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, gotMore, err := c.ListCommits(ctx, tt.args.repository, tt.args.branch, tt.args.fromReference, tt.args.limit)
+			got, gotMore, err := c.ListCommits(ctx, tt.args.repository, tt.args.branch, tt.args.fromReference, tt.args.limit, ListCommitsParams{})
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("ListCommits() error = %s, wantErr %t", err, tt.wantErr)
 			}
@@ -155,12 +156,12 @@ func TestCataloger_ListCommits(t *testing.T) {
 	}
 	testCatalogerBranch(t, ctx, c, repository, "br_1", "master")
 	testCatalogerBranch(t, ctx, c, repository, "br_2", "br_1")
-	master_commits, _, err := c.ListCommits(ctx, repository, "master", "", 100)
-	br_1_commits, _, err := c.ListCommits(ctx, repository, "br_1", "", 100)
+	master_commits, _, err := c.ListCommits(ctx, repository, "master", "", 100, ListCommitsParams{})
+	br_1_commits, _, err := c.ListCommits(ctx, repository, "br_1", "", 100, ListCommitsParams{})
 	if diff := deep.Equal(master_commits, br_1_commits[1:]); diff != nil {
 		t.Error("br_1 did not inherit commits correctly", diff)
 	}
-	br_2_commits, _, err := c.ListCommits(ctx, repository, "br_2", "", 100)
+	br_2_commits, _, err := c.ListCommits(ctx, repository, "br_2", "", 100, ListCommitsParams{})
 	_ = br_2_commits
 	if err != nil {
 		t.Fatalf("ListCommits() error = %s", err)
@@ -188,11 +189,11 @@ func TestCataloger_ListCommits(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Commit for list repository commits failed '%s': %s", "master commit failed", err)
 	}
-	_, err = c.Merge(ctx, repository, "master", "br_1", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "master", "br_1", "tester", "", nil, MergeParams{})
 
-	got, _, err := c.ListCommits(ctx, repository, "br_2", "", 100)
+	got, _, err := c.ListCommits(ctx, repository, "br_2", "", 100, ListCommitsParams{})
 	_ = got
-	got, _, err = c.ListCommits(ctx, repository, "br_1", "", 100)
+	got, _, err = c.ListCommits(ctx, repository, "br_1", "", 100, ListCommitsParams{})
 
 }
 
@@ -228,10 +229,10 @@ func TestCataloger_ListCommits_Lineage(t *testing.T) {
 
 	testCatalogerBranch(t, ctx, c, repository, "br_1", "master")
 	testCatalogerBranch(t, ctx, c, repository, "br_2", "br_1")
-	masterCommits, _, err := c.ListCommits(ctx, repository, "master", "", 100)
+	masterCommits, _, err := c.ListCommits(ctx, repository, "master", "", 100, ListCommitsParams{})
 	testutil.MustDo(t, "list master commits", err)
 
-	br1Commits, _, err := c.ListCommits(ctx, repository, "br_1", "", 100)
+	br1Commits, _, err := c.ListCommits(ctx, repository, "br_1", "", 100, ListCommitsParams{})
 	testutil.MustDo(t, "list br_1 commits", err)
 
 	// get all commits without the first one
@@ -239,7 +240,7 @@ func TestCataloger_ListCommits_Lineage(t *testing.T) {
 		t.Error("br_1 did not inherit commits correctly", diff)
 	}
 
-	b2Commits, _, err := c.ListCommits(ctx, repository, "br_2", "", 100)
+	b2Commits, _, err := c.ListCommits(ctx, repository, "br_2", "", 100, ListCommitsParams{})
 	testutil.MustDo(t, "list br_2 commits", err)
 
 	if diff := deep.Equal(br1Commits, b2Commits[1:]); diff != nil {
@@ -258,20 +259,108 @@ func TestCataloger_ListCommits_Lineage(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Commit for list repository commits failed '%s': %s", "master commit failed", err)
 	}
-	_, err = c.Merge(ctx, repository, "master", "br_1", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "master", "br_1", "tester", "", nil, MergeParams{})
 	testutil.MustDo(t, "merge master  into br_1", err)
 
-	got, _, err := c.ListCommits(ctx, repository, "br_2", "", 100)
+	got, _, err := c.ListCommits(ctx, repository, "br_2", "", 100, ListCommitsParams{})
 	testutil.MustDo(t, "list br_2 commits", err)
 	if diff := deep.Equal(got, b2Commits); diff != nil {
 		t.Error("br_2 changed although not merged", diff)
 	}
-	masterCommits, _, err = c.ListCommits(ctx, repository, "master", "", 100)
+	masterCommits, _, err = c.ListCommits(ctx, repository, "master", "", 100, ListCommitsParams{})
 	testutil.MustDo(t, "list master commits", err)
 
-	got, _, err = c.ListCommits(ctx, repository, "br_1", "", 100)
+	got, _, err = c.ListCommits(ctx, repository, "br_1", "", 100, ListCommitsParams{})
 	testutil.MustDo(t, "list br_1 commits", err)
 	if diff := deep.Equal(masterCommits[0], got[1]); diff != nil {
 		t.Error("br_1 did not inherit commits correctly", diff)
 	}
 }
+
+func TestCataloger_ListCommits_Tag(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	_ = setupListCommitsByBranchData(t, ctx, c, repository, "master")
+	tag, err := c.CreateTag(ctx, repository, "v1.0", "master")
+	testutil.MustDo(t, "create tag", err)
+	masterCommits, _, err := c.ListCommits(ctx, repository, "master", "", 100, ListCommitsParams{})
+	testutil.MustDo(t, "list master commits", err)
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/after-tag", nil, "")
+	_, err = c.Commit(ctx, repository, "master", "commit after tag", "tester", nil)
+	testutil.MustDo(t, "commit after tag", err)
+
+	got, _, err := c.ListCommits(ctx, repository, "v1.0", "", 100, ListCommitsParams{})
+	testutil.MustDo(t, "list tag commits", err)
+	if diff := deep.Equal(got, masterCommits); diff != nil {
+		t.Error("ListCommits on tag", diff)
+	}
+	if len(got) == 0 || got[0].Reference != tag.Reference {
+		t.Errorf("ListCommits on tag first commit = %v, expected %s", got, tag.Reference)
+	}
+
+	got, _, err = c.ListCommits(ctx, repository, "v1.0", masterCommits[0].Reference, 100, ListCommitsParams{})
+	testutil.MustDo(t, "list tag commits from its commit", err)
+	if diff := deep.Equal(got, masterCommits[1:]); diff != nil {
+		t.Error("ListCommits on tag from its commit", diff)
+	}
+}
+
+func TestCataloger_ListCommits_Filter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Round(time.Minute)
+	mockClock := clock.NewMock()
+	mockClock.Set(now)
+	c := testCataloger(t, WithClock(mockClock))
+	defer func() { _ = c.Close() }()
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	// commit a day apart - add two tables, change one and delete the other
+	commitDay := func(message, committer string, metadata Metadata) *CommitLog {
+		mockClock.Add(24 * time.Hour)
+		commitLog, err := c.Commit(ctx, repository, "master", message, committer, metadata)
+		testutil.MustDo(t, message, err)
+		return commitLog
+	}
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "tables/events/part0", nil, "")
+	commit1 := commitDay("add events", "airflow", Metadata{"job": "events", "run": "1"})
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "tables/users/part0", nil, "")
+	commit2 := commitDay("add users", "tester", Metadata{"job": "users"})
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "tables/users/part0", nil, "seed1")
+	commit3 := commitDay("change users", "airflow", Metadata{"job": "users"})
+	testutil.MustDo(t, "delete events", c.DeleteEntry(ctx, repository, "master", "tables/events/part0"))
+	commit4 := commitDay("delete events", "tester", Metadata{"job": "events", "run": "2"})
+
+	tests := []struct {
+		name   string
+		params ListCommitsParams
+		want   []string
+	}{
+		{name: "prefix", params: ListCommitsParams{Prefix: "tables/events/"}, want: []string{commit4.Reference, commit1.Reference}},
+		{name: "prefix change", params: ListCommitsParams{Prefix: "tables/users/"}, want: []string{commit3.Reference, commit2.Reference}},
+		{name: "committer", params: ListCommitsParams{Committer: "airflow"}, want: []string{commit3.Reference, commit1.Reference}},
+		{name: "since", params: ListCommitsParams{Since: commit3.CreationDate}, want: []string{commit4.Reference, commit3.Reference}},
+		{name: "until", params: ListCommitsParams{Since: commit1.CreationDate, Until: commit2.CreationDate}, want: []string{commit2.Reference, commit1.Reference}},
+		{name: "metadata", params: ListCommitsParams{Metadata: Metadata{"job": "events"}}, want: []string{commit4.Reference, commit1.Reference}},
+		{name: "metadata pairs", params: ListCommitsParams{Metadata: Metadata{"job": "events", "run": "1"}}, want: []string{commit1.Reference}},
+		{name: "combined", params: ListCommitsParams{Prefix: "tables/users/", Committer: "tester"}, want: []string{commit2.Reference}},
+		{name: "no match", params: ListCommitsParams{Committer: "nobody"}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits, hasMore, err := c.ListCommits(ctx, repository, "master", "", -1, tt.params)
+			testutil.MustDo(t, "list commits", err)
+			var got []string
+			for _, commit := range commits {
+				got = append(got, commit.Reference)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Fatal("ListCommits", diff)
+			}
+			if hasMore {
+				t.Fatal("ListCommits hasMore = true, expected false")
+			}
+		})
+	}
+}
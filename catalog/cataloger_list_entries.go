@@ -51,7 +51,7 @@ func (c *cataloger) ListEntries(ctx context.Context, repository, reference strin
 
 func (c *cataloger) listEntries(ctx context.Context, repository string, ref *Ref, prefix string, after string, limit int) (interface{}, error) {
 	return c.db.Transact(func(tx db.Tx) (interface{}, error) {
-		branchID, err := c.getBranchIDCache(tx, repository, ref.Branch)
+		ref, branchID, err := c.getRefBranchIDCache(tx, repository, ref)
 		if err != nil {
 			return nil, err
 		}
@@ -81,13 +81,12 @@ func (c *cataloger) listEntries(ctx context.Context, repository string, ref *Ref
 }
 
 func (c *cataloger) listEntriesByLevel(ctx context.Context, repository string, ref *Ref, prefix string, after string, delimiter string, limit int) (interface{}, error) {
-	branchName := ref.Branch
-	commitID := ref.CommitID
 	return c.db.Transact(func(tx db.Tx) (interface{}, error) {
-		branchID, err := c.getBranchIDCache(tx, repository, branchName)
+		ref, branchID, err := c.getRefBranchIDCache(tx, repository, ref)
 		if err != nil {
 			return nil, err
 		}
+		commitID := ref.CommitID
 		lineage, err := getLineage(tx, branchID, commitID)
 		if err != nil {
 			return nil, fmt.Errorf("get lineage: %w", err)
@@ -0,0 +1,108 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+const ListEntriesVersionsMaxLimit = 10000
+
+func (c *cataloger) ListEntriesVersions(ctx context.Context, repository, reference string, prefix string, afterPath, afterReference string, limit int) ([]*EntryVersion, bool, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "reference", IsValid: ValidateReference(reference)},
+		{Name: "afterReference", IsValid: ValidateOptionalString(afterReference, IsValidReference)},
+	}); err != nil {
+		return nil, false, err
+	}
+	ref, err := ParseRef(reference)
+	if err != nil {
+		return nil, false, err
+	}
+	// without after reference, the listing starts after all the versions of after path
+	var afterCommitID CommitID
+	if afterReference != "" {
+		afterRef, err := ParseRef(afterReference)
+		if err != nil {
+			return nil, false, err
+		}
+		if afterRef.CommitID <= 0 {
+			return nil, false, fmt.Errorf("after reference must be a commit reference: %w", ErrInvalidReference)
+		}
+		afterCommitID = afterRef.CommitID
+	}
+	if limit < 0 || limit > ListEntriesVersionsMaxLimit {
+		limit = ListEntriesVersionsMaxLimit
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		ref, branchID, err := c.getRefBranchIDCache(tx, repository, ref)
+		if err != nil {
+			return nil, err
+		}
+		lineage, err := getLineage(tx, branchID, ref.CommitID)
+		if err != nil {
+			return nil, fmt.Errorf("get lineage: %w", err)
+		}
+		untilCommitID := MaxCommitID
+		if ref.CommitID > 0 {
+			untilCommitID = ref.CommitID
+		}
+		lineageValues := getLineageAsValues(lineage, branchID)
+		// versions are the committed entries, created by their min commit, as listed by ListEntryHistory.
+		// delete markers are the commits that deleted an entry without writing a new version of it.  An entry,
+		// or a committed tombstone, ends on the commit previous to the one that deleted it.
+		query := `SELECT * FROM (
+				SELECT e.path, e.physical_address, e.creation_date, e.size, e.checksum, e.metadata, e.is_expired,
+					false AS delete_marker,
+					b.name AS branch_name, c.commit_id, c.committer, c.message, c.creation_date AS commit_date
+				FROM catalog_entries e
+					JOIN (SELECT * FROM ` + lineageValues + `) l
+						ON e.branch_id = l.branch_id AND e.min_commit <= l.commit_id
+					JOIN catalog_commits c ON c.branch_id = e.branch_id AND c.commit_id = e.min_commit
+					JOIN catalog_branches b ON b.id = e.branch_id
+				WHERE e.path LIKE $1 AND e.min_commit > 0 AND e.min_commit <= $2
+					AND NOT (e.physical_address = '' AND e.min_commit = e.max_commit)
+				UNION ALL
+				SELECT e.path, '' AS physical_address, c.creation_date, 0 AS size, '' AS checksum, NULL AS metadata,
+					false AS is_expired, true AS delete_marker,
+					b.name AS branch_name, c.commit_id, c.committer, c.message, c.creation_date AS commit_date
+				FROM catalog_entries e
+					JOIN catalog_commits c ON c.branch_id = e.branch_id AND c.previous_commit_id = e.max_commit
+					JOIN (SELECT * FROM ` + lineageValues + `) l
+						ON e.branch_id = l.branch_id AND c.commit_id <= l.commit_id
+					JOIN catalog_branches b ON b.id = e.branch_id
+				WHERE e.path LIKE $1 AND e.min_commit > 0 AND c.commit_id <= $2
+					AND e.max_commit < catalog_max_commit_id()
+					AND NOT EXISTS (SELECT 1 FROM catalog_entries n
+						WHERE n.branch_id = e.branch_id AND n.path = e.path AND n.min_commit = c.commit_id
+							AND n.physical_address <> '')
+			) v
+			WHERE v.path > $3 OR (v.path = $3 AND v.commit_id < $4)
+			ORDER BY v.path, v.commit_id DESC
+			LIMIT $5`
+		var rawVersions []*entryVersionRaw
+		if err := tx.Select(&rawVersions, query, db.Prefix(prefix), untilCommitID, afterPath, afterCommitID, limit+1); err != nil {
+			return nil, fmt.Errorf("select versions: %w", err)
+		}
+		versions := make([]*EntryVersion, len(rawVersions))
+		for i, raw := range rawVersions {
+			versions[i] = &EntryVersion{
+				Entry:        raw.Entry,
+				Reference:    MakeReference(raw.BranchName, raw.CommitID),
+				Committer:    raw.Committer,
+				Message:      raw.Message,
+				CommitDate:   raw.CommitDate,
+				DeleteMarker: raw.DeleteMarker,
+			}
+		}
+		return versions, nil
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, false, err
+	}
+	versions := res.([]*EntryVersion)
+	hasMore := paginateSlice(&versions, limit)
+	return versions, hasMore, nil
+}
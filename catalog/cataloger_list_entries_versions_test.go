@@ -0,0 +1,140 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCataloger_ListEntriesVersions(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	commit := func(branch, message string) string {
+		commitLog, err := c.Commit(ctx, repository, branch, message, "tester", nil)
+		if err != nil {
+			t.Fatalf("commit %s: %s", message, err)
+		}
+		return commitLog.Reference
+	}
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "v1")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file2", nil, "v1")
+	v1 := commit("master", "v1")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "v2")
+	v2 := commit("master", "v2")
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	// delete an entry inherited from master, writes a tombstone on branch1
+	if err := c.DeleteEntry(ctx, repository, "branch1", "/file2"); err != nil {
+		t.Fatal("delete entry:", err)
+	}
+	d3 := commit("branch1", "delete file2")
+	// delete an entry committed on master
+	if err := c.DeleteEntry(ctx, repository, "master", "/file1"); err != nil {
+		t.Fatal("delete entry:", err)
+	}
+	d4 := commit("master", "delete file1")
+	// uncommitted changes are not versions
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file3", nil, "v5")
+
+	type version struct {
+		Path         string
+		Reference    string
+		DeleteMarker bool
+	}
+	tests := []struct {
+		name           string
+		reference      string
+		prefix         string
+		afterPath      string
+		afterReference string
+		limit          int
+		want           []version
+		wantMore       bool
+	}{
+		{
+			name:      "branch",
+			reference: "branch1",
+			limit:     -1,
+			want: []version{
+				{Path: "/file1", Reference: v2},
+				{Path: "/file1", Reference: v1},
+				{Path: "/file2", Reference: d3, DeleteMarker: true},
+				{Path: "/file2", Reference: v1},
+			},
+		},
+		{
+			name:      "parent branch",
+			reference: "master",
+			limit:     -1,
+			want: []version{
+				{Path: "/file1", Reference: d4, DeleteMarker: true},
+				{Path: "/file1", Reference: v2},
+				{Path: "/file1", Reference: v1},
+				{Path: "/file2", Reference: v1},
+			},
+		},
+		{
+			name:      "prefix",
+			reference: "branch1",
+			prefix:    "/file2",
+			limit:     -1,
+			want: []version{
+				{Path: "/file2", Reference: d3, DeleteMarker: true},
+				{Path: "/file2", Reference: v1},
+			},
+		},
+		{
+			name:      "first page",
+			reference: "branch1",
+			limit:     3,
+			want: []version{
+				{Path: "/file1", Reference: v2},
+				{Path: "/file1", Reference: v1},
+				{Path: "/file2", Reference: d3, DeleteMarker: true},
+			},
+			wantMore: true,
+		},
+		{
+			name:           "after version",
+			reference:      "branch1",
+			afterPath:      "/file1",
+			afterReference: v2,
+			limit:          -1,
+			want: []version{
+				{Path: "/file1", Reference: v1},
+				{Path: "/file2", Reference: d3, DeleteMarker: true},
+				{Path: "/file2", Reference: v1},
+			},
+		},
+		{
+			name:      "after path",
+			reference: "branch1",
+			afterPath: "/file1",
+			limit:     -1,
+			want: []version{
+				{Path: "/file2", Reference: d3, DeleteMarker: true},
+				{Path: "/file2", Reference: v1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions, hasMore, err := c.ListEntriesVersions(ctx, repository, tt.reference, tt.prefix, tt.afterPath, tt.afterReference, tt.limit)
+			if err != nil {
+				t.Fatal("ListEntriesVersions() error:", err)
+			}
+			got := make([]version, len(versions))
+			for i, v := range versions {
+				got[i] = version{Path: v.Path, Reference: v.Reference, DeleteMarker: v.DeleteMarker}
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error("ListEntriesVersions()", diff)
+			}
+			if hasMore != tt.wantMore {
+				t.Errorf("ListEntriesVersions() hasMore = %t, expected %t", hasMore, tt.wantMore)
+			}
+		})
+	}
+}
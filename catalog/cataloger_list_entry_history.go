@@ -0,0 +1,87 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+const ListEntryHistoryMaxLimit = 10000
+
+func (c *cataloger) ListEntryHistory(ctx context.Context, repository, reference string, path string, after string, limit int) ([]*EntryVersion, bool, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "reference", IsValid: ValidateReference(reference)},
+		{Name: "path", IsValid: ValidatePath(path)},
+		{Name: "after", IsValid: ValidateOptionalString(after, IsValidReference)},
+	}); err != nil {
+		return nil, false, err
+	}
+	ref, err := ParseRef(reference)
+	if err != nil {
+		return nil, false, err
+	}
+	afterCommitID := MaxCommitID
+	if after != "" {
+		afterRef, err := ParseRef(after)
+		if err != nil {
+			return nil, false, err
+		}
+		if afterRef.CommitID <= 0 {
+			return nil, false, fmt.Errorf("after must be a commit reference: %w", ErrInvalidReference)
+		}
+		afterCommitID = afterRef.CommitID
+	}
+	if limit < 0 || limit > ListEntryHistoryMaxLimit {
+		limit = ListEntryHistoryMaxLimit
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		ref, branchID, err := c.getRefBranchIDCache(tx, repository, ref)
+		if err != nil {
+			return nil, err
+		}
+		lineage, err := getLineage(tx, branchID, ref.CommitID)
+		if err != nil {
+			return nil, fmt.Errorf("get lineage: %w", err)
+		}
+		untilCommitID := MaxCommitID
+		if ref.CommitID > 0 {
+			untilCommitID = ref.CommitID
+		}
+		// each committed entry of the path on the branch or its lineage is a version, created by its min commit.
+		// tombstones written by commit start and end on the same commit and are not versions.
+		query := `SELECT e.path, e.physical_address, e.creation_date, e.size, e.checksum, e.metadata, e.is_expired,
+				b.name AS branch_name, c.commit_id, c.committer, c.message, c.creation_date AS commit_date
+			FROM catalog_entries e
+				JOIN (SELECT * FROM ` + getLineageAsValues(lineage, branchID) + `) l
+					ON e.branch_id = l.branch_id AND e.min_commit <= l.commit_id
+				JOIN catalog_commits c ON c.branch_id = e.branch_id AND c.commit_id = e.min_commit
+				JOIN catalog_branches b ON b.id = e.branch_id
+			WHERE e.path = $1 AND e.min_commit > 0 AND e.min_commit <= $2 AND e.min_commit < $3
+				AND NOT (e.physical_address = '' AND e.min_commit = e.max_commit)
+			ORDER BY e.min_commit DESC
+			LIMIT $4`
+		var rawVersions []*entryVersionRaw
+		if err := tx.Select(&rawVersions, query, path, untilCommitID, afterCommitID, limit+1); err != nil {
+			return nil, fmt.Errorf("select versions: %w", err)
+		}
+		versions := make([]*EntryVersion, len(rawVersions))
+		for i, raw := range rawVersions {
+			versions[i] = &EntryVersion{
+				Entry:      raw.Entry,
+				Reference:  MakeReference(raw.BranchName, raw.CommitID),
+				Committer:  raw.Committer,
+				Message:    raw.Message,
+				CommitDate: raw.CommitDate,
+			}
+		}
+		return versions, nil
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, false, err
+	}
+	versions := res.([]*EntryVersion)
+	hasMore := paginateSlice(&versions, limit)
+	return versions, hasMore, nil
+}
@@ -0,0 +1,82 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCataloger_ListEntryHistory(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	commit := func(branch, seed string) string {
+		testCatalogerCreateEntry(t, ctx, c, repository, branch, "/file1", nil, seed)
+		commitLog, err := c.Commit(ctx, repository, branch, "commit "+seed, "tester", nil)
+		if err != nil {
+			t.Fatalf("commit %s: %s", seed, err)
+		}
+		return commitLog.Reference
+	}
+	v1 := commit("master", "v1")
+	v2 := commit("master", "v2")
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	v3 := commit("branch1", "v3")
+	v4 := commit("master", "v4")
+	// uncommitted changes and deletes are not versions
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file1", nil, "v5")
+	if err := c.DeleteEntry(ctx, repository, "master", "/file1"); err != nil {
+		t.Fatal("delete entry:", err)
+	}
+	if _, err := c.Commit(ctx, repository, "master", "delete file1", "tester", nil); err != nil {
+		t.Fatal("commit delete:", err)
+	}
+
+	tests := []struct {
+		name      string
+		reference string
+		after     string
+		limit     int
+		want      []string
+		wantMore  bool
+	}{
+		{name: "branch", reference: "branch1", limit: -1, want: []string{v3, v2, v1}},
+		{name: "parent branch", reference: "master", limit: -1, want: []string{v4, v2, v1}},
+		{name: "commit", reference: v2, limit: -1, want: []string{v2, v1}},
+		{name: "first page", reference: "branch1", limit: 2, want: []string{v3, v2}, wantMore: true},
+		{name: "next page", reference: "branch1", after: v2, limit: 2, want: []string{v1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions, hasMore, err := c.ListEntryHistory(ctx, repository, tt.reference, "/file1", tt.after, tt.limit)
+			if err != nil {
+				t.Fatal("ListEntryHistory() error:", err)
+			}
+			refs := make([]string, len(versions))
+			for i, v := range versions {
+				refs[i] = v.Reference
+			}
+			if diff := deep.Equal(refs, tt.want); diff != nil {
+				t.Error("ListEntryHistory() references", diff)
+			}
+			if hasMore != tt.wantMore {
+				t.Errorf("ListEntryHistory() hasMore = %t, expected %t", hasMore, tt.wantMore)
+			}
+		})
+	}
+
+	versions, _, err := c.ListEntryHistory(ctx, repository, "branch1", "/file1", "", 1)
+	if err != nil {
+		t.Fatal("ListEntryHistory() error:", err)
+	}
+	if len(versions) != 1 {
+		t.Fatalf("ListEntryHistory() got %d versions, expected 1", len(versions))
+	}
+	v := versions[0]
+	expectedChecksum := testCreateEntryCalcChecksum("/file1", "v3")
+	if v.Checksum != expectedChecksum || v.PhysicalAddress != expectedChecksum || v.Committer != "tester" || v.Message != "commit v3" {
+		t.Errorf("ListEntryHistory() version = %+v, expected checksum %s by tester", v, expectedChecksum)
+	}
+}
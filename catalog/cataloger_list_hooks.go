@@ -0,0 +1,39 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+func (c *cataloger) ListHooks(ctx context.Context, repository string) ([]*Hook, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+	}); err != nil {
+		return nil, err
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		repoID, err := c.getRepositoryIDCache(tx, repository)
+		if err != nil {
+			return nil, err
+		}
+		var rawHooks []*hookRaw
+		err = tx.Select(&rawHooks, `SELECT name, hook_type, url, timeout_ms, creation_date
+			FROM catalog_hooks
+			WHERE repository_id=$1
+			ORDER BY name`,
+			repoID)
+		if err != nil {
+			return nil, err
+		}
+		hooks := make([]*Hook, len(rawHooks))
+		for i, raw := range rawHooks {
+			hooks[i] = convertRawHook(repository, raw)
+		}
+		return hooks, nil
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, err
+	}
+	return res.([]*Hook), nil
+}
@@ -0,0 +1,37 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_ListHooks(t *testing.T) {
+	ctx := context.Background()
+	mockClock := clock.NewMock()
+	c := testCataloger(t, WithClock(mockClock))
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	hooks := []*Hook{
+		{Name: "rows", Type: HookTypePreMerge, URL: "http://localhost/rows", Timeout: time.Minute},
+		{Name: "schema", Type: HookTypePreCommit, URL: "http://localhost/schema", Timeout: time.Second},
+	}
+	for _, hook := range []*Hook{hooks[1], hooks[0]} {
+		testutil.MustDo(t, "create hook "+hook.Name, c.CreateHook(ctx, repository, hook))
+	}
+
+	got, err := c.ListHooks(ctx, repository)
+	testutil.MustDo(t, "list hooks", err)
+	for _, hook := range got {
+		hook.CreationDate = hook.CreationDate.UTC()
+	}
+	for _, hook := range hooks {
+		hook.CreationDate = hook.CreationDate.UTC()
+	}
+	if diff := deep.Equal(got, hooks); diff != nil {
+		t.Fatal("ListHooks()", diff)
+	}
+}
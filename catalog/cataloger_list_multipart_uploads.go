@@ -0,0 +1,51 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+const ListMultipartUploadsMaxLimit = 10000
+
+func (c *cataloger) ListMultipartUploads(ctx context.Context, repository, branch, prefix, afterPath, afterUploadID string, limit int) ([]*MultipartUpload, bool, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", IsValid: ValidateBranchName(branch)},
+	}); err != nil {
+		return nil, false, err
+	}
+	if limit < 0 || limit > ListMultipartUploadsMaxLimit {
+		limit = ListMultipartUploadsMaxLimit
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		branchID, err := c.getBranchIDCache(tx, repository, branch)
+		if err != nil {
+			return nil, err
+		}
+		// without after upload id, the listing starts after all the uploads of after path
+		var uploads []*MultipartUpload
+		err = tx.Select(&uploads, `
+			SELECT r.name AS repository, b.name AS branch, m.upload_id, m.path, m.creation_date,
+				m.physical_address, m.metadata
+			FROM catalog_multipart_uploads m
+				JOIN catalog_repositories r ON r.id = m.repository_id
+				JOIN catalog_branches b ON b.id = m.branch_id
+			WHERE m.branch_id = $1 AND m.path LIKE $2
+				AND (m.path > $3 OR (m.path = $3 AND $4 <> '' AND m.upload_id > $4))
+			ORDER BY m.path, m.upload_id
+			LIMIT $5`,
+			branchID, db.Prefix(prefix), afterPath, afterUploadID, limit+1)
+		if err != nil {
+			return nil, fmt.Errorf("select multipart uploads: %w", err)
+		}
+		return uploads, nil
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, false, err
+	}
+	uploads := res.([]*MultipartUpload)
+	hasMore := paginateSlice(&uploads, limit)
+	return uploads, hasMore, nil
+}
@@ -0,0 +1,117 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_ListMultipartUploads(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+
+	uploads := []struct {
+		branch   string
+		uploadID string
+		path     string
+	}{
+		{branch: "master", uploadID: "upload3", path: "dir/file1"},
+		{branch: "master", uploadID: "upload1", path: "dir/file1"},
+		{branch: "master", uploadID: "upload2", path: "dir/file2"},
+		{branch: "master", uploadID: "upload4", path: "file3"},
+		{branch: "branch1", uploadID: "upload5", path: "dir/file1"},
+	}
+	for _, u := range uploads {
+		err := c.CreateMultipartUpload(ctx, repository, u.branch, u.uploadID, u.path, "address-"+u.uploadID, time.Now(), nil)
+		testutil.MustDo(t, "create multipart upload "+u.uploadID, err)
+	}
+
+	tests := []struct {
+		name          string
+		branch        string
+		prefix        string
+		afterPath     string
+		afterUploadID string
+		limit         int
+		want          []string
+		wantMore      bool
+		wantErr       bool
+	}{
+		{
+			name:   "all",
+			branch: "master",
+			limit:  -1,
+			want:   []string{"upload1", "upload3", "upload2", "upload4"},
+		},
+		{
+			name:   "other branch",
+			branch: "branch1",
+			limit:  -1,
+			want:   []string{"upload5"},
+		},
+		{
+			name:   "prefix",
+			branch: "master",
+			prefix: "dir/",
+			limit:  -1,
+			want:   []string{"upload1", "upload3", "upload2"},
+		},
+		{
+			name:     "first page",
+			branch:   "master",
+			limit:    2,
+			want:     []string{"upload1", "upload3"},
+			wantMore: true,
+		},
+		{
+			name:          "after upload",
+			branch:        "master",
+			afterPath:     "dir/file1",
+			afterUploadID: "upload1",
+			limit:         -1,
+			want:          []string{"upload3", "upload2", "upload4"},
+		},
+		{
+			name:      "after path",
+			branch:    "master",
+			afterPath: "dir/file1",
+			limit:     -1,
+			want:      []string{"upload2", "upload4"},
+		},
+		{
+			name:    "unknown branch",
+			branch:  "branch2",
+			limit:   -1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, hasMore, err := c.ListMultipartUploads(ctx, repository, tt.branch, tt.prefix, tt.afterPath, tt.afterUploadID, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListMultipartUploads() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			uploadIDs := make([]string, len(got))
+			for i, u := range got {
+				uploadIDs[i] = u.UploadID
+				if u.Branch != tt.branch {
+					t.Errorf("ListMultipartUploads() upload %s branch %s, expected %s", u.UploadID, u.Branch, tt.branch)
+				}
+			}
+			if diff := deep.Equal(uploadIDs, tt.want); diff != nil {
+				t.Error("ListMultipartUploads()", diff)
+			}
+			if hasMore != tt.wantMore {
+				t.Errorf("ListMultipartUploads() hasMore = %t, expected %t", hasMore, tt.wantMore)
+			}
+		})
+	}
+}
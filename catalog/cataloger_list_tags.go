@@ -0,0 +1,46 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+const ListTagsMaxLimit = 10000
+
+func (c *cataloger) ListTags(ctx context.Context, repository string, limit int, after string) ([]*Tag, bool, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+	}); err != nil {
+		return nil, false, err
+	}
+	if limit < 0 || limit > ListTagsMaxLimit {
+		limit = ListTagsMaxLimit
+	}
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		repoID, err := c.getRepositoryIDCache(tx, repository)
+		if err != nil {
+			return nil, err
+		}
+		query := `SELECT t.name, b.name AS branch_name, t.commit_id, t.creation_date
+			FROM catalog_tags t JOIN catalog_branches b ON b.id = t.branch_id
+			WHERE t.repository_id = $1 AND t.name > $2
+			ORDER BY t.name
+			LIMIT $3`
+		var rawTags []*tagRaw
+		if err := tx.Select(&rawTags, query, repoID, after, limit+1); err != nil {
+			return nil, err
+		}
+		tags := make([]*Tag, len(rawTags))
+		for i, raw := range rawTags {
+			tags[i] = convertRawTag(repository, raw)
+		}
+		return tags, nil
+	}, c.txOpts(ctx, db.ReadOnly())...)
+	if err != nil {
+		return nil, false, err
+	}
+	tags := res.([]*Tag)
+	hasMore := paginateSlice(&tags, limit)
+	return tags, hasMore, nil
+}
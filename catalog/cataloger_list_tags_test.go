@@ -0,0 +1,48 @@
+package catalog
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_ListTags(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	for _, tag := range []string{"v3", "v1", "v2", "z1"} {
+		_, err := c.CreateTag(ctx, repository, tag, "master")
+		testutil.MustDo(t, "create tag "+tag, err)
+	}
+
+	tests := []struct {
+		name     string
+		limit    int
+		after    string
+		wantTags []string
+		wantMore bool
+	}{
+		{name: "all", limit: -1, wantTags: []string{"v1", "v2", "v3", "z1"}},
+		{name: "first two", limit: 2, wantTags: []string{"v1", "v2"}, wantMore: true},
+		{name: "after v2", limit: 2, after: "v2", wantTags: []string{"v3", "z1"}},
+		{name: "after last", limit: -1, after: "z1", wantTags: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotMore, err := c.ListTags(ctx, repository, tt.limit, tt.after)
+			testutil.MustDo(t, "list tags", err)
+			var names []string
+			for _, tag := range got {
+				names = append(names, tag.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantTags) {
+				t.Errorf("ListTags() names = %s, want %s", names, tt.wantTags)
+			}
+			if gotMore != tt.wantMore {
+				t.Errorf("ListTags() more = %t, want %t", gotMore, tt.wantMore)
+			}
+		})
+	}
+}
@@ -6,21 +6,42 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/jmoiron/sqlx"
+	sq "github.com/Masterminds/squirrel"
 	"github.com/treeverse/lakefs/db"
-	"github.com/treeverse/lakefs/logging"
 )
 
-func (c *cataloger) Merge(ctx context.Context, repository, leftBranch, rightBranch string, committer string, message string, metadata Metadata) (*MergeResult, error) {
+func (c *cataloger) Merge(ctx context.Context, repository, leftBranch, rightBranch string, committer string, message string, metadata Metadata, params MergeParams) (*MergeResult, error) {
 	if err := Validate(ValidateFields{
 		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
 		{Name: "leftBranch", IsValid: ValidateBranchName(leftBranch)},
 		{Name: "rightBranch", IsValid: ValidateBranchName(rightBranch)},
 		{Name: "committer", IsValid: ValidateCommitter(committer)},
+		{Name: "strategy", IsValid: ValidateMergeStrategy(params.Strategy)},
 	}); err != nil {
 		return nil, err
 	}
 
+	if message == "" {
+		message = formatMergeMessage(leftBranch, rightBranch)
+	}
+	var approvedState *mergeState
+	if !params.DryRun {
+		var err error
+		var result *MergeResult
+		approvedState, result, err = c.runMergeHooks(ctx, &HookEvent{
+			Type:         HookTypePreMerge,
+			Repository:   repository,
+			Branch:       rightBranch,
+			SourceBranch: leftBranch,
+			Committer:    committer,
+			Message:      message,
+			Metadata:     metadata,
+		}, params.Strategy)
+		if err != nil {
+			return result, err
+		}
+	}
+
 	var result *MergeResult
 	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
 		leftID, err := getBranchID(tx, repository, leftBranch, LockTypeUpdate)
@@ -31,45 +52,152 @@ func (c *cataloger) Merge(ctx context.Context, repository, leftBranch, rightBran
 		if err != nil {
 			return nil, fmt.Errorf("right branch: %w", err)
 		}
-		relation, err := getBranchesRelationType(tx, leftID, rightID)
-		if err != nil {
-			return nil, fmt.Errorf("branch relation: %w", err)
-		}
-
-		differences, err := c.doDiffByRelation(tx, relation, leftID, rightID)
+		var relation RelationType
+		result, relation, err = c.prepareMerge(tx, leftID, rightID, params.Strategy)
 		if err != nil {
 			return nil, err
 		}
-		result = &MergeResult{
-			Differences: differences,
-		}
-		diffCounts := result.Differences.CountByType()
-		if diffCounts[DifferenceTypeConflict] > 0 {
-			return nil, ErrConflictFound
+		// dry run - report the result without writing the merge
+		if params.DryRun {
+			return nil, nil
 		}
-		if len(diffCounts) == 0 {
-			leftCommitAdvanced, err := checkZeroDiffCommit(tx, leftID, rightID)
+		if approvedState != nil {
+			state, err := getMergeState(tx, leftID, rightID)
 			if err != nil {
 				return nil, err
 			}
-			if !leftCommitAdvanced {
-				return nil, ErrNoDifferenceWasFound
+			if state != *approvedState {
+				return nil, ErrBranchChangedDuringHooks
 			}
 		}
 
-		if message == "" {
-			message = formatMergeMessage(leftBranch, rightBranch)
-		}
 		commitID, err := c.doMergeByRelation(tx, relation, leftID, rightID, committer, message, metadata)
 		if err != nil {
 			return nil, err
 		}
 		result.Reference = MakeReference(rightBranch, commitID)
-		return nil, err
+		return nil, c.addEvent(tx, &Event{
+			Type:         EventTypeMerge,
+			Repository:   repository,
+			Branch:       rightBranch,
+			SourceBranch: leftBranch,
+			Reference:    result.Reference,
+			Committer:    committer,
+			Message:      message,
+			Metadata:     metadata,
+		})
 	}, c.txOpts(ctx)...)
 	return result, err
 }
 
+// prepareMerge calculates the differences the merge of the left branch into the right branch will write, and
+// resolves conflicts by the strategy.  The differences are left in the diff results table.
+func (c *cataloger) prepareMerge(tx db.Tx, leftID, rightID int64, strategy MergeStrategy) (*MergeResult, RelationType, error) {
+	relation, err := getBranchesRelationType(tx, leftID, rightID)
+	if err != nil {
+		return nil, relation, fmt.Errorf("branch relation: %w", err)
+	}
+	if err := c.diffByRelation(tx, relation, leftID, rightID); err != nil {
+		return nil, relation, err
+	}
+	differences, err := diffReadDifferences(tx)
+	if err != nil {
+		return nil, relation, err
+	}
+	result := &MergeResult{
+		Differences: differences,
+	}
+	diffCounts := result.Differences.CountByType()
+	result.Summary = diffCounts
+	conflictsResolved := false
+	if diffCounts[DifferenceTypeConflict] > 0 {
+		conflictsResolved, err = resolveMergeConflicts(tx, strategy, leftID, rightID)
+		if err != nil {
+			return result, relation, err
+		}
+		if !conflictsResolved {
+			result.Conflicts, err = getMergeConflicts(tx, leftID, rightID)
+			if err != nil {
+				return result, relation, err
+			}
+			return result, relation, ErrConflictFound
+		}
+		result.Differences, err = diffReadDifferences(tx)
+		if err != nil {
+			return result, relation, err
+		}
+		diffCounts = result.Differences.CountByType()
+		result.Summary = diffCounts
+	}
+	// a merge that resolved conflicts is recorded even without changes, so the next merge will not
+	// report the same conflicts
+	if len(diffCounts) == 0 && !conflictsResolved {
+		leftCommitAdvanced, err := checkZeroDiffCommit(tx, leftID, rightID)
+		if err != nil {
+			return result, relation, err
+		}
+		if !leftCommitAdvanced {
+			return result, relation, ErrNoDifferenceWasFound
+		}
+	}
+	return result, relation, nil
+}
+
+// mergeState is the state of the merged branches the pre-merge hooks approved
+type mergeState struct {
+	Left  branchState
+	Right branchState
+}
+
+func getMergeState(tx db.Tx, leftID, rightID int64) (mergeState, error) {
+	var state mergeState
+	var err error
+	state.Left, err = getBranchState(tx, leftID)
+	if err != nil {
+		return state, err
+	}
+	state.Right, err = getBranchState(tx, rightID)
+	return state, err
+}
+
+// runMergeHooks calls the pre-merge hooks with the merge differences, outside of the merge transaction.  It returns
+// the branches state the hooks approved, or nil when there are no hooks to run.  When the merge can't be done the
+// error is returned with the merge result, the same way Merge reports it.
+func (c *cataloger) runMergeHooks(ctx context.Context, event *HookEvent, strategy MergeStrategy) (*mergeState, *MergeResult, error) {
+	var hooks []*Hook
+	var state mergeState
+	var result *MergeResult
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		var err error
+		hooks, err = c.getHooksToRun(tx, event.Repository, event.Type)
+		if err != nil || len(hooks) == 0 {
+			return nil, err
+		}
+		leftID, err := getBranchID(tx, event.Repository, event.SourceBranch, LockTypeNone)
+		if err != nil {
+			return nil, fmt.Errorf("left branch: %w", err)
+		}
+		rightID, err := getBranchID(tx, event.Repository, event.Branch, LockTypeNone)
+		if err != nil {
+			return nil, fmt.Errorf("right branch: %w", err)
+		}
+		result, _, err = c.prepareMerge(tx, leftID, rightID, strategy)
+		if err != nil {
+			return nil, err
+		}
+		event.Differences = result.Differences
+		state, err = getMergeState(tx, leftID, rightID)
+		return nil, err
+	}, c.txOpts(ctx)...)
+	if err != nil || len(hooks) == 0 {
+		return nil, result, err
+	}
+	if err := c.hookRunner.Run(ctx, hooks, event); err != nil {
+		return nil, nil, err
+	}
+	return &state, nil, nil
+}
+
 // checkZeroDiffCommit - Checks if the current commit id of source branch advanced since last merge.
 //		If so - a merge record must be created, even if there are no changes between branches.
 func checkZeroDiffCommit(tx db.Tx, leftID, rightID int64) (bool, error) {
@@ -95,6 +223,127 @@ func checkZeroDiffCommit(tx db.Tx, leftID, rightID int64) (bool, error) {
 	return leftMaxCommitID > mergeMaxCommitID, nil
 }
 
+// resolveMergeConflicts updates the conflicts found in diff results table based on the merge strategy.
+// Returns false when the conflicts can't be resolved: no strategy was requested, or the destination branch
+// has uncommitted changes on a conflicting path.
+func resolveMergeConflicts(tx db.Tx, strategy MergeStrategy, leftID, rightID int64) (bool, error) {
+	if strategy == "" || strategy == MergeStrategyFail {
+		return false, nil
+	}
+	var uncommittedConflict bool
+	err := tx.Get(&uncommittedConflict, `SELECT EXISTS (SELECT 1 FROM catalog_entries
+			WHERE branch_id = $1 AND min_commit = 0
+				AND path IN (SELECT path FROM `+diffResultsTableName+` WHERE diff_type = $2))`,
+		rightID, DifferenceTypeConflict)
+	if err != nil {
+		return false, fmt.Errorf("uncommitted conflicts: %w", err)
+	}
+	if uncommittedConflict {
+		return false, nil
+	}
+
+	switch strategy {
+	case MergeStrategyDestWins:
+		// keep the destination version - nothing to apply
+		_, err = tx.Exec(`DELETE FROM `+diffResultsTableName+` WHERE diff_type = $1`, DifferenceTypeConflict)
+		if err != nil {
+			return false, fmt.Errorf("resolve conflicts: %w", err)
+		}
+	case MergeStrategySourceWins:
+		// take the source version - change to the source entry, or remove if the source deleted it
+		leftLineage, err := getLineage(tx, leftID, CommittedID)
+		if err != nil {
+			return false, fmt.Errorf("source lineage failed: %w", err)
+		}
+		sourceSQL, args, err := sq.Select("path", "entry_ctid").
+			FromSelect(sqEntriesLineage(leftID, CommittedID, leftLineage), "e").
+			Where("NOT is_deleted").
+			ToSql()
+		if err != nil {
+			return false, fmt.Errorf("source entries sql: %w", err)
+		}
+		resolveArgs := append([]interface{}{DifferenceTypeChanged}, args...)
+		resolveArgs = append(resolveArgs, DifferenceTypeConflict)
+		resolveSQL, err := sq.Dollar.ReplacePlaceholders(`UPDATE ` + diffResultsTableName + ` d
+			SET diff_type = ?, entry_ctid = s.entry_ctid
+			FROM (` + sourceSQL + `) s
+			WHERE s.path = d.path AND d.diff_type = ?`)
+		if err != nil {
+			return false, fmt.Errorf("resolve conflicts sql: %w", err)
+		}
+		_, err = tx.Exec(resolveSQL, resolveArgs...)
+		if err != nil {
+			return false, fmt.Errorf("resolve changed conflicts: %w", err)
+		}
+		_, err = tx.Exec(`UPDATE `+diffResultsTableName+` SET diff_type = $1 WHERE diff_type = $2`,
+			DifferenceTypeRemoved, DifferenceTypeConflict)
+		if err != nil {
+			return false, fmt.Errorf("resolve removed conflicts: %w", err)
+		}
+	default:
+		return false, fmt.Errorf("%w: strategy", ErrInvalidValue)
+	}
+	return true, nil
+}
+
+// getMergeConflicts returns the source and destination entries of each conflict found in diff results table
+func getMergeConflicts(tx db.Tx, leftID, rightID int64) ([]MergeConflict, error) {
+	var paths []string
+	err := tx.Select(&paths, `SELECT path FROM `+diffResultsTableName+` WHERE diff_type = $1 ORDER BY path`,
+		DifferenceTypeConflict)
+	if err != nil {
+		return nil, fmt.Errorf("conflict paths: %w", err)
+	}
+	leftLineage, err := getLineage(tx, leftID, CommittedID)
+	if err != nil {
+		return nil, fmt.Errorf("source lineage failed: %w", err)
+	}
+	conflictPaths := sq.Expr("path IN (SELECT path FROM "+diffResultsTableName+" WHERE diff_type = ?)", DifferenceTypeConflict)
+	sourceEntries, err := selectLineageEntries(tx, sqEntriesLineage(leftID, CommittedID, leftLineage), conflictPaths)
+	if err != nil {
+		return nil, fmt.Errorf("source entries: %w", err)
+	}
+	rightLineage, err := getLineage(tx, rightID, UncommittedID)
+	if err != nil {
+		return nil, fmt.Errorf("destination lineage failed: %w", err)
+	}
+	destinationEntries, err := selectLineageEntries(tx, sqEntriesLineage(rightID, UncommittedID, rightLineage), conflictPaths)
+	if err != nil {
+		return nil, fmt.Errorf("destination entries: %w", err)
+	}
+	conflicts := make([]MergeConflict, len(paths))
+	for i, p := range paths {
+		conflicts[i] = MergeConflict{
+			Path:        p,
+			Source:      sourceEntries[p],
+			Destination: destinationEntries[p],
+		}
+	}
+	return conflicts, nil
+}
+
+// selectLineageEntries returns the entries, that are not deleted, of a lineage query matching pathCond by path
+func selectLineageEntries(tx db.Tx, lineageQ sq.SelectBuilder, pathCond sq.Sqlizer) (map[string]*Entry, error) {
+	query, args, err := psql.
+		Select("path", "physical_address", "creation_date", "size", "checksum", "metadata", "is_expired").
+		FromSelect(lineageQ, "e").
+		Where("NOT is_deleted").
+		Where(pathCond).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build sql: %w", err)
+	}
+	var entries []*Entry
+	if err := tx.Select(&entries, query, args...); err != nil {
+		return nil, err
+	}
+	entriesByPath := make(map[string]*Entry, len(entries))
+	for _, ent := range entries {
+		entriesByPath[ent.Path] = ent
+	}
+	return entriesByPath, nil
+}
+
 func formatMergeMessage(leftBranch string, rightBranch string) string {
 	return fmt.Sprintf("Merge '%s' into '%s'", leftBranch, rightBranch)
 }
@@ -171,51 +420,84 @@ func (c *cataloger) mergeFromFather(tx db.Tx, previousMaxCommitID, nextCommitID
 }
 
 func (c *cataloger) mergeFromSon(tx db.Tx, previousMaxCommitID, nextCommitID CommitID, sonID int64, fatherID int64, committer string, msg string, metadata Metadata) error {
-	// DifferenceTypeRemoved and DifferenceTypeChanged - set max_commit the our commit for committed entries in father branch
+	err := mergeDiffResults(tx, previousMaxCommitID, nextCommitID, fatherID)
+	if err != nil {
+		return err
+	}
+	sonLastCommitID, err := getLastCommitIDByBranchID(tx, sonID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO catalog_commits (branch_id,commit_id,previous_commit_id,committer,message,creation_date,metadata,merge_type,merge_source_branch,merge_source_commit)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,'from_son',$8,$9)`,
+		fatherID, nextCommitID, previousMaxCommitID, committer, msg, c.clock.Now(), metadata, sonID, sonLastCommitID)
+	return err
+}
+
+func (c *cataloger) mergeNonDirect(tx db.Tx, previousMaxCommitID, nextCommitID CommitID, leftID, rightID int64, committer string, msg string, metadata Metadata) error {
+	err := mergeDiffResults(tx, previousMaxCommitID, nextCommitID, rightID)
+	if err != nil {
+		return err
+	}
+	// the merge source commit is used as the base of the next merge between the two branches
+	leftLastCommitID, err := getLastCommitIDByBranchID(tx, leftID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO catalog_commits (branch_id,commit_id,previous_commit_id,committer,message,creation_date,metadata,merge_type,merge_source_branch,merge_source_commit)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,'non_direct',$8,$9)`,
+		rightID, nextCommitID, previousMaxCommitID, committer, msg, c.clock.Now(), metadata, leftID, leftLastCommitID)
+	return err
+}
+
+// mergeDiffResults applies the differences found in diff results table on the destination branch as committed entries
+func mergeDiffResults(tx db.Tx, previousMaxCommitID, nextCommitID CommitID, branchID int64) error {
+	// DifferenceTypeRemoved and DifferenceTypeChanged - set max_commit the our commit for committed entries in destination branch
 	_, err := tx.Exec(`UPDATE catalog_entries SET max_commit = $2
 			WHERE branch_id = $1 AND max_commit = catalog_max_commit_id()
 				AND path in (SELECT path FROM `+diffResultsTableName+` WHERE diff_type IN ($3,$4))`,
-		fatherID, previousMaxCommitID, DifferenceTypeRemoved, DifferenceTypeChanged)
+		branchID, previousMaxCommitID, DifferenceTypeRemoved, DifferenceTypeChanged)
 	if err != nil {
 		return err
 	}
 
-	// DifferenceTypeChanged or DifferenceTypeAdded - create entries into this commit based on father branch
+	// DifferenceTypeChanged or DifferenceTypeAdded - create entries into this commit based on source branch
 	_, err = tx.Exec(`INSERT INTO catalog_entries (branch_id,path,physical_address,creation_date,size,checksum,metadata,min_commit)
 				SELECT $1,path,physical_address,creation_date,size,checksum,metadata,$2 AS min_commit
 				FROM catalog_entries e
 				WHERE e.ctid IN (SELECT entry_ctid FROM `+diffResultsTableName+` WHERE diff_type IN ($3,$4))`,
-		fatherID, nextCommitID, DifferenceTypeAdded, DifferenceTypeChanged)
+		branchID, nextCommitID, DifferenceTypeAdded, DifferenceTypeChanged)
 	if err != nil {
 		return err
 	}
-	// DifferenceTypeRemoved - create tombstones if father "sees" those entries from lineage branches
+	// DifferenceTypeRemoved - create tombstones if destination "sees" those entries from lineage branches
 	_, err = tx.Exec(`INSERT INTO catalog_entries (branch_id,path,physical_address,size,checksum,metadata,min_commit,max_commit)
 				SELECT $1,path,'',0,'','{}',$2,0
 				FROM `+diffResultsTableName+`
 				WHERE diff_type=$3 AND source_branch<>$1`,
-		fatherID, nextCommitID, DifferenceTypeRemoved)
+		branchID, nextCommitID, DifferenceTypeRemoved)
+	return err
+}
+
+// commitDiffResults applies the differences found in diff results table on the branch as a new commit
+func (c *cataloger) commitDiffResults(tx db.Tx, branchID int64, committer string, message string, metadata Metadata) (CommitID, error) {
+	previousMaxCommitID, err := getLastCommitIDByBranchID(tx, branchID)
 	if err != nil {
-		return err
+		return 0, fmt.Errorf("last commit id: %w", err)
 	}
-	sonLastCommitID, err := getLastCommitIDByBranchID(tx, sonID)
+	nextCommitID, err := getNextCommitID(tx)
 	if err != nil {
-		return err
+		return 0, fmt.Errorf("next commit id: %w", err)
 	}
-	_, err = tx.Exec(`INSERT INTO catalog_commits (branch_id,commit_id,previous_commit_id,committer,message,creation_date,metadata,merge_type,merge_source_branch,merge_source_commit)
-		VALUES ($1,$2,$3,$4,$5,$6,$7,'from_son',$8,$9)`,
-		fatherID, nextCommitID, previousMaxCommitID, committer, msg, c.clock.Now(), metadata, sonID, sonLastCommitID)
-	return err
-}
-
-func (c *cataloger) mergeNonDirect(_ sqlx.Execer, previousMaxCommitID, nextCommitID CommitID, leftID, rightID int64, committer string, msg string, _ Metadata) error {
-	c.log.WithFields(logging.Fields{
-		"commit_id":      previousMaxCommitID,
-		"next_commit_id": nextCommitID,
-		"left_id":        leftID,
-		"right_id":       rightID,
-		"committer":      committer,
-		"msg":            msg,
-	}).Debug("Merge non direct - feature not supported")
-	return ErrFeatureNotSupported
+	err = mergeDiffResults(tx, previousMaxCommitID, nextCommitID, branchID)
+	if err != nil {
+		return 0, fmt.Errorf("apply changes: %w", err)
+	}
+	_, err = tx.Exec(`INSERT INTO catalog_commits (branch_id,commit_id,committer,message,creation_date,metadata,merge_type,previous_commit_id)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`,
+		branchID, nextCommitID, committer, message, c.clock.Now(), metadata, RelationTypeNone, previousMaxCommitID)
+	if err != nil {
+		return 0, fmt.Errorf("insert commit: %w", err)
+	}
+	return nextCommitID, nil
 }
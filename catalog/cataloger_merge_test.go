@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/go-test/deep"
 	"github.com/treeverse/lakefs/testutil"
 )
 
@@ -49,7 +50,7 @@ func TestCataloger_Merge_FromFatherNoChangesInChild(t *testing.T) {
 	}
 
 	// merge master to branch1
-	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, MergeParams{})
 	if err != nil {
 		t.Fatal("Merge from master to branch1 failed:", err)
 	}
@@ -112,7 +113,7 @@ func TestCataloger_Merge_FromFatherConflicts(t *testing.T) {
 	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", overFilename, nil, "seed2")
 
 	// merge should identify conflicts on pending changes
-	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, MergeParams{})
 	// expected to find 2 conflicts on the files we update/created with the same path
 
 	if !errors.Is(err, ErrConflictFound) {
@@ -137,7 +138,7 @@ func TestCataloger_Merge_FromFatherNoChangesInFather(t *testing.T) {
 	c := testCataloger(t)
 	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
 	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
-	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, MergeParams{})
 	expectedErr := ErrNoDifferenceWasFound
 	if !errors.Is(err, expectedErr) {
 		t.Errorf("Merge err = %s, expected %s", err, expectedErr)
@@ -190,7 +191,7 @@ func TestCataloger_Merge_FromFatherChangesInBoth(t *testing.T) {
 	testutil.MustDo(t, "first commit on branch1", err)
 
 	// merge should work and grab all the changes from master
-	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, MergeParams{})
 	if err != nil {
 		t.Fatal("Merge from master to branch1 failed:", err)
 	}
@@ -253,10 +254,10 @@ func TestCataloger_Merge_FromFatherThreeBranches(t *testing.T) {
 	testutil.MustDo(t, "second commit to master", err)
 
 	// merge the above down (from master) to branch1
-	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, MergeParams{})
 	testutil.MustDo(t, "Merge changes from master to branch1", err)
 	// merge the changes from branch1 to branch2
-	res, err := c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil, MergeParams{})
 	testutil.MustDo(t, "Merge changes from master to branch1", err)
 
 	// verify valid commit id
@@ -295,7 +296,7 @@ func TestCataloger_Merge_FromSonNoChanges(t *testing.T) {
 	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
 
 	// merge empty branch into master
-	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{})
 	expectedErr := ErrNoDifferenceWasFound
 	if !errors.Is(err, expectedErr) {
 		t.Fatalf("Merge from branch1 to master err=%s, expected=%s", err, expectedErr)
@@ -338,7 +339,7 @@ func TestCataloger_Merge_FromSonChangesOnSon(t *testing.T) {
 	testutil.MustDo(t, "First commit to branch1", err)
 
 	// merge empty branch into master
-	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{})
 	if err != nil {
 		t.Fatalf("Merge from branch1 to master err=%s, expected none", err)
 	}
@@ -399,7 +400,7 @@ func TestCataloger_Merge_FromSonThreeBranches(t *testing.T) {
 	testutil.MustDo(t, "second commit to branch2", err)
 
 	// merge the above up to master (from branch2)
-	res, err := c.Merge(ctx, repository, "branch2", "branch1", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch2", "branch1", "tester", "", nil, MergeParams{})
 	testutil.MustDo(t, "Merge changes from branch2 to branch1", err)
 
 	if !IsValidReference(res.Reference) {
@@ -427,7 +428,7 @@ func TestCataloger_Merge_FromSonThreeBranches(t *testing.T) {
 	})
 
 	// merge the changes from branch1 to master
-	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{})
 	testutil.MustDo(t, "Merge changes from branch1 to master", err)
 
 	// verify valid commit id
@@ -473,7 +474,7 @@ func TestCataloger_Merge_FromSonNewDelSameEntry(t *testing.T) {
 	testutil.MustDo(t, "add new file to branch", err)
 
 	// merge branch to master
-	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{})
 	if err != nil {
 		t.Fatalf("Merge from branch1 to master err=%s, expected none", err)
 	}
@@ -495,7 +496,7 @@ func TestCataloger_Merge_FromSonNewDelSameEntry(t *testing.T) {
 	testutil.MustDo(t, "Commit with deleted file", err)
 
 	// merge branch to master
-	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{})
 	if err != nil {
 		t.Fatalf("Merge from branch1 to master err=%s, expected none", err)
 	}
@@ -523,7 +524,7 @@ func TestCataloger_Merge_FromSonNewEntrySameEntry(t *testing.T) {
 	testutil.MustDo(t, "add new file to branch", err)
 
 	// merge branch to master
-	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{})
 	if err != nil {
 		t.Fatalf("Merge from branch1 to master err=%s, expected none", err)
 	}
@@ -544,7 +545,7 @@ func TestCataloger_Merge_FromSonNewEntrySameEntry(t *testing.T) {
 	testutil.MustDo(t, "add same file to branch", err)
 
 	// merge branch to master
-	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{})
 	if err != nil {
 		t.Fatalf("Merge from branch1 to master err=%s, expected none", err)
 	}
@@ -580,7 +581,7 @@ func TestCataloger_Merge_FromSonDelModifyGrandfatherFiles(t *testing.T) {
 	testutil.MustDo(t, "Commit with deleted file", err)
 
 	// merge changes from branch2 to branch1
-	res, err := c.Merge(ctx, repository, "branch2", "branch1", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch2", "branch1", "tester", "", nil, MergeParams{})
 	if err != nil {
 		t.Fatalf("Merge from branch2 to branch1 err=%s, expected none", err)
 	}
@@ -623,7 +624,7 @@ func TestCataloger_Merge_FromSonConflicts(t *testing.T) {
 	testutil.MustDo(t, "modify /file0 on master", err)
 
 	// merge changes from branch to master should find the conflict
-	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{})
 	if !errors.Is(err, ErrConflictFound) {
 		t.Fatalf("Merge from branch1 to master err=%s, expected conflict", err)
 	}
@@ -675,10 +676,10 @@ func TestCataloger_Merge_FromFatherThreeBranchesExtended1(t *testing.T) {
 	testutil.MustDo(t, "second commit to master", err)
 
 	// merge the above down (from master) to branch1
-	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, MergeParams{})
 	testutil.MustDo(t, "Merge changes from master to branch1", err)
 	// merge the changes from branch1 to branch2
-	res, err := c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil)
+	res, err := c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil, MergeParams{})
 	testutil.MustDo(t, "Merge changes from master to branch1", err)
 
 	// verify valid commit id
@@ -708,14 +709,14 @@ func TestCataloger_Merge_FromFatherThreeBranchesExtended1(t *testing.T) {
 	testCatalogerGetEntry(t, ctx, c, repository, "branch1", "/file0", true)
 	testCatalogerGetEntry(t, ctx, c, repository, "master", "/file0", false)
 
-	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, MergeParams{})
 	testutil.MustDo(t, "merge to master to branch1", err)
 
 	testCatalogerGetEntry(t, ctx, c, repository, "branch2", "/file0", true)
 	testCatalogerGetEntry(t, ctx, c, repository, "branch1", "/file0", false)
 	testCatalogerGetEntry(t, ctx, c, repository, "master", "/file0", false)
 
-	_, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil)
+	_, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil, MergeParams{})
 	testutil.MustDo(t, "merge branch1 to branch2", err)
 
 	testCatalogerGetEntry(t, ctx, c, repository, "branch2", "/file0", false)
@@ -728,7 +729,7 @@ func TestCataloger_Merge_FromFatherThreeBranchesExtended1(t *testing.T) {
 	_, _ = c.Commit(ctx, repository, "branch2", "commit file0 creation", "tester", nil)
 	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "seed1")
 	_, _ = c.Commit(ctx, repository, "master", "commit file0 creation", "tester", nil)
-	res, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil)
+	res, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, MergeParams{})
 	testutil.MustDo(t, "merge master to branch1", err)
 	if res == nil {
 		t.Fatal("No merge results")
@@ -739,7 +740,7 @@ func TestCataloger_Merge_FromFatherThreeBranchesExtended1(t *testing.T) {
 	if !res.Differences.Equal(expectedDifferences) {
 		t.Errorf("Merge differences = %s, expected %s", spew.Sdump(res.Differences), spew.Sdump(expectedDifferences))
 	}
-	res, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil)
+	res, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "", nil, MergeParams{})
 	testutil.MustDo(t, "merge branch1 to branch2", err)
 	if res == nil {
 		t.Fatal("No merge results")
@@ -753,13 +754,13 @@ func TestCataloger_Merge_FromFatherThreeBranchesExtended1(t *testing.T) {
 		c.DeleteEntry(ctx, repository, "master", "/file0"))
 	_, err = c.Commit(ctx, repository, "master", "commit file0 deletion", "tester", nil)
 	testutil.MustDo(t, "commit file0 delete", err)
-	res, err = c.Merge(ctx, repository, "master", "branch1", "tester", "bubling /file0 deletion up", nil)
+	res, err = c.Merge(ctx, repository, "master", "branch1", "tester", "bubling /file0 deletion up", nil, MergeParams{})
 	testutil.MustDo(t, "merge master to branch1", err)
 	if res == nil {
 		t.Fatal("No merge results")
 	}
 
-	res, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "forcing file0 on branch2 to delete", nil)
+	res, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "forcing file0 on branch2 to delete", nil, MergeParams{})
 	testutil.MustDo(t, "merge master to branch1", err)
 	if res == nil {
 		t.Fatal("No merge results")
@@ -773,10 +774,10 @@ func TestCataloger_Merge_FromFatherThreeBranchesExtended1(t *testing.T) {
 	}
 
 	//identical entries created in son and grandfather do not create conflict - even when grandfather is uncommitted
-	_, err = c.Merge(ctx, repository, "branch2", "branch1", "tester", "empty updates", nil)
+	_, err = c.Merge(ctx, repository, "branch2", "branch1", "tester", "empty updates", nil, MergeParams{})
 	testutil.MustDo(t, "merge branch2 to branch1", err)
 
-	_, err = c.Merge(ctx, repository, "branch1", "master", "tester", "empty updates", nil)
+	_, err = c.Merge(ctx, repository, "branch1", "master", "tester", "empty updates", nil, MergeParams{})
 	testutil.MustDo(t, "merge branch1 to master", err)
 
 	testCatalogerCreateEntry(t, ctx, c, repository, "branch2", "/file111", nil, "seed1")
@@ -784,20 +785,20 @@ func TestCataloger_Merge_FromFatherThreeBranchesExtended1(t *testing.T) {
 	testutil.MustDo(t, "commit file0 creation to branch2", err)
 
 	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file111", nil, "seed1")
-	_, err = c.Merge(ctx, repository, "branch2", "branch1", "tester", "pushing /file111 down", nil)
+	_, err = c.Merge(ctx, repository, "branch2", "branch1", "tester", "pushing /file111 down", nil, MergeParams{})
 	testutil.MustDo(t, "merge branch2 to branch1", err)
 
-	_, err = c.Merge(ctx, repository, "branch1", "master", "tester", "pushing /file111 down", nil)
+	_, err = c.Merge(ctx, repository, "branch1", "master", "tester", "pushing /file111 down", nil, MergeParams{})
 	testutil.MustDo(t, "merge branch1 to master", err)
 
 	// push file111 delete
-	_, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "delete /file111 up", nil)
+	_, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "delete /file111 up", nil, MergeParams{})
 	testutil.MustDo(t, "delete committed file on branch1",
 		c.DeleteEntry(ctx, repository, "branch1", "/file111"))
 	_, err = c.Commit(ctx, repository, "branch1", "commit file111 deletion", "tester", nil)
 	testutil.MustDo(t, "commit file111 to branch1", err)
 
-	res, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "delete /file111 up", nil)
+	res, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "delete /file111 up", nil, MergeParams{})
 	testutil.MustDo(t, "merge branch1 to branch2", err)
 	if res == nil {
 		t.Fatal("No merge results")
@@ -809,7 +810,7 @@ func TestCataloger_Merge_FromFatherThreeBranchesExtended1(t *testing.T) {
 		t.Errorf("Merge differences = %s, expected %s", spew.Sdump(res.Differences), spew.Sdump(expectedDifferences))
 	}
 
-	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "try delete /file111 . get conflict", nil)
+	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "try delete /file111 . get conflict", nil, MergeParams{})
 	if !errors.Is(err, ErrConflictFound) {
 		t.Fatalf("Expected to get conflict error, got err=%+v", err)
 	}
@@ -823,3 +824,499 @@ func TestCataloger_Merge_FromFatherThreeBranchesExtended1(t *testing.T) {
 		t.Errorf("Merge differences = %s, expected %s", spew.Sdump(res.Differences), spew.Sdump(expectedDifferences))
 	}
 }
+
+func TestCataloger_Merge_NonDirectSiblings(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+
+	// create 3 files on master and commit
+	for i := 0; i < 3; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+
+	// create two sibling branches based on master
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch2", "master")
+
+	// add, delete and change files on branch1
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file5", nil, "")
+	testutil.MustDo(t, "delete committed file on branch1",
+		c.DeleteEntry(ctx, repository, "branch1", "/file1"))
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file2", nil, "seed1")
+	_, err = c.Commit(ctx, repository, "branch1", "commit to branch1", "tester", nil)
+	testutil.MustDo(t, "commit to branch1", err)
+
+	// unrelated change on branch2 should be kept
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch2", "/file6", nil, "")
+	_, err = c.Commit(ctx, repository, "branch2", "commit to branch2", "tester", nil)
+	testutil.MustDo(t, "commit to branch2", err)
+
+	res, err := c.Merge(ctx, repository, "branch1", "branch2", "tester", "merge branch1 to branch2", nil, MergeParams{})
+	testutil.MustDo(t, "merge branch1 to branch2", err)
+	if !IsValidReference(res.Reference) {
+		t.Fatalf("Merge reference = %s, expected valid reference", res.Reference)
+	}
+	expectedDifferences := Differences{
+		Difference{Type: DifferenceTypeChanged, Path: "/file2"},
+		Difference{Type: DifferenceTypeAdded, Path: "/file5"},
+		Difference{Type: DifferenceTypeRemoved, Path: "/file1"},
+	}
+	if !res.Differences.Equal(expectedDifferences) {
+		t.Fatalf("Merge differences = %s, expected %s", spew.Sdump(res.Differences), spew.Sdump(expectedDifferences))
+	}
+	testVerifyEntries(t, ctx, c, repository, "branch2", []testEntryInfo{
+		{Path: "/file0"},
+		{Path: "/file1", Deleted: true},
+		{Path: "/file2", Seed: "seed1"},
+		{Path: "/file5"},
+		{Path: "/file6"},
+	})
+	// master is not affected by the merge
+	testVerifyEntries(t, ctx, c, repository, "master", []testEntryInfo{
+		{Path: "/file1"},
+		{Path: "/file2"},
+		{Path: "/file5", Deleted: true},
+	})
+}
+
+func TestCataloger_Merge_NonDirectRepeated(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch2", "master")
+
+	// first merge between the siblings
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file1", nil, "")
+	_, err = c.Commit(ctx, repository, "branch1", "commit file1 to branch1", "tester", nil)
+	testutil.MustDo(t, "commit to branch1", err)
+	_, err = c.Merge(ctx, repository, "branch1", "branch2", "tester", "first merge", nil, MergeParams{})
+	testutil.MustDo(t, "first merge branch1 to branch2", err)
+
+	// second merge should include only the changes made after the first merge
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file2", nil, "")
+	_, err = c.Commit(ctx, repository, "branch1", "commit file2 to branch1", "tester", nil)
+	testutil.MustDo(t, "commit to branch1", err)
+	res, err := c.Merge(ctx, repository, "branch1", "branch2", "tester", "second merge", nil, MergeParams{})
+	testutil.MustDo(t, "second merge branch1 to branch2", err)
+	expectedDifferences := Differences{
+		Difference{Type: DifferenceTypeAdded, Path: "/file2"},
+	}
+	if !res.Differences.Equal(expectedDifferences) {
+		t.Fatalf("Merge differences = %s, expected %s", spew.Sdump(res.Differences), spew.Sdump(expectedDifferences))
+	}
+
+	// merge back to branch1 - branch2 holds the same content
+	res, err = c.Merge(ctx, repository, "branch2", "branch1", "tester", "merge back", nil, MergeParams{})
+	testutil.MustDo(t, "merge branch2 to branch1", err)
+	if len(res.Differences) != 0 {
+		t.Fatalf("Merge differences = %s, expected none", spew.Sdump(res.Differences))
+	}
+	testVerifyEntries(t, ctx, c, repository, "branch2", []testEntryInfo{
+		{Path: "/file0"},
+		{Path: "/file1"},
+		{Path: "/file2"},
+	})
+}
+
+func TestCataloger_Merge_NonDirectConflicts(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	for i := 0; i < 3; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch2", "master")
+
+	// change the same file on both branches, and delete on one while changed on the other
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file1", nil, "seed1")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file2", nil, "seed1")
+	_, err = c.Commit(ctx, repository, "branch1", "commit to branch1", "tester", nil)
+	testutil.MustDo(t, "commit to branch1", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch2", "/file1", nil, "seed2")
+	testutil.MustDo(t, "delete committed file on branch2",
+		c.DeleteEntry(ctx, repository, "branch2", "/file2"))
+	_, err = c.Commit(ctx, repository, "branch2", "commit to branch2", "tester", nil)
+	testutil.MustDo(t, "commit to branch2", err)
+
+	res, err := c.Merge(ctx, repository, "branch1", "branch2", "tester", "merge with conflicts", nil, MergeParams{})
+	if !errors.Is(err, ErrConflictFound) {
+		t.Fatalf("Merge err = %s, expected conflict with err = %s", err, ErrConflictFound)
+	}
+	if res == nil {
+		t.Fatal("No merge results")
+	}
+	expectedDifferences := Differences{
+		Difference{Type: DifferenceTypeConflict, Path: "/file1"},
+		Difference{Type: DifferenceTypeConflict, Path: "/file2"},
+	}
+	if !res.Differences.Equal(expectedDifferences) {
+		t.Fatalf("Merge differences = %s, expected %s", spew.Sdump(res.Differences), spew.Sdump(expectedDifferences))
+	}
+}
+
+// testCatalogerMergeConflictsRepo creates a repository with 'master' and 'branch1' changing /file1 and /file2
+// after branch1 was created. Each branch also adds a file without a conflict.
+func testCatalogerMergeConflictsRepo(t *testing.T, ctx context.Context, c Cataloger) string {
+	t.Helper()
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	for i := 0; i < 3; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file1", nil, "branch1")
+	testutil.MustDo(t, "delete file2 on branch1", c.DeleteEntry(ctx, repository, "branch1", "/file2"))
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file3", nil, "")
+	_, err = c.Commit(ctx, repository, "branch1", "commit to branch1", "tester", nil)
+	testutil.MustDo(t, "commit to branch1", err)
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file2", nil, "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file4", nil, "")
+	_, err = c.Commit(ctx, repository, "master", "second commit to master", "tester", nil)
+	testutil.MustDo(t, "second commit to master", err)
+	return repository
+}
+
+func TestCataloger_Merge_Strategy(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+
+	tests := []struct {
+		name        string
+		source      string
+		destination string
+		strategy    MergeStrategy
+		entries     []testEntryInfo
+	}{
+		{
+			name:        "from father source wins",
+			source:      "master",
+			destination: "branch1",
+			strategy:    MergeStrategySourceWins,
+			entries: []testEntryInfo{
+				{Path: "/file0"},
+				{Path: "/file1", Seed: "master"},
+				{Path: "/file2", Seed: "master"},
+				{Path: "/file3"},
+				{Path: "/file4"},
+			},
+		},
+		{
+			name:        "from father dest wins",
+			source:      "master",
+			destination: "branch1",
+			strategy:    MergeStrategyDestWins,
+			entries: []testEntryInfo{
+				{Path: "/file0"},
+				{Path: "/file1", Seed: "branch1"},
+				{Path: "/file2", Deleted: true},
+				{Path: "/file3"},
+				{Path: "/file4"},
+			},
+		},
+		{
+			name:        "from son source wins",
+			source:      "branch1",
+			destination: "master",
+			strategy:    MergeStrategySourceWins,
+			entries: []testEntryInfo{
+				{Path: "/file0"},
+				{Path: "/file1", Seed: "branch1"},
+				{Path: "/file2", Deleted: true},
+				{Path: "/file3"},
+				{Path: "/file4"},
+			},
+		},
+		{
+			name:        "from son dest wins",
+			source:      "branch1",
+			destination: "master",
+			strategy:    MergeStrategyDestWins,
+			entries: []testEntryInfo{
+				{Path: "/file0"},
+				{Path: "/file1", Seed: "master"},
+				{Path: "/file2", Seed: "master"},
+				{Path: "/file3"},
+				{Path: "/file4"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := testCatalogerMergeConflictsRepo(t, ctx, c)
+			res, err := c.Merge(ctx, repository, tt.source, tt.destination, "tester", "", nil, MergeParams{Strategy: tt.strategy})
+			testutil.MustDo(t, "merge with strategy", err)
+			if res == nil || res.Reference == "" {
+				t.Fatalf("Merge result = %+v, expected a reference", res)
+			}
+			if res.Differences.CountByType()[DifferenceTypeConflict] != 0 {
+				t.Fatalf("Merge differences = %s, expected no conflicts", spew.Sdump(res.Differences))
+			}
+			testVerifyEntries(t, ctx, c, repository, tt.destination, tt.entries)
+
+			// the conflicts were resolved by the merge
+			_, err = c.Merge(ctx, repository, tt.source, tt.destination, "tester", "", nil, MergeParams{})
+			if !errors.Is(err, ErrNoDifferenceWasFound) {
+				t.Fatalf("Merge again err = %s, expected %s", err, ErrNoDifferenceWasFound)
+			}
+		})
+	}
+}
+
+func TestCataloger_Merge_StrategyUncommittedConflict(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerMergeConflictsRepo(t, ctx, c)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "uncommitted")
+
+	_, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{Strategy: MergeStrategySourceWins})
+	if !errors.Is(err, ErrConflictFound) {
+		t.Fatalf("Merge err = %s, expected %s", err, ErrConflictFound)
+	}
+	testVerifyEntries(t, ctx, c, repository, "master", []testEntryInfo{
+		{Path: "/file1", Seed: "uncommitted"},
+		{Path: "/file2", Seed: "master"},
+		{Path: "/file3", Deleted: true},
+	})
+}
+
+func TestCataloger_Merge_ConflictReport(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerMergeConflictsRepo(t, ctx, c)
+
+	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{Strategy: MergeStrategyFail})
+	if !errors.Is(err, ErrConflictFound) {
+		t.Fatalf("Merge err = %s, expected %s", err, ErrConflictFound)
+	}
+	if res == nil || len(res.Conflicts) != 2 {
+		t.Fatalf("Merge result = %s, expected two conflicts", spew.Sdump(res))
+	}
+
+	file1 := res.Conflicts[0]
+	if file1.Path != "/file1" || file1.Source == nil || file1.Destination == nil {
+		t.Fatalf("Conflict = %s, expected /file1 with source and destination entries", spew.Sdump(file1))
+	}
+	expectedSourceAddr := testCreateEntryCalcChecksum("/file1", "branch1")
+	if file1.Source.PhysicalAddress != expectedSourceAddr || file1.Source.Checksum != expectedSourceAddr || file1.Source.Size == 0 {
+		t.Fatalf("Conflict source = %s, expected address and checksum %s", spew.Sdump(file1.Source), expectedSourceAddr)
+	}
+	expectedDestinationAddr := testCreateEntryCalcChecksum("/file1", "master")
+	if file1.Destination.PhysicalAddress != expectedDestinationAddr || file1.Destination.Checksum != expectedDestinationAddr {
+		t.Fatalf("Conflict destination = %s, expected address and checksum %s", spew.Sdump(file1.Destination), expectedDestinationAddr)
+	}
+
+	file2 := res.Conflicts[1]
+	if file2.Path != "/file2" || file2.Source != nil || file2.Destination == nil {
+		t.Fatalf("Conflict = %s, expected /file2 deleted on source", spew.Sdump(file2))
+	}
+}
+
+func TestCataloger_Merge_InvalidStrategy(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerMergeConflictsRepo(t, ctx, c)
+	_, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{Strategy: "no-strategy"})
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Fatalf("Merge err = %s, expected %s", err, ErrInvalidValue)
+	}
+}
+
+func TestCataloger_Merge_DryRun(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	for i := 0; i < 3; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file1", nil, "seed1")
+	testutil.MustDo(t, "delete file2", c.DeleteEntry(ctx, repository, "branch1", "/file2"))
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file3", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file4", nil, "")
+	_, err = c.Commit(ctx, repository, "branch1", "commit to branch1", "tester", nil)
+	testutil.MustDo(t, "commit to branch1", err)
+	masterReference, err := c.GetBranchReference(ctx, repository, "master")
+	testutil.MustDo(t, "get master reference", err)
+
+	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{DryRun: true})
+	testutil.MustDo(t, "merge dry run", err)
+	if res.Reference != "" {
+		t.Fatalf("Merge dry run reference = %s, expected none", res.Reference)
+	}
+	expectedSummary := map[DifferenceType]int{
+		DifferenceTypeAdded:   2,
+		DifferenceTypeRemoved: 1,
+		DifferenceTypeChanged: 1,
+	}
+	if diff := deep.Equal(res.Summary, expectedSummary); diff != nil {
+		t.Fatalf("Merge dry run summary diff: %s", diff)
+	}
+
+	// nothing was written
+	reference, err := c.GetBranchReference(ctx, repository, "master")
+	testutil.MustDo(t, "get master reference after dry run", err)
+	if reference != masterReference {
+		t.Fatalf("Master reference after dry run = %s, expected %s", reference, masterReference)
+	}
+	testVerifyEntries(t, ctx, c, repository, "master", []testEntryInfo{
+		{Path: "/file1"},
+		{Path: "/file2"},
+		{Path: "/file3", Deleted: true},
+	})
+
+	// the merge does what the dry run reported
+	merged, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{})
+	testutil.MustDo(t, "merge", err)
+	if !merged.Differences.Equal(res.Differences) {
+		t.Fatalf("Merge differences = %s, expected dry run differences %s", spew.Sdump(merged.Differences), spew.Sdump(res.Differences))
+	}
+}
+
+func TestCataloger_Merge_DryRunConflicts(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerMergeConflictsRepo(t, ctx, c)
+	masterReference, err := c.GetBranchReference(ctx, repository, "master")
+	testutil.MustDo(t, "get master reference", err)
+
+	res, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{DryRun: true})
+	if !errors.Is(err, ErrConflictFound) {
+		t.Fatalf("Merge dry run err = %s, expected %s", err, ErrConflictFound)
+	}
+	if res == nil || res.Summary[DifferenceTypeConflict] != 2 || len(res.Conflicts) != 2 {
+		t.Fatalf("Merge dry run result = %s, expected two conflicts", spew.Sdump(res))
+	}
+
+	// dry run with a strategy reports the resolved differences
+	res, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{
+		Strategy: MergeStrategySourceWins,
+		DryRun:   true,
+	})
+	testutil.MustDo(t, "merge dry run with strategy", err)
+	expectedSummary := map[DifferenceType]int{
+		DifferenceTypeAdded:   1,
+		DifferenceTypeRemoved: 1,
+		DifferenceTypeChanged: 1,
+	}
+	if diff := deep.Equal(res.Summary, expectedSummary); diff != nil {
+		t.Fatalf("Merge dry run summary diff: %s", diff)
+	}
+	reference, err := c.GetBranchReference(ctx, repository, "master")
+	testutil.MustDo(t, "get master reference after dry run", err)
+	if reference != masterReference {
+		t.Fatalf("Master reference after dry run = %s, expected %s", reference, masterReference)
+	}
+}
+
+func TestCataloger_Merge_Hooks(t *testing.T) {
+	ctx := context.Background()
+	runner := &testHookRunner{}
+	c := testCataloger(t, WithHookRunner(runner))
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file0", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "file1", nil, "")
+	_, err = c.Commit(ctx, repository, "branch1", "branch commit", "tester", nil)
+	testutil.MustDo(t, "branch commit", err)
+
+	err = c.CreateHook(ctx, repository, &Hook{Name: "gate", Type: HookTypePreMerge, URL: "http://localhost/gate"})
+	testutil.MustDo(t, "create hook", err)
+
+	// rejected merge doesn't change the destination
+	runner.RejectType = HookTypePreMerge
+	_, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{})
+	if !errors.Is(err, ErrHookRejected) {
+		t.Fatalf("Merge() rejected by hook err = %v, expected %s", err, ErrHookRejected)
+	}
+	_, err = c.GetEntry(ctx, repository, MakeReference("master", CommittedID), "file1", GetEntryParams{})
+	if err == nil {
+		t.Fatal("GetEntry() after rejected merge found entry, expected not found")
+	}
+
+	// dry run doesn't call hooks
+	runner.Events = nil
+	_, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{DryRun: true})
+	testutil.MustDo(t, "merge dry run", err)
+	if len(runner.Events) != 0 {
+		t.Fatalf("Merge() dry run ran %d events, expected none", len(runner.Events))
+	}
+
+	runner.RejectType = ""
+	_, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", Metadata{"key": "value"}, MergeParams{})
+	testutil.MustDo(t, "merge", err)
+	expectedEvent := &HookEvent{
+		Type:         HookTypePreMerge,
+		Repository:   repository,
+		Branch:       "master",
+		SourceBranch: "branch1",
+		Committer:    "tester",
+		Message:      formatMergeMessage("branch1", "master"),
+		Metadata:     Metadata{"key": "value"},
+		Differences:  Differences{{Type: DifferenceTypeAdded, Path: "file1"}},
+	}
+	if diff := deep.Equal(runner.Events, []*HookEvent{expectedEvent}); diff != nil {
+		t.Fatal("Merge() hook events", diff)
+	}
+}
+
+func TestCataloger_Merge_HooksOutsideTransaction(t *testing.T) {
+	ctx := context.Background()
+	runner := &testHookRunner{}
+	c := testCataloger(t, WithHookRunner(runner))
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file0", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "file1", nil, "")
+	_, err = c.Commit(ctx, repository, "branch1", "branch commit", "tester", nil)
+	testutil.MustDo(t, "branch commit", err)
+	err = c.CreateHook(ctx, repository, &Hook{Name: "gate", Type: HookTypePreMerge, URL: "http://localhost/gate"})
+	testutil.MustDo(t, "create hook", err)
+
+	// a commit to the source branch while the hooks run fails the merge the hooks approved
+	runner.OnRun = func(_ *HookEvent) {
+		testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "file2", nil, "")
+		_, err := c.Commit(ctx, repository, "branch1", "commit while hooks run", "tester", nil)
+		testutil.MustDo(t, "commit while hooks run", err)
+	}
+	_, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{})
+	if !errors.Is(err, ErrBranchChangedDuringHooks) {
+		t.Fatalf("Merge() changed during hooks err = %v, expected %s", err, ErrBranchChangedDuringHooks)
+	}
+	_, err = c.GetEntry(ctx, repository, MakeReference("master", CommittedID), "file1", GetEntryParams{})
+	if err == nil {
+		t.Fatal("GetEntry() after failed merge found entry, expected not found")
+	}
+
+	// the hooks approve the current differences
+	runner.OnRun = nil
+	runner.Events = nil
+	_, err = c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{})
+	testutil.MustDo(t, "merge", err)
+	expectedDifferences := Differences{
+		{Type: DifferenceTypeAdded, Path: "file1"},
+		{Type: DifferenceTypeAdded, Path: "file2"},
+	}
+	if len(runner.Events) != 1 || !runner.Events[0].Differences.Equal(expectedDifferences) {
+		t.Fatalf("Merge() hook events = %v, expected one event with %v", runner.Events, expectedDifferences)
+	}
+}
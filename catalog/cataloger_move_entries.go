@@ -0,0 +1,85 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/treeverse/lakefs/db"
+)
+
+// MoveEntries moves the entry of source to destination, or when prefix is set, every entry with a path starting
+// with source to the same path starting with destination.  Entries keep their physical address, so no data is
+// copied, and entries found on destination are replaced.  Returns the number of entries moved.
+func (c *cataloger) MoveEntries(ctx context.Context, repository, branch string, source, destination string, prefix bool) (int, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "source", IsValid: ValidatePath(source)},
+		{Name: "destination", IsValid: ValidatePath(destination)},
+	}); err != nil {
+		return 0, err
+	}
+	if source == destination || (prefix && strings.HasPrefix(destination, source)) {
+		return 0, fmt.Errorf("destination under source: %w", ErrInvalidValue)
+	}
+
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		branchID, err := c.getBranchIDCache(tx, repository, branch)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkBranchProtection(tx, repository, branch); err != nil {
+			return nil, err
+		}
+		lineage, err := getLineage(tx, branchID, UncommittedID)
+		if err != nil {
+			return nil, fmt.Errorf("get lineage: %w", err)
+		}
+		var pathCond sq.Sqlizer = sq.Eq{"path": source}
+		if prefix {
+			pathCond = sq.Like{"path": db.Prefix(source)}
+		}
+		sql, args, err := psql.
+			Select("path", "physical_address", "creation_date", "size", "checksum", "metadata", "is_expired").
+			FromSelect(sqEntriesLineage(branchID, UncommittedID, lineage), "entries").
+			Where(sq.And{sq.Eq{"is_deleted": false}, pathCond}).
+			OrderBy("path").
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("build sql: %w", err)
+		}
+		var entries []*Entry
+		if err := tx.Select(&entries, sql, args...); err != nil {
+			return nil, fmt.Errorf("select entries: %w", err)
+		}
+		if len(entries) == 0 {
+			return nil, ErrEntryNotFound
+		}
+		paths := make([]string, 0, 2*len(entries))
+		for _, entry := range entries {
+			paths = append(paths, entry.Path, destination+strings.TrimPrefix(entry.Path, source))
+		}
+		if err := lockEntries(tx, branchID, paths...); err != nil {
+			return nil, err
+		}
+		// delete all sources before writing destinations - moving a prefix up may write to another source path
+		for _, entry := range entries {
+			if err := deleteEntry(tx, branchID, entry.Path); err != nil {
+				return nil, fmt.Errorf("delete %s: %w", entry.Path, err)
+			}
+		}
+		for _, entry := range entries {
+			entry.Path = destination + strings.TrimPrefix(entry.Path, source)
+			if _, err := insertEntry(tx, branchID, entry); err != nil {
+				return nil, fmt.Errorf("insert %s: %w", entry.Path, err)
+			}
+		}
+		return len(entries), nil
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return 0, err
+	}
+	return res.(int), nil
+}
@@ -0,0 +1,99 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/db"
+)
+
+func TestCataloger_MoveEntries(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "tmp/part-0", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "tmp/sub/part-1", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "tmp2/part-0", nil, "")
+	if _, err := c.Commit(ctx, repository, "master", "commit tmp", "tester", nil); err != nil {
+		t.Fatal("commit for MoveEntries:", err)
+	}
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "tmp/part-2", nil, "")
+
+	// move prefix with committed entries from the lineage and an uncommitted entry
+	moved, err := c.MoveEntries(ctx, repository, "branch1", "tmp/", "data/", true)
+	if err != nil {
+		t.Fatal("MoveEntries() prefix:", err)
+	}
+	if moved != 3 {
+		t.Errorf("MoveEntries() moved %d entries, expected 3", moved)
+	}
+	testCatalogerMoveEntriesPaths(t, ctx, c, repository, "branch1", []string{"data/part-0", "data/part-2", "data/sub/part-1", "tmp2/part-0"})
+	entry, err := c.GetEntry(ctx, repository, "branch1", "data/sub/part-1", GetEntryParams{})
+	if err != nil {
+		t.Fatal("get moved entry:", err)
+	}
+	if expected := testCreateEntryCalcChecksum("tmp/sub/part-1", ""); entry.PhysicalAddress != expected {
+		t.Errorf("moved entry physical address = %s, expected %s", entry.PhysicalAddress, expected)
+	}
+	// source branch is not changed
+	testCatalogerMoveEntriesPaths(t, ctx, c, repository, "master", []string{"tmp/part-0", "tmp/sub/part-1", "tmp2/part-0"})
+
+	// move a single path, and move a prefix up over one of its own paths
+	if _, err := c.MoveEntries(ctx, repository, "branch1", "tmp2/part-0", "data/sub/sub/part-0", false); err != nil {
+		t.Fatal("MoveEntries() path:", err)
+	}
+	if _, err := c.MoveEntries(ctx, repository, "branch1", "data/sub/", "data/", true); err != nil {
+		t.Fatal("MoveEntries() prefix up:", err)
+	}
+	testCatalogerMoveEntriesPaths(t, ctx, c, repository, "branch1", []string{"data/part-0", "data/part-1", "data/part-2", "data/sub/part-0"})
+	if _, err := c.Commit(ctx, repository, "branch1", "move", "tester", nil); err != nil {
+		t.Fatal("commit moved entries:", err)
+	}
+	testCatalogerMoveEntriesPaths(t, ctx, c, repository, "branch1", []string{"data/part-0", "data/part-1", "data/part-2", "data/sub/part-0"})
+}
+
+func TestCataloger_MoveEntries_Errors(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "tmp/part-0", nil, "")
+
+	tests := []struct {
+		name        string
+		source      string
+		destination string
+		prefix      bool
+		wantErr     error
+	}{
+		{name: "path not found", source: "tmp/part-1", destination: "data/part-1", wantErr: db.ErrNotFound},
+		{name: "prefix not found", source: "data/", destination: "tmp/", prefix: true, wantErr: db.ErrNotFound},
+		{name: "same path", source: "tmp/part-0", destination: "tmp/part-0", wantErr: ErrInvalidValue},
+		{name: "destination under source", source: "tmp/", destination: "tmp/sub/", prefix: true, wantErr: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.MoveEntries(ctx, repository, "master", tt.source, tt.destination, tt.prefix)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MoveEntries() error = %v, expected %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func testCatalogerMoveEntriesPaths(t *testing.T, ctx context.Context, c Cataloger, repository, branch string, expected []string) {
+	t.Helper()
+	entries, _, err := c.ListEntries(ctx, repository, branch, "", "", "", -1)
+	if err != nil {
+		t.Fatal("list entries:", err)
+	}
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		paths[i] = entry.Path
+	}
+	if diff := deep.Equal(paths, expected); diff != nil {
+		t.Errorf("entries on %s: %s", branch, diff)
+	}
+}
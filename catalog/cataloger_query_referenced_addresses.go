@@ -0,0 +1,76 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// referencedAddressesQuery selects the storage namespace, physical address and upload id referenced by branch heads
+// ($1 is MaxCommitID), tags, commits created since $2 and active multipart uploads.
+const referencedAddressesQuery = `
+	WITH points AS (
+		SELECT id AS branch_id, $1::bigint AS commit_id FROM catalog_branches
+		UNION
+		SELECT branch_id, commit_id FROM catalog_commits WHERE creation_date >= $2
+		UNION
+		SELECT branch_id, commit_id FROM catalog_tags
+	), lineage_points AS (
+		SELECT branch_id, commit_id FROM points
+		UNION
+		SELECT l.branch_id, l.commit_id FROM points p CROSS JOIN LATERAL UNNEST(
+			(SELECT lineage FROM catalog_branches WHERE id = p.branch_id),
+			(SELECT lineage_commits FROM catalog_commits
+				WHERE branch_id = p.branch_id AND merge_type = 'from_father' AND commit_id <= p.commit_id
+				ORDER BY commit_id DESC LIMIT 1)
+		) AS l(branch_id, commit_id)
+	)
+	SELECT DISTINCT r.storage_namespace, e.physical_address, '' AS upload_id
+	FROM catalog_entries e
+		JOIN lineage_points p ON p.branch_id = e.branch_id AND e.min_commit <= p.commit_id AND e.max_commit >= p.commit_id
+		JOIN catalog_branches b ON b.id = e.branch_id
+		JOIN catalog_repositories r ON r.id = b.repository_id
+	WHERE e.physical_address <> ''
+	UNION
+	SELECT r.storage_namespace, COALESCE(m.physical_address, ''), m.upload_id
+	FROM catalog_multipart_uploads m
+		JOIN catalog_repositories r ON r.id = m.repository_id`
+
+// referencedAddressRows implements ReferencedAddressRows.
+type referencedAddressRows struct {
+	rows *sqlx.Rows
+}
+
+func (r *referencedAddressRows) Next() bool {
+	return r.rows.Next()
+}
+
+func (r *referencedAddressRows) Err() error {
+	return r.rows.Err()
+}
+
+func (r *referencedAddressRows) Close() error {
+	return r.rows.Close()
+}
+
+func (r *referencedAddressRows) Read() (*ReferencedAddress, error) {
+	var address ReferencedAddress
+	if err := r.rows.StructScan(&address); err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+// QueryReferencedAddresses lists the physical addresses visible from a set of points - branch heads, tags and
+// commits created since the given time.  Each point also references the commits of its lineage, which is how a
+// branch reads the entries of the branch it was created from.
+func (c *cataloger) QueryReferencedAddresses(ctx context.Context, since time.Time) (ReferencedAddressRows, error) {
+	// a single statement, the returned rows iterator can't outlive a transaction
+	rows, err := c.db.Queryx(referencedAddressesQuery, MaxCommitID, since)
+	if err != nil {
+		return nil, fmt.Errorf("running query: %w", err)
+	}
+	return &referencedAddressRows{rows: rows}, nil
+}
@@ -0,0 +1,93 @@
+package catalog
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func testCatalogerReferencedAddresses(t *testing.T, ctx context.Context, c Cataloger, since time.Time) []string {
+	t.Helper()
+	rows, err := c.QueryReferencedAddresses(ctx, since)
+	testutil.MustDo(t, "query referenced addresses", err)
+	defer func() { _ = rows.Close() }()
+	var addresses []string
+	for rows.Next() {
+		address, err := rows.Read()
+		testutil.MustDo(t, "read referenced address", err)
+		addresses = append(addresses, address.PhysicalAddress)
+	}
+	testutil.MustDo(t, "referenced addresses rows", rows.Err())
+	sort.Strings(addresses)
+	return addresses
+}
+
+func TestCataloger_QueryReferencedAddresses(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Round(time.Minute)
+	mockClock := clock.NewMock()
+	mockClock.Set(now)
+	c := testCataloger(t, WithClock(mockClock))
+	defer func() { _ = c.Close() }()
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	commitDay := func(branch, message string) *CommitLog {
+		mockClock.Add(24 * time.Hour)
+		commitLog, err := c.Commit(ctx, repository, branch, message, "tester", nil)
+		testutil.MustDo(t, message, err)
+		return commitLog
+	}
+	// file1 changed by the second commit, file2 deleted by the third
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	commit1 := commitDay("master", "add file1")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "seed1")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file2", nil, "")
+	commit2 := commitDay("master", "change file1")
+	testutil.MustDo(t, "delete file2", c.DeleteEntry(ctx, repository, "master", "file2"))
+	commitDay("master", "delete file2")
+
+	// branch1 with uncommitted file3 reads master, deleted branch2 with file4
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "file3", nil, "")
+	testCatalogerBranch(t, ctx, c, repository, "branch2", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch2", "file4", nil, "")
+	commitDay("branch2", "add file4")
+	testutil.MustDo(t, "delete branch2", c.DeleteBranch(ctx, repository, "branch2"))
+
+	const uploadAddress = "upload-address"
+	err := c.CreateMultipartUpload(ctx, repository, "master", "upload1", "file5", uploadAddress, now, nil)
+	testutil.MustDo(t, "create multipart upload", err)
+
+	file1 := testCreateEntryCalcChecksum("file1", "")
+	file1Seed1 := testCreateEntryCalcChecksum("file1", "seed1")
+	file2 := testCreateEntryCalcChecksum("file2", "")
+	file3 := testCreateEntryCalcChecksum("file3", "")
+	sorted := func(addresses ...string) []string {
+		sort.Strings(addresses)
+		return addresses
+	}
+
+	// heads only
+	future := now.Add(30 * 24 * time.Hour)
+	if diff := deep.Equal(testCatalogerReferencedAddresses(t, ctx, c, future),
+		sorted(file1Seed1, file3, uploadAddress)); diff != nil {
+		t.Fatal("referenced by heads", diff)
+	}
+	// commits since the second commit
+	if diff := deep.Equal(testCatalogerReferencedAddresses(t, ctx, c, commit2.CreationDate),
+		sorted(file1Seed1, file2, file3, uploadAddress)); diff != nil {
+		t.Fatal("referenced since second commit", diff)
+	}
+	// tag on the first commit
+	_, err = c.CreateTag(ctx, repository, "v1", commit1.Reference)
+	testutil.MustDo(t, "create tag", err)
+	if diff := deep.Equal(testCatalogerReferencedAddresses(t, ctx, c, future),
+		sorted(file1, file1Seed1, file3, uploadAddress)); diff != nil {
+		t.Fatal("referenced by heads and tag", diff)
+	}
+}
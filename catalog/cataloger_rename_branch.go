@@ -0,0 +1,75 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// RenameBranch changes the name of a branch.  The branch keeps its id, so its commits, lineage and the merge
+// history of other branches are kept.  The default branch is referenced by id and is renamed as well.
+// Commit references made before the rename keep resolving, by their commit id.  Other cataloger instances may
+// resolve the previous branch name from their branch id cache until it expires.
+func (c *cataloger) RenameBranch(ctx context.Context, repository, from, to string) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "from", IsValid: ValidateBranchName(from)},
+		{Name: "to", IsValid: ValidateBranchName(to)},
+	}); err != nil {
+		return err
+	}
+
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		_, err := tx.Exec("LOCK TABLE catalog_branches IN SHARE UPDATE EXCLUSIVE MODE")
+		if err != nil {
+			return nil, fmt.Errorf("lock branches for update: %w", err)
+		}
+
+		repoID, err := c.getRepositoryIDCache(tx, repository)
+		if err != nil {
+			return nil, err
+		}
+		branchID, err := getBranchID(tx, repository, from, LockTypeUpdate)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkBranchProtection(tx, repository, from); err != nil {
+			return nil, err
+		}
+
+		// branch name can't be used by another branch or a tag
+		_, err = getBranchID(tx, repository, to, LockTypeNone)
+		if err == nil {
+			return nil, ErrBranchAlreadyExists
+		}
+		if !errors.Is(err, db.ErrNotFound) {
+			return nil, fmt.Errorf("branch name check: %w", err)
+		}
+		var tagsCount int
+		if err := tx.Get(&tagsCount, `SELECT COUNT(*) FROM catalog_tags WHERE repository_id=$1 AND name=$2`,
+			repoID, to); err != nil {
+			return nil, fmt.Errorf("tag name check: %w", err)
+		}
+		if tagsCount > 0 {
+			return nil, fmt.Errorf("branch name used by tag: %w", ErrOperationNotPermitted)
+		}
+
+		if _, err := tx.Exec(`UPDATE catalog_branches SET name=$2 WHERE id=$1`, branchID, to); err != nil {
+			return nil, fmt.Errorf("rename branch: %w", err)
+		}
+		return nil, c.addEvent(tx, &Event{
+			Type:         EventTypeBranchRename,
+			Repository:   repository,
+			Branch:       to,
+			SourceBranch: from,
+		})
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return err
+	}
+	c.cache.InvalidateBranchID(repository, from)
+	c.cache.InvalidateRepository(repository)
+	return nil
+}
@@ -0,0 +1,151 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+func TestCataloger_RenameBranch(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	if _, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil); err != nil {
+		t.Fatal("commit for RenameBranch:", err)
+	}
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file2", nil, "")
+	// cache the branch ids before renaming
+	for _, branch := range []string{"master", "branch1"} {
+		if _, err := c.GetEntry(ctx, repository, MakeReference(branch, UncommittedID), "/file1", GetEntryParams{}); err != nil {
+			t.Fatalf("get entry on %s: %s", branch, err)
+		}
+	}
+
+	if err := c.RenameBranch(ctx, repository, "master", "main"); err != nil {
+		t.Fatal("RenameBranch() default branch:", err)
+	}
+	if err := c.RenameBranch(ctx, repository, "branch1", "feature"); err != nil {
+		t.Fatal("RenameBranch() branch:", err)
+	}
+
+	repo, err := c.GetRepository(ctx, repository)
+	if err != nil {
+		t.Fatal("get repository:", err)
+	}
+	if repo.DefaultBranch != "main" {
+		t.Errorf("default branch = %s, expected main", repo.DefaultBranch)
+	}
+	_, err = c.GetEntry(ctx, repository, MakeReference("branch1", UncommittedID), "/file1", GetEntryParams{})
+	if !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("get entry on renamed branch err = %v, expected not found", err)
+	}
+	if _, err := c.GetEntry(ctx, repository, MakeReference("feature", UncommittedID), "/file2", GetEntryParams{}); err != nil {
+		t.Errorf("get entry on new branch name: %s", err)
+	}
+
+	// lineage is kept - merge finds the common commit and has no conflicts
+	if _, err := c.Commit(ctx, repository, "feature", "commit file2", "tester", nil); err != nil {
+		t.Fatal("commit on renamed branch:", err)
+	}
+	res, err := c.Merge(ctx, repository, "feature", "main", "tester", "", nil, MergeParams{})
+	if err != nil {
+		t.Fatal("merge renamed branches:", err)
+	}
+	if res.Summary[DifferenceTypeAdded] != 1 {
+		t.Errorf("merge summary = %v, expected one added", res.Summary)
+	}
+	commits, _, err := c.ListCommits(ctx, repository, "main", "", -1, ListCommitsParams{})
+	if err != nil {
+		t.Fatal("list commits:", err)
+	}
+	if len(commits) == 0 || len(commits[0].Parents) != 2 {
+		t.Fatalf("merge commit parents = %v, expected two parents", commits)
+	}
+	if ref, _ := ParseRef(commits[0].Parents[0]); ref == nil || ref.Branch != "feature" {
+		t.Errorf("merge commit source parent = %s, expected on feature", commits[0].Parents[0])
+	}
+}
+
+func TestCataloger_RenameBranch_CommitReference(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil)
+	if err != nil {
+		t.Fatal("commit for RenameBranch:", err)
+	}
+
+	if err := c.RenameBranch(ctx, repository, "master", "main"); err != nil {
+		t.Fatal("RenameBranch():", err)
+	}
+	// a new branch with the previous name doesn't take over the commit reference
+	testCatalogerBranch(t, ctx, c, repository, "master", "main")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "seed1")
+	if _, err := c.Commit(ctx, repository, "master", "change file1", "tester", nil); err != nil {
+		t.Fatal("commit on new branch:", err)
+	}
+
+	entry, err := c.GetEntry(ctx, repository, commitLog.Reference, "/file1", GetEntryParams{})
+	if err != nil {
+		t.Fatal("get entry by commit reference made before rename:", err)
+	}
+	if expected := testCreateEntryCalcChecksum("/file1", ""); entry.Checksum != expected {
+		t.Errorf("entry checksum = %s, expected %s of the renamed branch commit", entry.Checksum, expected)
+	}
+	commit, err := c.GetCommit(ctx, repository, commitLog.Reference)
+	if err != nil {
+		t.Fatal("get commit by commit reference made before rename:", err)
+	}
+	ref, err := ParseRef(commitLog.Reference)
+	if err != nil {
+		t.Fatal("parse commit reference:", err)
+	}
+	if expected := MakeReference("main", ref.CommitID); commit.Reference != expected {
+		t.Errorf("commit reference = %s, expected %s", commit.Reference, expected)
+	}
+}
+
+func TestCataloger_RenameBranch_Errors(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "master", "commit file1", "tester", nil)
+	if err != nil {
+		t.Fatal("commit for RenameBranch:", err)
+	}
+	if _, err := c.CreateTag(ctx, repository, "v1", commitLog.Reference); err != nil {
+		t.Fatal("create tag for RenameBranch:", err)
+	}
+	testCatalogerBranch(t, ctx, c, repository, "protected1", "master")
+	if err := c.CreateBranchProtectionRule(ctx, repository, "protected*"); err != nil {
+		t.Fatal("create branch protection rule:", err)
+	}
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr error
+	}{
+		{name: "unknown branch", from: "nob", to: "branch2", wantErr: db.ErrNotFound},
+		{name: "existing branch", from: "branch1", to: "master", wantErr: ErrBranchAlreadyExists},
+		{name: "tag name", from: "branch1", to: "v1", wantErr: ErrOperationNotPermitted},
+		{name: "protected branch", from: "protected1", to: "branch2", wantErr: ErrBranchProtected},
+		{name: "invalid name", from: "branch1", to: "", wantErr: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.RenameBranch(ctx, repository, tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RenameBranch() error = %v, expected %s", err, tt.wantErr)
+			}
+		})
+	}
+}
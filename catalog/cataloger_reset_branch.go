@@ -18,12 +18,21 @@ func (c *cataloger) ResetBranch(ctx context.Context, repository, branch string)
 		if err != nil {
 			return nil, err
 		}
+		if err := checkBranchProtection(tx, repository, branch); err != nil {
+			return nil, err
+		}
 		res, err := tx.Exec(`DELETE FROM catalog_entries WHERE branch_id=$1 AND min_commit=0`, branchID)
 		if err != nil {
 			return nil, err
 		}
-		_, err = res.RowsAffected()
-		return nil, err
+		if _, err := res.RowsAffected(); err != nil {
+			return nil, err
+		}
+		return nil, c.addEvent(tx, &Event{
+			Type:       EventTypeBranchReset,
+			Repository: repository,
+			Branch:     branch,
+		})
 	}, c.txOpts(ctx)...)
 	return err
 }
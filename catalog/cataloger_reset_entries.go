@@ -2,6 +2,7 @@ package catalog
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/treeverse/lakefs/db"
 )
@@ -18,7 +19,18 @@ func (c *cataloger) ResetEntries(ctx context.Context, repository, branch string,
 		if err != nil {
 			return nil, err
 		}
+		if err := checkBranchProtection(tx, repository, branch); err != nil {
+			return nil, err
+		}
 		prefixCond := db.Prefix(prefix)
+		var paths []string
+		if err := tx.Select(&paths, `SELECT path FROM catalog_entries WHERE branch_id=$1 AND path LIKE $2 AND min_commit=0`,
+			branchID, prefixCond); err != nil {
+			return nil, fmt.Errorf("select entries: %w", err)
+		}
+		if err := lockEntries(tx, branchID, paths...); err != nil {
+			return nil, err
+		}
 		_, err = tx.Exec(`DELETE FROM catalog_entries WHERE branch_id=$1 AND path LIKE $2 AND min_commit=0`, branchID, prefixCond)
 		return nil, err
 	}, c.txOpts(ctx)...)
@@ -0,0 +1,101 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+const (
+	revertCommitMessageFormat = "Revert \"%s\""
+)
+
+// RevertCommit undoes the changes introduced by the reference commit by writing a new commit on the branch.
+// Entries changed by the commit are restored to their previous version, added entries are removed and removed
+// entries are added back. Paths changed on the branch after the commit are reported as conflicts.
+func (c *cataloger) RevertCommit(ctx context.Context, repository, branch string, reference string, committer string) (*MergeResult, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "reference", IsValid: ValidateReference(reference)},
+		{Name: "committer", IsValid: ValidateCommitter(committer)},
+	}); err != nil {
+		return nil, err
+	}
+	ref, err := ParseRef(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *MergeResult
+	_, err = c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		branchID, err := getBranchID(tx, repository, branch, LockTypeUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("get branch id: %w", err)
+		}
+		if err := checkBranchProtection(tx, repository, branch); err != nil {
+			return nil, err
+		}
+		sourceID, commit, err := c.getRefCommit(tx, repository, ref)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkCommitInLineage(tx, branchID, sourceID, commit.CommitID); err != nil {
+			return nil, err
+		}
+		if commit.PreviousCommitID <= 0 {
+			return nil, fmt.Errorf("revert commit without parent: %w", ErrOperationNotPermitted)
+		}
+
+		// the inverse of the commit changes - from the commit back to its parent
+		differences, err := diffCommits(tx, sourceID, commit.CommitID, commit.PreviousCommitID, branchID)
+		if err != nil {
+			return nil, err
+		}
+		result = &MergeResult{
+			Differences: differences,
+		}
+		diffCounts := result.Differences.CountByType()
+		if diffCounts[DifferenceTypeConflict] > 0 {
+			return nil, ErrConflictFound
+		}
+		if len(diffCounts) == 0 {
+			return nil, ErrNoDifferenceWasFound
+		}
+
+		message := fmt.Sprintf(revertCommitMessageFormat, commit.Message)
+		commitID, err := c.commitDiffResults(tx, branchID, committer, message, nil)
+		if err != nil {
+			return nil, err
+		}
+		result.Reference = MakeReference(branch, commitID)
+		return nil, c.addEvent(tx, &Event{
+			Type:       EventTypeCommit,
+			Repository: repository,
+			Branch:     branch,
+			Reference:  result.Reference,
+			Committer:  committer,
+			Message:    message,
+		})
+	}, c.txOpts(ctx)...)
+	return result, err
+}
+
+// checkCommitInLineage returns ErrCommitNotInLineage unless the commit is found on the branch or on one of the
+// branches it was created from, up to the commit the branch sees
+func checkCommitInLineage(tx db.Tx, branchID int64, commitBranchID int64, commitID CommitID) error {
+	if commitBranchID == branchID {
+		return nil
+	}
+	lineage, err := getLineage(tx, branchID, UncommittedID)
+	if err != nil {
+		return fmt.Errorf("get lineage: %w", err)
+	}
+	for _, l := range lineage {
+		if l.BranchID == commitBranchID && commitID <= l.CommitID {
+			return nil
+		}
+	}
+	return ErrCommitNotInLineage
+}
@@ -0,0 +1,195 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_RevertCommit_Basic(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	for i := 0; i < 3; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+
+	// commit to revert - add, change and delete
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file3", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "seed1")
+	testutil.MustDo(t, "delete file2", c.DeleteEntry(ctx, repository, "master", "/file2"))
+	commitLog, err := c.Commit(ctx, repository, "master", "second commit", "tester", nil)
+	testutil.MustDo(t, "second commit", err)
+
+	// later commit on other paths
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file4", nil, "")
+	_, err = c.Commit(ctx, repository, "master", "third commit", "tester", nil)
+	testutil.MustDo(t, "third commit", err)
+
+	res, err := c.RevertCommit(ctx, repository, "master", commitLog.Reference, "reverter")
+	testutil.MustDo(t, "revert commit", err)
+	expectedDifferences := Differences{
+		{Type: DifferenceTypeChanged, Path: "/file1"},
+		{Type: DifferenceTypeAdded, Path: "/file2"},
+		{Type: DifferenceTypeRemoved, Path: "/file3"},
+	}
+	if !res.Differences.Equal(expectedDifferences) {
+		t.Fatalf("RevertCommit differences = %s, expected %s", spew.Sdump(res.Differences), spew.Sdump(expectedDifferences))
+	}
+	testVerifyEntries(t, ctx, c, repository, "master", []testEntryInfo{
+		{Path: "/file0"},
+		{Path: "/file1"},
+		{Path: "/file2"},
+		{Path: "/file3", Deleted: true},
+		{Path: "/file4"},
+	})
+
+	// history is kept - the reverted commit is still readable
+	testVerifyEntries(t, ctx, c, repository, commitLog.Reference, []testEntryInfo{
+		{Path: "/file1", Seed: "seed1"},
+		{Path: "/file3"},
+	})
+	commit, err := c.GetCommit(ctx, repository, res.Reference)
+	testutil.MustDo(t, "get revert commit", err)
+	expectedMessage := `Revert "second commit"`
+	if commit.Committer != "reverter" || commit.Message != expectedMessage {
+		t.Fatalf("RevertCommit commit = %+v, expected committer 'reverter' and message %s", commit, expectedMessage)
+	}
+
+	// nothing left to revert
+	_, err = c.RevertCommit(ctx, repository, "master", commitLog.Reference, "reverter")
+	if !errors.Is(err, ErrNoDifferenceWasFound) {
+		t.Fatalf("RevertCommit again err = %s, expected %s", err, ErrNoDifferenceWasFound)
+	}
+}
+
+func TestCataloger_RevertCommit_Lineage(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "master", "second commit", "tester", nil)
+	testutil.MustDo(t, "second commit", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+
+	// revert a commit branch1 sees through its lineage
+	_, err = c.RevertCommit(ctx, repository, "branch1", commitLog.Reference, "tester")
+	testutil.MustDo(t, "revert commit", err)
+	testVerifyEntries(t, ctx, c, repository, "branch1", []testEntryInfo{
+		{Path: "/file0"},
+		{Path: "/file1", Deleted: true},
+	})
+	testVerifyEntries(t, ctx, c, repository, "master", []testEntryInfo{
+		{Path: "/file0"},
+		{Path: "/file1"},
+	})
+}
+
+func TestCataloger_RevertCommit_NotInLineage(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch2", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "branch1", "/file1", nil, "")
+	branch1Commit, err := c.Commit(ctx, repository, "branch1", "commit to branch1", "tester", nil)
+	testutil.MustDo(t, "commit to branch1", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file2", nil, "")
+	masterCommit, err := c.Commit(ctx, repository, "master", "commit to master after branching", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+
+	tests := []struct {
+		name      string
+		reference string
+	}{
+		{name: "commit of sibling branch", reference: branch1Commit.Reference},
+		{name: "commit of father after branching", reference: masterCommit.Reference},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.RevertCommit(ctx, repository, "branch2", tt.reference, "tester")
+			if !errors.Is(err, ErrCommitNotInLineage) {
+				t.Fatalf("RevertCommit err = %s, expected %s", err, ErrCommitNotInLineage)
+			}
+		})
+	}
+	testVerifyEntries(t, ctx, c, repository, "branch2", []testEntryInfo{
+		{Path: "/file0"},
+	})
+}
+
+func TestCataloger_RevertCommit_Conflict(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "seed1")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "seed1")
+	commitLog, err := c.Commit(ctx, repository, "master", "second commit", "tester", nil)
+	testutil.MustDo(t, "second commit", err)
+
+	// later commit touches one of the paths
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "seed2")
+	_, err = c.Commit(ctx, repository, "master", "third commit", "tester", nil)
+	testutil.MustDo(t, "third commit", err)
+
+	res, err := c.RevertCommit(ctx, repository, "master", commitLog.Reference, "tester")
+	if !errors.Is(err, ErrConflictFound) {
+		t.Fatalf("RevertCommit err = %s, expected %s", err, ErrConflictFound)
+	}
+	expectedDifferences := Differences{
+		{Type: DifferenceTypeChanged, Path: "/file0"},
+		{Type: DifferenceTypeConflict, Path: "/file1"},
+	}
+	if res == nil || !res.Differences.Equal(expectedDifferences) {
+		t.Fatalf("RevertCommit result = %s, expected differences %s", spew.Sdump(res), spew.Sdump(expectedDifferences))
+	}
+	testVerifyEntries(t, ctx, c, repository, "master", []testEntryInfo{
+		{Path: "/file0", Seed: "seed1"},
+		{Path: "/file1", Seed: "seed2"},
+	})
+}
+
+func TestCataloger_RevertCommit_InvalidReference(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	branchReference, err := c.GetBranchReference(ctx, repository, "branch1")
+	testutil.MustDo(t, "get branch reference", err)
+	ref, err := ParseRef(branchReference)
+	testutil.MustDo(t, "parse branch reference", err)
+
+	tests := []struct {
+		name      string
+		reference string
+		wantErr   error
+	}{
+		{name: "initial commit", reference: branchReference, wantErr: ErrOperationNotPermitted},
+		{name: "commit of other branch", reference: MakeReference("master", ref.CommitID), wantErr: ErrCommitNotFound},
+		{name: "invalid reference", reference: "", wantErr: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.RevertCommit(ctx, repository, "branch1", tt.reference, "tester")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RevertCommit err = %s, expected %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,14 +2,119 @@ package catalog
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
-	"github.com/treeverse/lakefs/logging"
+	"github.com/treeverse/lakefs/db"
 )
 
-func (c *cataloger) RollbackCommit(ctx context.Context, repository, reference string) error {
-	c.log.WithContext(ctx).WithFields(logging.Fields{
-		"repository": repository,
-		"reference":  reference,
-	}).Debug("Rollback commit - feature not supported")
-	return ErrFeatureNotSupported
+// RollbackCommit sets branch back to the commit of the reference, which must be a commit of branch.
+// Uncommitted changes and all commits made after the reference commit are removed from the branch.
+// The rollback is rejected in case another branch depends on a commit that is going to be removed.
+func (c *cataloger) RollbackCommit(ctx context.Context, repository, branch, reference string) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "reference", IsValid: ValidateReference(reference)},
+	}); err != nil {
+		return err
+	}
+	ref, err := ParseRef(reference)
+	if err != nil {
+		return err
+	}
+	if ref.CommitID <= UncommittedID && !ref.IsRelative() {
+		return fmt.Errorf("%w: rollback requires a commit reference", ErrInvalidReference)
+	}
+	_, err = c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		branchID, err := getBranchID(tx, repository, branch, LockTypeUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("get branch id: %w", err)
+		}
+		if err := checkBranchProtection(tx, repository, branch); err != nil {
+			return nil, err
+		}
+
+		// a commit reference selects its commit by id, whatever branch name it holds
+		commitRef, commitBranchID, err := c.getRefBranchIDCache(tx, repository, ref)
+		if err != nil {
+			return nil, err
+		}
+		if commitBranchID != branchID {
+			return nil, ErrCommitNotOnBranch
+		}
+		commitID := commitRef.CommitID
+
+		// validate the commit is part of the branch
+		var branchCommitID CommitID
+		err = tx.Get(&branchCommitID, `SELECT commit_id FROM catalog_commits WHERE branch_id=$1 AND commit_id=$2`,
+			branchID, commitID)
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrCommitNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get commit: %w", err)
+		}
+
+		// validate no other branch merged or branched from a commit we remove
+		var dependentCount int
+		err = tx.Get(&dependentCount, `SELECT COUNT(*) FROM catalog_commits
+			WHERE merge_source_branch=$1 AND merge_source_commit>$2 AND branch_id<>$1`,
+			branchID, commitID)
+		if err != nil {
+			return nil, fmt.Errorf("dependent branches: %w", err)
+		}
+		if dependentCount > 0 {
+			return nil, ErrRollbackWithActiveBranch
+		}
+
+		// validate no tag points to a commit we remove
+		var tagsCount int
+		err = tx.Get(&tagsCount, `SELECT COUNT(*) FROM catalog_tags WHERE branch_id=$1 AND commit_id>$2`,
+			branchID, commitID)
+		if err != nil {
+			return nil, fmt.Errorf("tags check: %w", err)
+		}
+		if tagsCount > 0 {
+			return nil, fmt.Errorf("rollback tagged commit: %w", ErrOperationNotPermitted)
+		}
+
+		// remove uncommitted entries and entries created after the commit
+		_, err = tx.Exec(`DELETE FROM catalog_entries WHERE branch_id=$1 AND (min_commit=0 OR min_commit>$2)`,
+			branchID, commitID)
+		if err != nil {
+			return nil, fmt.Errorf("delete entries: %w", err)
+		}
+
+		// remove tombstones committed by the first commit after the commit - a committed tombstone
+		// ends its lifetime on the commit that came before the commit that deleted it
+		_, err = tx.Exec(`DELETE FROM catalog_entries
+			WHERE branch_id=$1 AND min_commit=$2 AND max_commit=$2 AND physical_address=''`,
+			branchID, commitID)
+		if err != nil {
+			return nil, fmt.Errorf("delete tombstones: %w", err)
+		}
+
+		// restore entries that were visible in the commit and deleted later
+		_, err = tx.Exec(`UPDATE catalog_entries SET max_commit=catalog_max_commit_id()
+			WHERE branch_id=$1 AND max_commit>=$2 AND max_commit<catalog_max_commit_id()`,
+			branchID, commitID)
+		if err != nil {
+			return nil, fmt.Errorf("restore entries: %w", err)
+		}
+
+		// remove the commits, including merge commits, which also restores the branch lineage
+		_, err = tx.Exec(`DELETE FROM catalog_commits WHERE branch_id=$1 AND commit_id>$2`,
+			branchID, commitID)
+		if err != nil {
+			return nil, fmt.Errorf("delete commits: %w", err)
+		}
+		return nil, c.addEvent(tx, &Event{
+			Type:       EventTypeBranchReset,
+			Repository: repository,
+			Branch:     branch,
+			Reference:  reference,
+		})
+	}, c.txOpts(ctx)...)
+	return err
 }
@@ -0,0 +1,185 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_RollbackCommit_Basic(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	// create 3 files on master and commit
+	for i := 0; i < 3; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+	commitLog, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	reference := commitLog.Reference
+
+	// add, change and delete files in two more commits
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file3", nil, "")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "seed1")
+	testutil.MustDo(t, "delete file0", c.DeleteEntry(ctx, repository, "master", "/file0"))
+	_, err = c.Commit(ctx, repository, "master", "second commit", "tester", nil)
+	testutil.MustDo(t, "second commit", err)
+	testutil.MustDo(t, "delete file2", c.DeleteEntry(ctx, repository, "master", "/file2"))
+	_, err = c.Commit(ctx, repository, "master", "third commit", "tester", nil)
+	testutil.MustDo(t, "third commit", err)
+
+	// uncommitted change
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file4", nil, "")
+
+	err = c.RollbackCommit(ctx, repository, "master", reference)
+	testutil.MustDo(t, "rollback commit", err)
+
+	testVerifyEntries(t, ctx, c, repository, "master", []testEntryInfo{
+		{Path: "/file0"},
+		{Path: "/file1"},
+		{Path: "/file2"},
+		{Path: "/file3", Deleted: true},
+		{Path: "/file4", Deleted: true},
+	})
+	branchReference, err := c.GetBranchReference(ctx, repository, "master")
+	testutil.MustDo(t, "get branch reference", err)
+	if branchReference != reference {
+		t.Fatalf("Branch reference = %s, expected %s", branchReference, reference)
+	}
+
+	// branch is usable after rollback
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file5", nil, "")
+	_, err = c.Commit(ctx, repository, "master", "commit after rollback", "tester", nil)
+	testutil.MustDo(t, "commit after rollback", err)
+	testVerifyEntries(t, ctx, c, repository, "master:HEAD", []testEntryInfo{
+		{Path: "/file0"},
+		{Path: "/file3", Deleted: true},
+		{Path: "/file5"},
+	})
+}
+
+func TestCataloger_RollbackCommit_LineageTombstones(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	for i := 0; i < 3; i++ {
+		testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file"+strconv.Itoa(i), nil, "")
+	}
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	reference, err := c.GetBranchReference(ctx, repository, "branch1")
+	testutil.MustDo(t, "get branch reference", err)
+
+	// delete files we see from master and commit
+	testutil.MustDo(t, "delete file0", c.DeleteEntry(ctx, repository, "branch1", "/file0"))
+	_, err = c.Commit(ctx, repository, "branch1", "delete file0", "tester", nil)
+	testutil.MustDo(t, "delete file0 commit", err)
+	testutil.MustDo(t, "delete file1", c.DeleteEntry(ctx, repository, "branch1", "/file1"))
+	_, err = c.Commit(ctx, repository, "branch1", "delete file1", "tester", nil)
+	testutil.MustDo(t, "delete file1 commit", err)
+
+	err = c.RollbackCommit(ctx, repository, "branch1", reference)
+	testutil.MustDo(t, "rollback commit", err)
+	testVerifyEntries(t, ctx, c, repository, "branch1", []testEntryInfo{
+		{Path: "/file0"},
+		{Path: "/file1"},
+		{Path: "/file2"},
+	})
+}
+
+func TestCataloger_RollbackCommit_MergeFromFather(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "commit to master", "tester", nil)
+	testutil.MustDo(t, "commit to master", err)
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	reference, err := c.GetBranchReference(ctx, repository, "branch1")
+	testutil.MustDo(t, "get branch reference", err)
+
+	// change master and merge the changes into branch1
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "seed1")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	_, err = c.Commit(ctx, repository, "master", "second commit to master", "tester", nil)
+	testutil.MustDo(t, "second commit to master", err)
+	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, MergeParams{})
+	testutil.MustDo(t, "merge master to branch1", err)
+
+	// rollback restores the lineage branch1 had before the merge
+	err = c.RollbackCommit(ctx, repository, "branch1", reference)
+	testutil.MustDo(t, "rollback commit", err)
+	testVerifyEntries(t, ctx, c, repository, "branch1", []testEntryInfo{
+		{Path: "/file0"},
+		{Path: "/file1", Deleted: true},
+	})
+
+	// merge again
+	_, err = c.Merge(ctx, repository, "master", "branch1", "tester", "", nil, MergeParams{})
+	testutil.MustDo(t, "merge master to branch1 after rollback", err)
+	testVerifyEntries(t, ctx, c, repository, "branch1", []testEntryInfo{
+		{Path: "/file0", Seed: "seed1"},
+		{Path: "/file1"},
+	})
+}
+
+func TestCataloger_RollbackCommit_ActiveBranch(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file0", nil, "")
+	commitLog, err := c.Commit(ctx, repository, "master", "first commit", "tester", nil)
+	testutil.MustDo(t, "first commit", err)
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "/file1", nil, "")
+	_, err = c.Commit(ctx, repository, "master", "second commit", "tester", nil)
+	testutil.MustDo(t, "second commit", err)
+
+	// branch based on the second commit
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+
+	err = c.RollbackCommit(ctx, repository, "master", commitLog.Reference)
+	if !errors.Is(err, ErrRollbackWithActiveBranch) {
+		t.Fatalf("RollbackCommit err = %s, expected %s", err, ErrRollbackWithActiveBranch)
+	}
+	testVerifyEntries(t, ctx, c, repository, "master", []testEntryInfo{
+		{Path: "/file0"},
+		{Path: "/file1"},
+	})
+}
+
+func TestCataloger_RollbackCommit_InvalidReference(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+	testCatalogerBranch(t, ctx, c, repository, "branch1", "master")
+	branchReference, err := c.GetBranchReference(ctx, repository, "branch1")
+	testutil.MustDo(t, "get branch reference", err)
+	ref, err := ParseRef(branchReference)
+	testutil.MustDo(t, "parse branch reference", err)
+
+	tests := []struct {
+		name      string
+		reference string
+		wantErr   error
+	}{
+		{name: "branch", reference: "master", wantErr: ErrInvalidReference},
+		{name: "committed branch", reference: "master:HEAD", wantErr: ErrInvalidReference},
+		{name: "commit of other branch", reference: MakeReference("master", ref.CommitID), wantErr: ErrCommitNotOnBranch},
+		{name: "unknown commit", reference: MakeReference("master", ref.CommitID+1000), wantErr: ErrCommitNotFound},
+		{name: "unknown branch", reference: "no-branch~1", wantErr: db.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.RollbackCommit(ctx, repository, "master", tt.reference)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RollbackCommit err = %s, expected %s", err, tt.wantErr)
+			}
+		})
+	}
+}
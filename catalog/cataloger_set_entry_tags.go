@@ -0,0 +1,45 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// SetEntryTags replaces the object tags kept in the metadata of the entry of path, keeping the rest of the entry.
+// The entry is read and written under its lock, so a concurrent write of the entry is never overwritten by the
+// entry it replaced.
+func (c *cataloger) SetEntryTags(ctx context.Context, repository, branch string, path string, tags map[string]string) error {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "branch", IsValid: ValidateBranchName(branch)},
+		{Name: "path", IsValid: ValidatePath(path)},
+	}); err != nil {
+		return err
+	}
+
+	_, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		branchID, err := c.getBranchIDCache(tx, repository, branch)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkBranchProtection(tx, repository, branch); err != nil {
+			return nil, err
+		}
+		if err := lockEntries(tx, branchID, path); err != nil {
+			return nil, err
+		}
+		entry, err := getEntry(tx, branchID, UncommittedID, path)
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrEntryNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get entry: %w", err)
+		}
+		entry.Metadata = entry.Metadata.WithTags(tags)
+		return insertEntry(tx, branchID, entry)
+	}, c.txOpts(ctx)...)
+	return err
+}
@@ -0,0 +1,51 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_SetEntryTags(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	defer func() { _ = c.Close() }()
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	err := c.CreateEntry(ctx, repository, "master", Entry{
+		Path:            "/file1",
+		Checksum:        "ff",
+		PhysicalAddress: "/addr1",
+		Size:            2,
+		Metadata:        Metadata{"key": "value"}.WithTags(map[string]string{"tag1": "v1"}),
+	}, CreateEntryParams{})
+	testutil.MustDo(t, "create entry", err)
+	_, err = c.Commit(ctx, repository, "master", "commit file1", "tester", nil)
+	testutil.MustDo(t, "commit", err)
+
+	// committed entry is written as uncommitted with its new tags
+	testutil.MustDo(t, "set tags", c.SetEntryTags(ctx, repository, "master", "/file1", map[string]string{"tag2": "v2"}))
+	entry, err := c.GetEntry(ctx, repository, "master", "/file1", GetEntryParams{})
+	testutil.MustDo(t, "get entry", err)
+	if entry.PhysicalAddress != "/addr1" || entry.Checksum != "ff" || entry.Size != 2 {
+		t.Errorf("entry %+v changed by set tags", entry)
+	}
+	if diff := deep.Equal(entry.Metadata, Metadata{"key": "value", TagMetadataPrefix + "tag2": "v2"}); diff != nil {
+		t.Error("metadata after set tags", diff)
+	}
+
+	testutil.MustDo(t, "delete tags", c.SetEntryTags(ctx, repository, "master", "/file1", nil))
+	entry, err = c.GetEntry(ctx, repository, "master", "/file1", GetEntryParams{})
+	testutil.MustDo(t, "get entry", err)
+	if diff := deep.Equal(entry.Metadata, Metadata{"key": "value"}); diff != nil {
+		t.Error("metadata after delete tags", diff)
+	}
+
+	err = c.SetEntryTags(ctx, repository, "master", "/file2", map[string]string{"tag1": "v1"})
+	if !errors.Is(err, ErrEntryNotFound) {
+		t.Errorf("set tags of missing entry err = %v, expected %s", err, ErrEntryNotFound)
+	}
+}
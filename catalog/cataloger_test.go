@@ -101,3 +101,21 @@ func testVerifyEntries(t testing.TB, ctx context.Context, c Cataloger, repositor
 		}
 	}
 }
+
+// testHookRunner records the events it runs, calls OnRun when set, and rejects events of RejectType
+type testHookRunner struct {
+	RejectType HookType
+	Events     []*HookEvent
+	OnRun      func(event *HookEvent)
+}
+
+func (r *testHookRunner) Run(_ context.Context, hooks []*Hook, event *HookEvent) error {
+	r.Events = append(r.Events, event)
+	if r.OnRun != nil {
+		r.OnRun(event)
+	}
+	if event.Type == r.RejectType {
+		return fmt.Errorf("%w: hook '%s' test reject", ErrHookRejected, hooks[0].Name)
+	}
+	return nil
+}
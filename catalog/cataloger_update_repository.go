@@ -0,0 +1,64 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+// UpdateRepository changes the repository settings set in params, and returns the updated repository.  The
+// default branch can be set to any existing branch of the repository.
+func (c *cataloger) UpdateRepository(ctx context.Context, repository string, params UpdateRepositoryParams) (*Repository, error) {
+	if err := Validate(ValidateFields{
+		{Name: "repository", IsValid: ValidateRepositoryName(repository)},
+		{Name: "defaultBranch", IsValid: func() bool {
+			return params.DefaultBranch == "" || IsValidBranchName(params.DefaultBranch)
+		}},
+	}); err != nil {
+		return nil, err
+	}
+
+	res, err := c.db.Transact(func(tx db.Tx) (interface{}, error) {
+		var repoID int
+		err := tx.Get(&repoID, `SELECT id FROM catalog_repositories WHERE name=$1 FOR UPDATE`, repository)
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrRepositoryNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get repository id: %w", err)
+		}
+		if params.DefaultBranch != "" {
+			branchID, err := getBranchID(tx, repository, params.DefaultBranch, LockTypeShare)
+			if errors.Is(err, db.ErrNotFound) {
+				return nil, ErrBranchNotFound
+			}
+			if err != nil {
+				return nil, fmt.Errorf("default branch id: %w", err)
+			}
+			if _, err := tx.Exec(`UPDATE catalog_repositories SET default_branch=$2 WHERE id=$1`,
+				repoID, branchID); err != nil {
+				return nil, fmt.Errorf("update default branch: %w", err)
+			}
+		}
+		if params.Description != nil {
+			if _, err := tx.Exec(`UPDATE catalog_repositories SET description=$2 WHERE id=$1`,
+				repoID, *params.Description); err != nil {
+				return nil, fmt.Errorf("update description: %w", err)
+			}
+		}
+		if params.Metadata != nil {
+			if _, err := tx.Exec(`UPDATE catalog_repositories SET metadata=$2 WHERE id=$1`,
+				repoID, params.Metadata); err != nil {
+				return nil, fmt.Errorf("update metadata: %w", err)
+			}
+		}
+		return getRepository(tx, repository)
+	}, c.txOpts(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.InvalidateRepository(repository)
+	return res.(*Repository), nil
+}
@@ -0,0 +1,80 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCataloger_UpdateRepository(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	testCatalogerBranch(t, ctx, c, repository, "main", "master")
+
+	description := "sales data"
+	repo, err := c.UpdateRepository(ctx, repository, UpdateRepositoryParams{
+		DefaultBranch: "main",
+		Description:   &description,
+		Metadata:      Metadata{"owner": "data-team", "env": "prod"},
+	})
+	if err != nil {
+		t.Fatal("UpdateRepository() failed:", err)
+	}
+	if repo.DefaultBranch != "main" || repo.Description != "sales data" {
+		t.Errorf("UpdateRepository() got default branch %s description %s", repo.DefaultBranch, repo.Description)
+	}
+	if diff := deep.Equal(repo.Metadata, Metadata{"owner": "data-team", "env": "prod"}); diff != nil {
+		t.Error("UpdateRepository() metadata", diff)
+	}
+
+	// unset settings are kept, and the cached repository is updated
+	if _, err := c.UpdateRepository(ctx, repository, UpdateRepositoryParams{
+		Metadata: Metadata{"owner": "ml-team"},
+	}); err != nil {
+		t.Fatal("UpdateRepository() metadata failed:", err)
+	}
+	repo, err = c.GetRepository(ctx, repository)
+	if err != nil {
+		t.Fatal("GetRepository() failed:", err)
+	}
+	if repo.DefaultBranch != "main" || repo.Description != "sales data" {
+		t.Errorf("GetRepository() got default branch %s description %s", repo.DefaultBranch, repo.Description)
+	}
+	if diff := deep.Equal(repo.Metadata, Metadata{"owner": "ml-team"}); diff != nil {
+		t.Error("GetRepository() metadata", diff)
+	}
+
+	// the new default branch can't be deleted, the previous one is not the default branch anymore
+	if err := c.DeleteBranch(ctx, repository, "main"); !errors.Is(err, ErrOperationNotPermitted) {
+		t.Errorf("DeleteBranch() default branch err = %v, expected %s", err, ErrOperationNotPermitted)
+	}
+}
+
+func TestCataloger_UpdateRepository_Errors(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	repository := testCatalogerRepo(t, ctx, c, "repo", "master")
+	description := "description"
+
+	tests := []struct {
+		name       string
+		repository string
+		params     UpdateRepositoryParams
+		wantErr    error
+	}{
+		{name: "unknown repository", repository: "repo-unknown", params: UpdateRepositoryParams{Description: &description}, wantErr: ErrRepositoryNotFound},
+		{name: "unknown branch", repository: repository, params: UpdateRepositoryParams{DefaultBranch: "nob"}, wantErr: ErrBranchNotFound},
+		{name: "invalid branch", repository: repository, params: UpdateRepositoryParams{DefaultBranch: "no/b"}, wantErr: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.UpdateRepository(ctx, tt.repository, tt.params)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateRepository() error = %v, expected %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package catalog
 
 import (
 	"fmt"
+	"path"
 	"reflect"
+	"sort"
 	"strings"
 
 	sq "github.com/Masterminds/squirrel"
@@ -20,6 +22,9 @@ const (
 
 var psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
+// lockEntriesBatchSize is the number of entry locks taken by a single statement
+const lockEntriesBatchSize = 1000
+
 func getBranchID(tx db.Tx, repository, branch string, lockType LockType) (int64, error) {
 	const b = `SELECT b.id FROM catalog_branches b join catalog_repositories r 
 					ON r.id = b.repository_id
@@ -33,6 +38,39 @@ func getBranchID(tx db.Tx, repository, branch string, lockType LockType) (int64,
 	return branchID, err
 }
 
+// lockEntries takes a transaction lock on the entry of each path, in path order so writers of the same paths
+// don't deadlock.  Every write of an entry takes its lock before reading or writing it, so a conditional write
+// checks and writes the entry with no other write of the entry in between.  The lock is taken on the path and not
+// on the entry row, which may not exist yet.
+func lockEntries(tx db.Tx, branchID int64, paths ...string) error {
+	sorted := make([]string, 0, len(paths))
+	sorted = append(sorted, paths...)
+	sort.Strings(sorted)
+	unique := sorted[:0]
+	for _, p := range sorted {
+		if len(unique) == 0 || p != unique[len(unique)-1] {
+			unique = append(unique, p)
+		}
+	}
+	for i := 0; i < len(unique); i += lockEntriesBatchSize {
+		end := i + lockEntriesBatchSize
+		if end > len(unique) {
+			end = len(unique)
+		}
+		args := []interface{}{branchID}
+		values := make([]string, 0, end-i)
+		for _, p := range unique[i:end] {
+			args = append(args, p)
+			values = append(values, fmt.Sprintf("($%d)", len(args)))
+		}
+		if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtextextended($1::text || ':' || v.path, 0))
+			FROM (VALUES `+strings.Join(values, ",")+`) AS v(path)`, args...); err != nil {
+			return fmt.Errorf("lock entries: %w", err)
+		}
+	}
+	return nil
+}
+
 func formatSQLWithLockType(sql string, lockType LockType) (string, error) {
 	var q string
 	switch lockType {
@@ -60,6 +98,60 @@ func getLastCommitIDByBranchID(tx db.Tx, branchID int64) (CommitID, error) {
 	return commitID, err
 }
 
+func getTag(tx db.Tx, repository, tag string) (*tagRaw, error) {
+	var t tagRaw
+	err := tx.Get(&t, `SELECT t.name, b.name AS branch_name, t.commit_id, t.creation_date
+			FROM catalog_tags t
+				JOIN catalog_repositories r ON r.id = t.repository_id
+				JOIN catalog_branches b ON b.id = t.branch_id
+			WHERE r.name = $1 AND t.name = $2`,
+		repository, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+type commitBranch struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+// getCommitBranch returns the branch a commit was made on.  Commit ids are unique across branches, so a commit
+// reference is resolved by its commit id and keeps selecting the same commit after its branch is renamed.
+func getCommitBranch(tx db.Tx, repository string, commitID CommitID) (*commitBranch, error) {
+	var b commitBranch
+	err := tx.Get(&b, `SELECT b.id, b.name
+			FROM catalog_commits c
+				JOIN catalog_branches b ON b.id = c.branch_id
+				JOIN catalog_repositories r ON r.id = b.repository_id
+			WHERE r.name = $1 AND c.commit_id = $2`,
+		repository, commitID)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// checkBranchProtection returns ErrBranchProtected when the branch matches one of the repository branch protection
+// rules
+func checkBranchProtection(tx db.Tx, repository, branch string) error {
+	var patterns []string
+	err := tx.Select(&patterns, `SELECT p.pattern
+			FROM catalog_branch_protection_rules p JOIN catalog_repositories r ON r.id = p.repository_id
+			WHERE r.name = $1`,
+		repository)
+	if err != nil {
+		return fmt.Errorf("branch protection rules: %w", err)
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, branch); matched {
+			return fmt.Errorf("%w: '%s' matches '%s'", ErrBranchProtected, branch, pattern)
+		}
+	}
+	return nil
+}
+
 func getNextCommitID(tx db.Tx) (CommitID, error) {
 	var commitID CommitID
 	err := tx.Get(&commitID, `SELECT nextval('catalog_commit_id_seq');`)
@@ -68,7 +160,8 @@ func getNextCommitID(tx db.Tx) (CommitID, error) {
 
 func getRepository(tx db.Tx, repository string) (*Repository, error) {
 	var r Repository
-	err := tx.Get(&r, `SELECT r.name, r.storage_namespace, b.name as default_branch, r.creation_date
+	err := tx.Get(&r, `SELECT r.name, r.storage_namespace, b.name as default_branch, r.creation_date,
+				r.description, r.metadata
 			FROM catalog_repositories r, catalog_branches b
 			WHERE r.id = b.repository_id AND r.default_branch = b.id AND r.name = $1`,
 		repository)
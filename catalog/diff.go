@@ -12,6 +12,10 @@ const (
 type Difference struct {
 	Type DifferenceType `db:"diff_type"`
 	Path string         `db:"path"`
+	// LeftEntry and RightEntry are the path's entry on each side of the diff, nil when the path does not exist on
+	// that side.  They are set only by Diff and DiffUncommitted.
+	LeftEntry  *Entry `db:"-"`
+	RightEntry *Entry `db:"-"`
 }
 
 func (d Difference) String() string {
@@ -31,6 +35,22 @@ func (d Difference) String() string {
 
 type Differences []Difference
 
+// DiffSummary aggregates all the differences matching the diff filter, not only the returned page.  BytesAdded
+// sums the left side size of added and changed paths, BytesRemoved sums the right side size of removed and
+// changed paths.
+type DiffSummary struct {
+	Files        int   `db:"files"`
+	BytesAdded   int64 `db:"bytes_added"`
+	BytesRemoved int64 `db:"bytes_removed"`
+}
+
+type DiffResult struct {
+	Differences Differences
+	// Summary is set only when requested by DiffParams.WithSummary
+	Summary *DiffSummary
+	HasMore bool
+}
+
 func (d Differences) CountByType() map[DifferenceType]int {
 	result := make(map[DifferenceType]int)
 	for i := range d {
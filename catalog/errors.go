@@ -8,18 +8,33 @@ import (
 )
 
 var (
-	ErrFeatureNotSupported     = errors.New("feature not supported")
-	ErrOperationNotPermitted   = errors.New("operation not permitted")
-	ErrInvalidLockValue        = errors.New("invalid lock value")
-	ErrNothingToCommit         = errors.New("nothing to commit")
-	ErrNoDifferenceWasFound    = errors.New("no difference was found")
-	ErrConflictFound           = errors.New("conflict found")
-	ErrUnsupportedRelation     = errors.New("unsupported relation")
-	ErrUnsupportedDelimiter    = errors.New("unsupported delimiter")
-	ErrInvalidReference        = errors.New("invalid reference")
-	ErrBranchNotFound          = fmt.Errorf("branch %w", db.ErrNotFound)
-	ErrCommitNotFound          = fmt.Errorf("commit %w", db.ErrNotFound)
-	ErrRepositoryNotFound      = fmt.Errorf("repository %w", db.ErrNotFound)
-	ErrMultipartUploadNotFound = fmt.Errorf("multipart upload %w", db.ErrNotFound)
-	ErrEntryNotFound           = fmt.Errorf("entry %w", db.ErrNotFound)
+	ErrFeatureNotSupported               = errors.New("feature not supported")
+	ErrOperationNotPermitted             = errors.New("operation not permitted")
+	ErrInvalidLockValue                  = errors.New("invalid lock value")
+	ErrNothingToCommit                   = errors.New("nothing to commit")
+	ErrNoDifferenceWasFound              = errors.New("no difference was found")
+	ErrConflictFound                     = errors.New("conflict found")
+	ErrUnsupportedRelation               = errors.New("unsupported relation")
+	ErrUnsupportedDelimiter              = errors.New("unsupported delimiter")
+	ErrInvalidReference                  = errors.New("invalid reference")
+	ErrHookRejected                      = errors.New("hook rejected")
+	ErrBranchChangedDuringHooks          = errors.New("branch changed while running hooks")
+	ErrNoHookRunner                      = errors.New("hooks configured without a hook runner")
+	ErrPreconditionFailed                = errors.New("precondition failed")
+	ErrRollbackWithActiveBranch          = fmt.Errorf("%w: rollback with active branch", ErrFeatureNotSupported)
+	ErrBranchProtected                   = fmt.Errorf("%w: branch is protected", ErrOperationNotPermitted)
+	ErrCommitNotInLineage                = fmt.Errorf("%w: commit is not in the branch lineage", ErrOperationNotPermitted)
+	ErrCommitNotOnBranch                 = fmt.Errorf("%w: commit is not on the branch", ErrOperationNotPermitted)
+	ErrBranchNotFound                    = fmt.Errorf("branch %w", db.ErrNotFound)
+	ErrBranchAlreadyExists               = fmt.Errorf("branch %w", db.ErrAlreadyExists)
+	ErrCommitNotFound                    = fmt.Errorf("commit %w", db.ErrNotFound)
+	ErrRepositoryNotFound                = fmt.Errorf("repository %w", db.ErrNotFound)
+	ErrTagNotFound                       = fmt.Errorf("tag %w", db.ErrNotFound)
+	ErrTagAlreadyExists                  = fmt.Errorf("tag %w", db.ErrAlreadyExists)
+	ErrBranchProtectionRuleNotFound      = fmt.Errorf("branch protection rule %w", db.ErrNotFound)
+	ErrBranchProtectionRuleAlreadyExists = fmt.Errorf("branch protection rule %w", db.ErrAlreadyExists)
+	ErrHookNotFound                      = fmt.Errorf("hook %w", db.ErrNotFound)
+	ErrHookAlreadyExists                 = fmt.Errorf("hook %w", db.ErrAlreadyExists)
+	ErrMultipartUploadNotFound           = fmt.Errorf("multipart upload %w", db.ErrNotFound)
+	ErrEntryNotFound                     = fmt.Errorf("entry %w", db.ErrNotFound)
 )
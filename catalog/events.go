@@ -0,0 +1,55 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+type EventType string
+
+const (
+	EventTypeRepositoryCreate EventType = "repository_create"
+	EventTypeRepositoryDelete EventType = "repository_delete"
+	EventTypeBranchCreate     EventType = "branch_create"
+	EventTypeBranchDelete     EventType = "branch_delete"
+	EventTypeBranchReset      EventType = "branch_reset"
+	EventTypeBranchRename     EventType = "branch_rename"
+	EventTypeCommit           EventType = "commit"
+	EventTypeMerge            EventType = "merge"
+	EventTypeImport           EventType = "import"
+)
+
+// Event describes a change in the catalog.  Events are written to the events outbox table in the transaction of
+// the change, and delivered from there to the webhook endpoints.
+type Event struct {
+	Type         EventType `json:"event_type"`
+	Time         time.Time `json:"event_time"`
+	Repository   string    `json:"repository"`
+	Branch       string    `json:"branch,omitempty"`
+	SourceBranch string    `json:"source_branch,omitempty"`
+	Reference    string    `json:"reference,omitempty"`
+	Committer    string    `json:"committer,omitempty"`
+	Message      string    `json:"message,omitempty"`
+	Metadata     Metadata  `json:"metadata,omitempty"`
+}
+
+// addEvent writes the event to the outbox as part of the transaction.  Does nothing when events are disabled.
+func (c *cataloger) addEvent(tx db.Tx, event *Event) error {
+	if !c.eventsEnabled {
+		return nil
+	}
+	event.Time = c.clock.Now()
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("event payload: %w", err)
+	}
+	_, err = tx.Exec(`INSERT INTO catalog_events_outbox (event_type, payload, creation_date) VALUES ($1, $2, $3)`,
+		event.Type, payload, event.Time)
+	if err != nil {
+		return fmt.Errorf("insert event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+type HookType string
+
+const (
+	HookTypePreCommit HookType = "pre-commit"
+	HookTypePreMerge  HookType = "pre-merge"
+
+	DefaultHookTimeout = 30 * time.Second
+)
+
+// Hook is an HTTP endpoint called before an operation on the repository.  The operation is aborted when the
+// endpoint rejects it or doesn't respond within Timeout.
+type Hook struct {
+	Repository   string
+	Name         string
+	Type         HookType
+	URL          string
+	Timeout      time.Duration
+	CreationDate time.Time
+}
+
+type hookRaw struct {
+	Name         string    `db:"name"`
+	Type         string    `db:"hook_type"`
+	URL          string    `db:"url"`
+	TimeoutMS    int64     `db:"timeout_ms"`
+	CreationDate time.Time `db:"creation_date"`
+}
+
+// HookEvent describes the operation passed to the hooks.  Branch is the branch the operation writes to, and
+// SourceBranch is set only for merge.
+type HookEvent struct {
+	Type         HookType
+	Repository   string
+	Branch       string
+	SourceBranch string
+	Committer    string
+	Message      string
+	Metadata     Metadata
+	Differences  Differences
+}
+
+// HookRunner calls hooks with an event.  Run returns an error wrapping ErrHookRejected when any of the hooks
+// rejects the event.
+type HookRunner interface {
+	Run(ctx context.Context, hooks []*Hook, event *HookEvent) error
+}
+
+func convertRawHook(repository string, raw *hookRaw) *Hook {
+	return &Hook{
+		Repository:   repository,
+		Name:         raw.Name,
+		Type:         HookType(raw.Type),
+		URL:          raw.URL,
+		Timeout:      time.Duration(raw.TimeoutMS) * time.Millisecond,
+		CreationDate: raw.CreationDate,
+	}
+}
+
+func getHooks(tx db.Tx, repository string, hookType HookType) ([]*Hook, error) {
+	var rawHooks []*hookRaw
+	err := tx.Select(&rawHooks, `SELECT h.name, h.hook_type, h.url, h.timeout_ms, h.creation_date
+			FROM catalog_hooks h JOIN catalog_repositories r ON r.id = h.repository_id
+			WHERE r.name = $1 AND h.hook_type = $2
+			ORDER BY h.name`,
+		repository, hookType)
+	if err != nil {
+		return nil, err
+	}
+	hooks := make([]*Hook, len(rawHooks))
+	for i, raw := range rawHooks {
+		hooks[i] = convertRawHook(repository, raw)
+	}
+	return hooks, nil
+}
+
+// branchState identifies the changes an operation on a branch is about to write: the branch last commit and
+// its uncommitted entries.  Hooks run outside of the operation transaction, so they don't hold the branch while
+// waiting for a response.  The operation then verifies, in its own transaction, that the branch state is the
+// one the hooks approved.
+type branchState struct {
+	LastCommitID CommitID `db:"last_commit_id"`
+	Uncommitted  string   `db:"uncommitted"`
+}
+
+func getBranchState(tx db.Tx, branchID int64) (branchState, error) {
+	var state branchState
+	err := tx.Get(&state, `SELECT
+			(SELECT COALESCE(MAX(commit_id), 0) FROM catalog_commits WHERE branch_id = $1) AS last_commit_id,
+			(SELECT COALESCE(md5(string_agg(path || ' ' || physical_address || ' ' || max_commit || ' ' || creation_date, E'\n' ORDER BY path)), '')
+				FROM catalog_entries WHERE branch_id = $1 AND min_commit = 0) AS uncommitted`,
+		branchID)
+	if err != nil {
+		return state, fmt.Errorf("branch state: %w", err)
+	}
+	return state, nil
+}
+
+// getHooksToRun returns the repository hooks that match the hook type.  Hooks may enforce a policy, so a
+// cataloger without a runner fails with ErrNoHookRunner instead of skipping them.
+func (c *cataloger) getHooksToRun(tx db.Tx, repository string, hookType HookType) ([]*Hook, error) {
+	hooks, err := getHooks(tx, repository, hookType)
+	if err != nil {
+		return nil, fmt.Errorf("get hooks: %w", err)
+	}
+	if len(hooks) > 0 && c.hookRunner == nil {
+		return nil, ErrNoHookRunner
+	}
+	return hooks, nil
+}
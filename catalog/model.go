@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 )
 
@@ -14,6 +15,8 @@ type Repository struct {
 	StorageNamespace string    `db:"storage_namespace"`
 	DefaultBranch    string    `db:"default_branch"`
 	CreationDate     time.Time `db:"creation_date"`
+	Description      string    `db:"description"`
+	Metadata         Metadata  `db:"metadata"`
 }
 
 type Entry struct {
@@ -27,6 +30,27 @@ type Entry struct {
 	Expired         bool      `db:"is_expired"`
 }
 
+// EntryVersion is a version of an entry, as written by the commit of Reference.  DeleteMarker is set when the
+// commit deleted the entry, and only Path is set on the entry.
+type EntryVersion struct {
+	Entry
+	Reference    string
+	Committer    string
+	Message      string
+	CommitDate   time.Time
+	DeleteMarker bool
+}
+
+type entryVersionRaw struct {
+	Entry
+	BranchName   string    `db:"branch_name"`
+	CommitID     CommitID  `db:"commit_id"`
+	Committer    string    `db:"committer"`
+	Message      string    `db:"message"`
+	CommitDate   time.Time `db:"commit_date"`
+	DeleteMarker bool      `db:"delete_marker"`
+}
+
 type CommitLog struct {
 	Reference    string
 	Committer    string    `db:"committer"`
@@ -58,12 +82,34 @@ type Branch struct {
 	Name       string `db:"name"`
 }
 
+type Tag struct {
+	Repository   string
+	Name         string
+	Reference    string
+	CreationDate time.Time
+}
+
+type tagRaw struct {
+	Name         string    `db:"name"`
+	BranchName   string    `db:"branch_name"`
+	CommitID     CommitID  `db:"commit_id"`
+	CreationDate time.Time `db:"creation_date"`
+}
+
+type BranchProtectionRule struct {
+	Repository   string    `db:"repository"`
+	Pattern      string    `db:"pattern"`
+	CreationDate time.Time `db:"creation_date"`
+}
+
 type MultipartUpload struct {
 	Repository      string    `db:"repository"`
+	Branch          string    `db:"branch"`
 	UploadID        string    `db:"upload_id"`
 	Path            string    `db:"path"`
 	CreationDate    time.Time `db:"creation_date"`
 	PhysicalAddress string    `db:"physical_address"`
+	Metadata        Metadata  `db:"metadata"`
 }
 
 func (j Metadata) Value() (driver.Value, error) {
@@ -83,3 +129,59 @@ func (j *Metadata) Scan(src interface{}) error {
 	}
 	return json.Unmarshal(data, j)
 }
+
+// TagMetadataPrefix prefixes the entry metadata keys holding the object tags.  Tags are kept in the entry
+// metadata so they are versioned with the entry.
+const TagMetadataPrefix = "tag:"
+
+// Tags returns the object tags kept in the metadata, or nil when there are none
+func (j Metadata) Tags() map[string]string {
+	var tags map[string]string
+	for k, v := range j {
+		if !strings.HasPrefix(k, TagMetadataPrefix) {
+			continue
+		}
+		if tags == nil {
+			tags = make(map[string]string)
+		}
+		tags[strings.TrimPrefix(k, TagMetadataPrefix)] = v
+	}
+	return tags
+}
+
+// WithoutTags returns a copy of the metadata without the object tags
+func (j Metadata) WithoutTags() Metadata {
+	if j == nil {
+		return nil
+	}
+	m := make(Metadata, len(j))
+	for k, v := range j {
+		if !strings.HasPrefix(k, TagMetadataPrefix) {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+// WithTags returns a copy of the metadata with its object tags replaced by tags
+func (j Metadata) WithTags(tags map[string]string) Metadata {
+	m := j.WithoutTags()
+	if len(tags) == 0 {
+		return m
+	}
+	if m == nil {
+		m = make(Metadata, len(tags))
+	}
+	for k, v := range tags {
+		m[TagMetadataPrefix+k] = v
+	}
+	return m
+}
+
+// ReferencedAddress is a physical address garbage collection must keep.  UploadID is set for the address of an
+// active multipart upload.
+type ReferencedAddress struct {
+	StorageNamespace string `db:"storage_namespace"`
+	PhysicalAddress  string `db:"physical_address"`
+	UploadID         string `db:"upload_id"`
+}
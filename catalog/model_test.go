@@ -0,0 +1,33 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMetadata_Tags(t *testing.T) {
+	metadata := Metadata{
+		"Content-Type":             "text/csv",
+		TagMetadataPrefix + "team": "data",
+	}
+	if tags := metadata.Tags(); !reflect.DeepEqual(tags, map[string]string{"team": "data"}) {
+		t.Errorf("Tags() = %v, expected team tag", tags)
+	}
+	if m := metadata.WithoutTags(); !reflect.DeepEqual(m, Metadata{"Content-Type": "text/csv"}) {
+		t.Errorf("WithoutTags() = %v, expected content type only", m)
+	}
+	replaced := metadata.WithTags(map[string]string{"stage": "raw"})
+	expected := Metadata{
+		"Content-Type":              "text/csv",
+		TagMetadataPrefix + "stage": "raw",
+	}
+	if !reflect.DeepEqual(replaced, expected) {
+		t.Errorf("WithTags() = %v, expected %v", replaced, expected)
+	}
+	if _, ok := metadata[TagMetadataPrefix+"team"]; !ok {
+		t.Error("WithTags() modified the original metadata")
+	}
+	if tags := Metadata(nil).WithTags(nil).Tags(); tags != nil {
+		t.Errorf("Tags() of nil metadata = %v, expected nil", tags)
+	}
+}
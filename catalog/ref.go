@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/mr-tron/base58"
@@ -19,6 +20,12 @@ const (
 	CommittedSuffix = ":HEAD"
 	CommitPrefix    = "~"
 
+	RefAncestorOperator = '~'
+	RefParentOperator   = '^'
+	RefAsOfPrefix       = "@{"
+	RefAsOfSuffix       = "}"
+	refExpressionChars  = "~^@"
+
 	InternalObjectRefSeparator = "$"
 	InternalObjectRefFormat    = "int:pbm:%s"
 	InternalObjectRefParts     = 3
@@ -27,9 +34,42 @@ const (
 type Ref struct {
 	Branch   string
 	CommitID CommitID
+	// AsOf selects the last commit made on the branch up to this time
+	AsOf *time.Time
+	// Steps are applied in order on the commit selected by the reference, to reach one of its ancestors
+	Steps []RefStep
+}
+
+// RefStep moves from a commit to one of its ancestors: '~' with N follows the first parent N times, '^' with
+// N selects the N-th parent (the first parent is the previous commit on the branch, the second is the merge
+// source commit).
+type RefStep struct {
+	Operator byte
+	N        int
+}
+
+func (s RefStep) String() string {
+	return string(s.Operator) + strconv.Itoa(s.N)
+}
+
+// IsRelative returns true when the reference is resolved by the commits of the branch and not by its name
+func (r Ref) IsRelative() bool {
+	return r.AsOf != nil || len(r.Steps) > 0
 }
 
 func (r Ref) String() string {
+	var sb strings.Builder
+	sb.WriteString(r.baseString())
+	if r.AsOf != nil {
+		sb.WriteString(RefAsOfPrefix + r.AsOf.Format(time.RFC3339) + RefAsOfSuffix)
+	}
+	for _, step := range r.Steps {
+		sb.WriteString(step.String())
+	}
+	return sb.String()
+}
+
+func (r Ref) baseString() string {
 	switch r.CommitID {
 	case CommittedID:
 		return r.Branch + CommittedSuffix
@@ -46,7 +86,78 @@ func MakeReference(branch string, commitID CommitID) string {
 	return Ref{Branch: branch, CommitID: commitID}.String()
 }
 
+// ParseRef parses a branch name, a committed branch ("branch:HEAD") or a commit reference, optionally followed
+// by a relative expression: "@{time}" for the branch as of a point in time, "~N" for the N-th ancestor and
+// "^N" for the N-th parent.  For example "master~3", "master^2" and "master@{2020-10-01T00:00:00Z}~1".
 func ParseRef(ref string) (*Ref, error) {
+	base, expression := splitRefExpression(ref)
+	r, err := parseBaseRef(base)
+	if err != nil {
+		return nil, err
+	}
+	if expression == "" {
+		return r, nil
+	}
+	if strings.HasPrefix(expression, RefAsOfPrefix) {
+		end := strings.Index(expression, RefAsOfSuffix)
+		if end == -1 {
+			return nil, fmt.Errorf("%w: missing '%s'", ErrInvalidReference, RefAsOfSuffix)
+		}
+		asOf, err := parseRefTime(expression[len(RefAsOfPrefix):end])
+		if err != nil {
+			return nil, err
+		}
+		r.AsOf = &asOf
+		expression = expression[end+len(RefAsOfSuffix):]
+	}
+	for expression != "" {
+		op := expression[0]
+		if op != RefAncestorOperator && op != RefParentOperator {
+			return nil, fmt.Errorf("%w: unexpected '%c'", ErrInvalidReference, op)
+		}
+		digits := 1
+		for digits < len(expression) && expression[digits] >= '0' && expression[digits] <= '9' {
+			digits++
+		}
+		n := 1
+		if digits > 1 {
+			n, err = strconv.Atoi(expression[1:digits])
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid '%c' number", ErrInvalidReference, op)
+			}
+		}
+		r.Steps = append(r.Steps, RefStep{Operator: op, N: n})
+		expression = expression[digits:]
+	}
+	return r, nil
+}
+
+// splitRefExpression splits the reference to its base and relative expression parts
+func splitRefExpression(ref string) (string, string) {
+	start := 0
+	if strings.HasPrefix(ref, CommitPrefix) {
+		start = len(CommitPrefix)
+	}
+	idx := strings.IndexAny(ref[start:], refExpressionChars)
+	if idx == -1 {
+		return ref, ""
+	}
+	return ref[:start+idx], ref[start+idx:]
+}
+
+// parseRefTime accepts RFC 3339 time or a date, which is the start of the day in UTC
+func parseRefTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid time '%s'", ErrInvalidReference, s)
+	}
+	return t, nil
+}
+
+func parseBaseRef(ref string) (*Ref, error) {
 	// committed branch
 	if strings.HasSuffix(ref, CommittedSuffix) {
 		return &Ref{
@@ -0,0 +1,125 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/treeverse/lakefs/db"
+)
+
+type commitParentsRaw struct {
+	PreviousCommitID  CommitID `db:"previous_commit_id"`
+	MergeSourceBranch int64    `db:"merge_source_branch"`
+	MergeSourceCommit CommitID `db:"merge_source_commit"`
+}
+
+// resolveRelativeRef resolves the relative expression of ref, starting from the commit base selects on branchID.
+// Returns the commit reference and the id of the branch the commit was made on.
+func resolveRelativeRef(tx db.Tx, branchID int64, base *Ref, ref *Ref) (*Ref, int64, error) {
+	var err error
+	commitID := base.CommitID
+	switch {
+	case ref.AsOf != nil:
+		upToCommitID := MaxCommitID
+		if commitID > UncommittedID {
+			upToCommitID = commitID
+		}
+		branchID, commitID, err = getCommitAsOf(tx, branchID, upToCommitID, *ref.AsOf)
+	case commitID <= UncommittedID:
+		commitID, err = getLastCommitIDByBranchID(tx, branchID)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, step := range ref.Steps {
+		switch step.Operator {
+		case RefAncestorOperator:
+			for i := 0; i < step.N && err == nil; i++ {
+				branchID, commitID, err = getCommitParent(tx, branchID, commitID, 1)
+			}
+		case RefParentOperator:
+			if step.N > 0 {
+				branchID, commitID, err = getCommitParent(tx, branchID, commitID, step.N)
+			}
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	var branch string
+	if err := tx.Get(&branch, `SELECT name FROM catalog_branches WHERE id=$1`, branchID); err != nil {
+		return nil, 0, fmt.Errorf("get branch name: %w", err)
+	}
+	return &Ref{Branch: branch, CommitID: commitID}, branchID, nil
+}
+
+// getCommitAsOf returns the last commit made up to asOf on the branch lineage, not after upToCommitID.
+// Commits made before the branch was created are looked up on the branch it was created from.
+func getCommitAsOf(tx db.Tx, branchID int64, upToCommitID CommitID, asOf time.Time) (int64, CommitID, error) {
+	for {
+		var commitID CommitID
+		err := tx.Get(&commitID, `SELECT commit_id FROM catalog_commits
+			WHERE branch_id=$1 AND commit_id<=$2 AND creation_date<=$3
+			ORDER BY commit_id DESC LIMIT 1`,
+			branchID, upToCommitID, asOf)
+		if err == nil {
+			return branchID, commitID, nil
+		}
+		if !errors.Is(err, db.ErrNotFound) {
+			return 0, 0, fmt.Errorf("get commit: %w", err)
+		}
+		var source commitParentsRaw
+		err = tx.Get(&source, `SELECT previous_commit_id,
+				COALESCE(merge_source_branch,0) AS merge_source_branch,
+				COALESCE(merge_source_commit,0) AS merge_source_commit
+			FROM catalog_commits
+			WHERE branch_id=$1 AND previous_commit_id=0
+			ORDER BY commit_id LIMIT 1`,
+			branchID)
+		if errors.Is(err, db.ErrNotFound) {
+			return 0, 0, ErrCommitNotFound
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("get branch source: %w", err)
+		}
+		if source.MergeSourceBranch == 0 || source.MergeSourceCommit <= 0 {
+			return 0, 0, ErrCommitNotFound
+		}
+		branchID = source.MergeSourceBranch
+		upToCommitID = source.MergeSourceCommit
+	}
+}
+
+// getCommitParent returns the n-th parent of the commit.  The first parent is the previous commit on the branch,
+// or the commit the branch was created from for the first commit of a branch. The second parent is the source
+// of a merge commit.
+func getCommitParent(tx db.Tx, branchID int64, commitID CommitID, n int) (int64, CommitID, error) {
+	var commit commitParentsRaw
+	err := tx.Get(&commit, `SELECT previous_commit_id,
+			COALESCE(merge_source_branch,0) AS merge_source_branch,
+			COALESCE(merge_source_commit,0) AS merge_source_commit
+		FROM catalog_commits WHERE branch_id=$1 AND commit_id=$2`,
+		branchID, commitID)
+	if errors.Is(err, db.ErrNotFound) {
+		return 0, 0, ErrCommitNotFound
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("get commit: %w", err)
+	}
+	type parent struct {
+		branchID int64
+		commitID CommitID
+	}
+	var parents []parent
+	if commit.PreviousCommitID > 0 {
+		parents = append(parents, parent{branchID: branchID, commitID: commit.PreviousCommitID})
+	}
+	if commit.MergeSourceBranch > 0 && commit.MergeSourceCommit > 0 {
+		parents = append(parents, parent{branchID: commit.MergeSourceBranch, commitID: commit.MergeSourceCommit})
+	}
+	if n > len(parents) {
+		return 0, 0, fmt.Errorf("%w: parent %d", ErrCommitNotFound, n)
+	}
+	return parents[n-1].branchID, parents[n-1].commitID, nil
+}
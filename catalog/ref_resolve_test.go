@@ -0,0 +1,102 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+func TestCataloger_RelativeRef(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Round(time.Minute)
+	mockClock := clock.NewMock()
+	mockClock.Set(now)
+	c := testCataloger(t, WithClock(mockClock))
+	defer func() { _ = c.Close() }()
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	// commits a day apart: two on master, branch1 with one commit merged back to master
+	commitDay := func(branch, path string) string {
+		mockClock.Add(24 * time.Hour)
+		testCatalogerCreateEntry(t, ctx, c, repository, branch, path, nil, "")
+		commitLog, err := c.Commit(ctx, repository, branch, "commit "+path, "tester", nil)
+		testutil.MustDo(t, "commit "+path, err)
+		return commitLog.Reference
+	}
+	master1 := commitDay("master", "file1")
+	master2 := commitDay("master", "file2")
+	branch0, err := c.CreateBranch(ctx, repository, "branch1", "master")
+	testutil.MustDo(t, "create branch", err)
+	branch1 := commitDay("branch1", "file3")
+	mockClock.Add(24 * time.Hour)
+	merge, err := c.Merge(ctx, repository, "branch1", "master", "tester", "", nil, MergeParams{})
+	testutil.MustDo(t, "merge", err)
+
+	day := func(n int) string {
+		return now.Add(time.Duration(n) * 24 * time.Hour).Add(time.Hour).Format(time.RFC3339)
+	}
+	tests := []struct {
+		name      string
+		reference string
+		want      string
+		wantErr   error
+	}{
+		{name: "head", reference: "master~0", want: merge.Reference},
+		{name: "ancestor", reference: "master~1", want: master2},
+		{name: "ancestors", reference: "master~2", want: master1},
+		{name: "first parent", reference: "master^", want: master2},
+		{name: "merge parent", reference: "master^2", want: branch1},
+		{name: "merge parent ancestor", reference: "master^2~1", want: branch0.Reference},
+		{name: "branch source", reference: "branch1~2", want: master2},
+		{name: "commit ancestor", reference: merge.Reference + "~2", want: master1},
+		{name: "as of", reference: "master@{" + day(1) + "}", want: master1},
+		{name: "as of before merge", reference: "master@{" + day(3) + "}", want: master2},
+		{name: "as of ancestor", reference: "master@{" + day(2) + "}~1", want: master1},
+		{name: "as of before branch", reference: "branch1@{" + day(1) + "}", want: master1},
+		{name: "no ancestor", reference: "master~10", wantErr: ErrCommitNotFound},
+		{name: "no parent", reference: "master~1^2", wantErr: ErrCommitNotFound},
+		{name: "before repository", reference: "master@{" + day(-2) + "}", wantErr: ErrCommitNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commit, err := c.GetCommit(ctx, repository, tt.reference)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetCommit(%s) err = %v, expected %v", tt.reference, err, tt.wantErr)
+				}
+				return
+			}
+			testutil.MustDo(t, "get commit "+tt.reference, err)
+			if commit.Reference != tt.want {
+				t.Fatalf("GetCommit(%s) reference = %s, expected %s", tt.reference, commit.Reference, tt.want)
+			}
+		})
+	}
+}
+
+func TestCataloger_RelativeRef_GetEntry(t *testing.T) {
+	ctx := context.Background()
+	c := testCataloger(t)
+	defer func() { _ = c.Close() }()
+	repository := testCatalogerRepo(t, ctx, c, "repository", "master")
+
+	testCatalogerCreateEntry(t, ctx, c, repository, "master", "file1", nil, "")
+	_, err := c.Commit(ctx, repository, "master", "add file1", "tester", nil)
+	testutil.MustDo(t, "commit file1", err)
+	testutil.MustDo(t, "delete file1", c.DeleteEntry(ctx, repository, "master", "file1"))
+	_, err = c.Commit(ctx, repository, "master", "delete file1", "tester", nil)
+	testutil.MustDo(t, "commit delete", err)
+
+	if _, err := c.GetEntry(ctx, repository, "master", "file1", GetEntryParams{}); err == nil {
+		t.Fatal("GetEntry on master expected deleted file1 not to be found")
+	}
+	entry, err := c.GetEntry(ctx, repository, "master~1", "file1", GetEntryParams{})
+	testutil.MustDo(t, "get entry of previous commit", err)
+	if entry.Path != "file1" {
+		t.Fatalf("GetEntry path = %s, expected file1", entry.Path)
+	}
+}
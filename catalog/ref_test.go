@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestRef_String(t *testing.T) {
@@ -50,6 +51,11 @@ func TestRef_String(t *testing.T) {
 	}
 }
 
+var (
+	refTestTime = time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)
+	refTestDate = time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+)
+
 func TestParseRef(t *testing.T) {
 	type args struct {
 		ref string
@@ -90,6 +96,66 @@ func TestParseRef(t *testing.T) {
 			want:    &Ref{},
 			wantErr: false,
 		},
+		{
+			name:    "ancestor",
+			args:    args{ref: "main~3"},
+			want:    &Ref{Branch: "main", Steps: []RefStep{{Operator: '~', N: 3}}},
+			wantErr: false,
+		},
+		{
+			name:    "parent",
+			args:    args{ref: "main^2"},
+			want:    &Ref{Branch: "main", Steps: []RefStep{{Operator: '^', N: 2}}},
+			wantErr: false,
+		},
+		{
+			name:    "default steps",
+			args:    args{ref: "main^~"},
+			want:    &Ref{Branch: "main", Steps: []RefStep{{Operator: '^', N: 1}, {Operator: '~', N: 1}}},
+			wantErr: false,
+		},
+		{
+			name:    "as of",
+			args:    args{ref: "main@{2020-10-01T10:00:00Z}"},
+			want:    &Ref{Branch: "main", AsOf: &refTestTime},
+			wantErr: false,
+		},
+		{
+			name:    "as of date",
+			args:    args{ref: "main@{2020-10-01}~1"},
+			want:    &Ref{Branch: "main", AsOf: &refTestDate, Steps: []RefStep{{Operator: '~', N: 1}}},
+			wantErr: false,
+		},
+		{
+			name:    "commit ancestor",
+			args:    args{ref: "~6kfQBz477AZCUw~2"},
+			want:    &Ref{Branch: "feature", CommitID: 10, Steps: []RefStep{{Operator: '~', N: 2}}},
+			wantErr: false,
+		},
+		{
+			name:    "committed ancestor",
+			args:    args{ref: "main:HEAD^"},
+			want:    &Ref{Branch: "main", CommitID: CommittedID, Steps: []RefStep{{Operator: '^', N: 1}}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid time",
+			args:    args{ref: "main@{yesterday}"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "unterminated time",
+			args:    args{ref: "main@{2020-10-01"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid step",
+			args:    args{ref: "main~1x"},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3,13 +3,19 @@ package catalog
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"path"
 	"regexp"
+	"time"
 )
 
+const branchProtectionPatternMaxLength = 64
+
 var (
 	ErrInvalidValue = errors.New("invalid value")
 
 	validBranchNameRegexp     = regexp.MustCompile(`^\w[-\w]*$`)
+	validTagNameRegexp        = regexp.MustCompile(`^\w[-\w.]*$`)
 	validRepositoryNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{2,62}$`)
 )
 
@@ -45,6 +51,57 @@ func IsValidBranchName(branch string) bool {
 	return validBranchNameRegexp.MatchString(branch)
 }
 
+func ValidateTagName(tag string) ValidateFunc {
+	return func() bool {
+		return IsValidTagName(tag)
+	}
+}
+
+// IsValidTagName accepts branch names and also allows dots after the first character, as in version tags ("v1.2.3")
+func IsValidTagName(tag string) bool {
+	return validTagNameRegexp.MatchString(tag)
+}
+
+func ValidateBranchProtectionPattern(pattern string) ValidateFunc {
+	return func() bool {
+		return IsValidBranchProtectionPattern(pattern)
+	}
+}
+
+// IsValidBranchProtectionPattern accepts a branch name or a glob pattern, as matched by path.Match
+func IsValidBranchProtectionPattern(pattern string) bool {
+	if len(pattern) == 0 || len(pattern) > branchProtectionPatternMaxLength {
+		return false
+	}
+	_, err := path.Match(pattern, "")
+	return err == nil
+}
+
+func ValidateHookName(name string) ValidateFunc {
+	return func() bool {
+		return validBranchNameRegexp.MatchString(name)
+	}
+}
+
+func ValidateHookType(hookType HookType) ValidateFunc {
+	return func() bool {
+		return hookType == HookTypePreCommit || hookType == HookTypePreMerge
+	}
+}
+
+func ValidateHookURL(hookURL string) ValidateFunc {
+	return func() bool {
+		u, err := url.Parse(hookURL)
+		return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+	}
+}
+
+func ValidateHookTimeout(timeout time.Duration) ValidateFunc {
+	return func() bool {
+		return timeout >= 0
+	}
+}
+
 func ValidateRepositoryName(repository string) ValidateFunc {
 	return func() bool {
 		return IsValidRepositoryName(repository)
@@ -66,7 +123,8 @@ func IsValidReference(reference string) bool {
 	if err != nil {
 		return false
 	}
-	if !IsValidBranchName(ref.Branch) {
+	// a name without a commit can also be a tag
+	if !IsValidBranchName(ref.Branch) && (ref.CommitID != UncommittedID || !IsValidTagName(ref.Branch)) {
 		return false
 	}
 	if ref.CommitID < CommittedID {
@@ -119,3 +177,18 @@ func ValidateOptionalString(s string, validator func(string) bool) ValidateFunc
 		return validator(s)
 	}
 }
+
+func ValidateMergeStrategy(strategy MergeStrategy) ValidateFunc {
+	return func() bool {
+		return IsValidMergeStrategy(strategy)
+	}
+}
+
+func IsValidMergeStrategy(strategy MergeStrategy) bool {
+	switch strategy {
+	case "", MergeStrategyFail, MergeStrategySourceWins, MergeStrategyDestWins:
+		return true
+	default:
+		return false
+	}
+}
@@ -86,6 +86,56 @@ func TestIsValidBranchName(t *testing.T) {
 	}
 }
 
+func TestIsValidTagName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "simple", input: "tag", want: true},
+		{name: "empty", input: "", want: false},
+		{name: "version", input: "v1.0", want: true},
+		{name: "semver", input: "v2.3.1", want: true},
+		{name: "semver-suffix", input: "v2.3.1-rc1", want: true},
+		{name: "leading-dot", input: ".v1", want: false},
+		{name: "leading-dash", input: "-v1", want: false},
+		{name: "space", input: "v 1", want: false},
+		{name: "slash", input: "release/v1", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsValidTagName(tt.input)
+			if got != tt.want {
+				t.Errorf("IsValidTagName() got = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidReference(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "branch", input: "master", want: true},
+		{name: "tag", input: "v1.0", want: true},
+		{name: "tag ancestor", input: "v1.0~1", want: true},
+		{name: "committed branch", input: "master:HEAD", want: true},
+		{name: "committed tag", input: "v1.0:HEAD", want: false},
+		{name: "commit", input: MakeReference("master", 1), want: true},
+		{name: "empty", input: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsValidReference(tt.input)
+			if got != tt.want {
+				t.Errorf("IsValidReference() got = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsNonEmptyString(t *testing.T) {
 	tests := []struct {
 		name  string
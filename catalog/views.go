@@ -97,8 +97,8 @@ func sqEntriesLineageV(branchID int64, requestedCommit CommitID, lineage []linea
 	return baseSelect
 }
 
-func sqDiffFromSonV(fatherID, sonID int64, fatherEffectiveCommit, sonEffectiveCommit CommitID, fatherUncommittedLineage []lineageCommit, sonLineageValues string) sq.SelectBuilder {
-	lineage := sqEntriesLineage(fatherID, UncommittedID, fatherUncommittedLineage)
+func sqDiffFromSonV(fatherID, sonID int64, fatherEffectiveCommit, sonEffectiveCommit CommitID, fatherUncommittedLineage []lineageCommit, sonLineageValues string, filter diffPathFilter) sq.SelectBuilder {
+	lineage := filter.where(sqEntriesLineage(fatherID, UncommittedID, fatherUncommittedLineage), "e.path")
 	sqFather := sq.Select("*").
 		FromSelect(lineage, "z").
 		Where("displayed_branch = ?", fatherID)
@@ -127,10 +127,10 @@ func sqDiffFromSonV(fatherID, sonID int64, fatherEffectiveCommit, sonEffectiveCo
 											 (l.commit_id > f.max_commit OR NOT f.is_deleted))
 										   ))) 
 											AS DifferenceTypeConflict`, fatherID, fatherEffectiveCommit, fatherEffectiveCommit, fatherID).
-		FromSelect(sqEntriesV(CommittedID).Distinct().
+		FromSelect(filter.where(sqEntriesV(CommittedID).Distinct().
 			Options(" on (branch_id,path)").
 			OrderBy("branch_id", "path", "min_commit desc").
-			Where("branch_id = ? AND (min_commit >= ? OR max_commit >= ? and is_deleted)", sonID, sonEffectiveCommit, sonEffectiveCommit), "s").
+			Where("branch_id = ? AND (min_commit >= ? OR max_commit >= ? and is_deleted)", sonID, sonEffectiveCommit, sonEffectiveCommit), "path"), "s").
 		JoinClause(sqFather.Prefix("LEFT JOIN (").Suffix(") AS f ON f.path = s.path"))
 	RemoveNonRelevantQ := sq.Select("*").FromSelect(fromSonInternalQ, "t").Where("NOT (same_object OR both_deleted)")
 	return sq.Select().
@@ -146,14 +146,14 @@ func sqDiffFromSonV(fatherID, sonID int64, fatherEffectiveCommit, sonEffectiveCo
 		FromSelect(RemoveNonRelevantQ, "t1")
 }
 
-func sqDiffFromFatherV(fatherID, sonID int64, lastSonMergeWithFather CommitID, fatherUncommittedLineage, sonUncommittedLineage []lineageCommit) sq.SelectBuilder {
+func sqDiffFromFatherV(fatherID, sonID int64, lastSonMergeWithFather CommitID, fatherUncommittedLineage, sonUncommittedLineage []lineageCommit, filter diffPathFilter) sq.SelectBuilder {
 	sonLineageValues := getLineageAsValues(sonUncommittedLineage, sonID)
-	sonLineage := sqEntriesLineage(sonID, UncommittedID, sonUncommittedLineage)
+	sonLineage := filter.where(sqEntriesLineage(sonID, UncommittedID, sonUncommittedLineage), "e.path")
 	sqSon := sq.Select("*").
 		FromSelect(sonLineage, "s").
 		Where("displayed_branch = ?", sonID)
 
-	fatherLineage := sqEntriesLineage(fatherID, CommittedID, fatherUncommittedLineage)
+	fatherLineage := filter.where(sqEntriesLineage(fatherID, CommittedID, fatherUncommittedLineage), "e.path")
 	// Can diff with expired files, just not usefully!
 	internalV := sq.Select("f.path",
 		"f.entry_ctid",
@@ -193,3 +193,101 @@ func sqDiffFromFatherV(fatherID, sonID int64, lastSonMergeWithFather CommitID, f
 			Else("NULL"), "entry_ctid")).
 		FromSelect(RemoveNonRelevantQ, "t1")
 }
+
+// sqEntriesSnapshot returns the committed entries of a branch at a specific commit, the same way a son branch
+// observes its father branch through its lineage
+func sqEntriesSnapshot(branchID int64, commitID CommitID, lineage []lineageCommit) sq.SelectBuilder {
+	snapshotLineage := make([]lineageCommit, 0, len(lineage)+1)
+	snapshotLineage = append(snapshotLineage, lineageCommit{BranchID: branchID, CommitID: commitID})
+	snapshotLineage = append(snapshotLineage, lineage...)
+	// no branch uses id zero, so all entries are taken from the lineage
+	return sqEntriesLineage(0, CommittedID, snapshotLineage)
+}
+
+func sqDiffNonDirectV(leftID, rightID int64, leftLineage, rightUncommittedLineage []lineageCommit, base lineageCommit, baseLineage []lineageCommit, filter diffPathFilter) sq.SelectBuilder {
+	sqBase := sq.Select("path", "physical_address").
+		FromSelect(filter.where(sqEntriesSnapshot(base.BranchID, base.CommitID, baseLineage), "e.path"), "eb").
+		Where("NOT is_deleted")
+	sqLeft := sq.Select("path", "physical_address", "entry_ctid").
+		FromSelect(filter.where(sqEntriesLineage(leftID, CommittedID, leftLineage), "e.path"), "el").
+		Where("NOT is_deleted")
+	sqRight := sq.Select("path", "physical_address", "source_branch", "is_committed", "is_deleted").
+		FromSelect(filter.where(sqEntriesLineage(rightID, UncommittedID, rightUncommittedLineage), "e.path"), "er")
+	return sqDiffThreeWayV(sqBase, sqLeft, sqRight)
+}
+
+// sqDiffSnapshotsV compares two lineage queries by path: paths found only on the left are added, paths found only
+// on the right are removed, and paths found on both with a different object are changed
+func sqDiffSnapshotsV(leftQ, rightQ sq.SelectBuilder, filter diffPathFilter) sq.SelectBuilder {
+	sqLeft := sq.Select("path", "physical_address").FromSelect(filter.where(leftQ, "e.path"), "el").Where("NOT is_deleted")
+	sqRight := sq.Select("path", "physical_address").FromSelect(filter.where(rightQ, "e.path"), "er").Where("NOT is_deleted")
+	return sq.Select().
+		Column(sq.Alias(sq.Case().When("r.path IS NULL", "0").
+			When("l.path IS NULL", "1").
+			Else("2"), "diff_type")).
+		Column("COALESCE(l.path, r.path) AS path").
+		FromSelect(sqLeft, "l").
+		JoinClause(sqRight.Prefix("FULL OUTER JOIN (").Suffix(") AS r ON r.path = l.path")).
+		Where("l.path IS NULL OR r.path IS NULL OR l.physical_address IS DISTINCT FROM r.physical_address")
+}
+
+// sqDiffCommitsV compares the changes made on the source branch between two commits with the destination branch.
+// The snapshot of the first commit serves as the base of the three way comparison.
+func sqDiffCommitsV(sourceID int64, fromCommitID, toCommitID CommitID, fromLineage, toLineage []lineageCommit, destinationID int64, destinationUncommittedLineage []lineageCommit) sq.SelectBuilder {
+	sqBase := sq.Select("path", "physical_address").
+		FromSelect(sqEntriesSnapshot(sourceID, fromCommitID, fromLineage), "eb").
+		Where("NOT is_deleted")
+	sqLeft := sq.Select("path", "physical_address", "entry_ctid").
+		FromSelect(sqEntriesSnapshot(sourceID, toCommitID, toLineage), "el").
+		Where("NOT is_deleted")
+	sqRight := sq.Select("path", "physical_address", "source_branch", "is_committed", "is_deleted").
+		FromSelect(sqEntriesLineage(destinationID, UncommittedID, destinationUncommittedLineage), "er")
+	return sqDiffThreeWayV(sqBase, sqLeft, sqRight)
+}
+
+// sqDiffThreeWayV returns the differences needed to apply the changes made between base and left on right.
+// Paths changed by both left and right are reported as conflicts.
+func sqDiffThreeWayV(sqBase, sqLeft, sqRight sq.SelectBuilder) sq.SelectBuilder {
+	// three way comparison of each path - base, left (source) and right (destination)
+	internalV := sq.Select("COALESCE(l.path, r.path, b.path) AS path",
+		"l.entry_ctid",
+		"r.source_branch",
+		"b.path IS NOT NULL AS base_exists",
+		"l.path IS NOT NULL AS left_exists",
+		"r.path IS NOT NULL AND NOT r.is_deleted AS right_exists",
+		"r.path IS NOT NULL AND NOT r.is_committed AS right_uncommitted",
+		"b.physical_address AS base_address",
+		"l.physical_address AS left_address",
+		"r.physical_address AS right_address").
+		FromSelect(sqBase, "b").
+		JoinClause(sqLeft.Prefix("FULL OUTER JOIN (").Suffix(") AS l ON l.path = b.path")).
+		JoinClause(sqRight.Prefix("FULL OUTER JOIN (").Suffix(") AS r ON r.path = COALESCE(l.path, b.path)"))
+
+	changesV := sq.Select("*").
+		// left changed the path since base
+		Column(`base_exists <> left_exists OR
+			(base_exists AND left_exists AND base_address IS DISTINCT FROM left_address) AS left_changed`).
+		// right changed the path since base - uncommitted entries are always new
+		Column(`right_uncommitted OR base_exists <> right_exists OR
+			(base_exists AND right_exists AND base_address IS DISTINCT FROM right_address) AS right_changed`).
+		// both sides end up with the same object, or both deleted it
+		Column(`left_exists = right_exists AND
+			(NOT left_exists OR left_address IS NOT DISTINCT FROM right_address) AS same_object`).
+		FromSelect(internalV, "t")
+
+	RemoveNonRelevantQ := sq.Select("*").
+		FromSelect(changesV, "t1").
+		Where("left_changed AND NOT same_object")
+
+	return sq.Select().
+		Column(sq.Alias(sq.Case().When("right_changed", "3").
+			When("NOT left_exists", "1").
+			When("right_exists", "2").
+			Else("0"), "diff_type")).
+		Column("path").
+		Column(sq.Alias(sq.Case().
+			When("left_exists AND NOT right_changed", "entry_ctid").
+			Else("NULL"), "entry_ctid")).
+		Column("source_branch").
+		FromSelect(RemoveNonRelevantQ, "t2")
+}
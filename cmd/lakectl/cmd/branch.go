@@ -14,7 +14,7 @@ import (
 var branchCmd = &cobra.Command{
 	Use:   "branch",
 	Short: "create and manage branches within a repository",
-	Long:  `Create delete and list branches within a lakeFS repository`,
+	Long:  `Create delete rename and list branches within a lakeFS repository`,
 }
 
 var branchListTemplate = `{{.BranchTable | table -}}
@@ -118,15 +118,35 @@ var branchDeleteCmd = &cobra.Command{
 	},
 }
 
+var branchRenameCmd = &cobra.Command{
+	Use:     "rename <branch uri> <new name>",
+	Short:   "rename a branch, keeping its commits and merge history",
+	Example: "lakectl branch rename lakefs://<repository>@<branch> <new name>",
+	Args: ValidationChain(
+		HasNArgs(2),
+		IsRefURI(0),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := getClient()
+		u := uri.Must(uri.Parse(args[0]))
+		err := client.RenameBranch(context.Background(), u.Repository, u.Ref, args[1])
+		if err != nil {
+			DieErr(err)
+		}
+		Fmt("renamed branch '%s' to '%s'\n", u.Ref, args[1])
+	},
+}
+
 // lakectl branch revert lakefs://myrepo@master --commit commitId --prefix path --object path
 var branchRevertCmd = &cobra.Command{
 	Use:   "revert <branch uri> [flags]",
 	Short: "revert changes to specified commit, or revert uncommitted changes - all changes, or by path",
-	Long: `revert changes.  There are four different ways to revert changes:
+	Long: `revert changes.  There are five different ways to revert changes:
   1. revert to previous commit, set HEAD of branch to given commit - revert lakefs://myrepo@master --commit commitId
-  2. revert all uncommitted changes (reset) - revert lakefs://myrepo@master 
-  3. revert uncommitted changes under specific path -	revert lakefs://myrepo@master --prefix path
-  4. revert uncommitted changes for specific object - revert lakefs://myrepo@master --object path`,
+  2. revert the changes of a single commit by creating an inverse commit - revert lakefs://myrepo@master --inverse-commit commitId
+  3. revert all uncommitted changes (reset) - revert lakefs://myrepo@master 
+  4. revert uncommitted changes under specific path -	revert lakefs://myrepo@master --prefix path
+  5. revert uncommitted changes for specific object - revert lakefs://myrepo@master --object path`,
 	Args: ValidationChain(
 		HasNArgs(1),
 		IsRefURI(0),
@@ -139,6 +159,10 @@ var branchRevertCmd = &cobra.Command{
 		if err != nil {
 			DieErr(err)
 		}
+		inverseCommitID, err := cmd.Flags().GetString("inverse-commit")
+		if err != nil {
+			DieErr(err)
+		}
 		prefix, err := cmd.Flags().GetString("prefix")
 		if err != nil {
 			DieErr(err)
@@ -157,6 +181,12 @@ var branchRevertCmd = &cobra.Command{
 				Commit: commitID,
 				Type:   swag.String(models.RevertCreationTypeCommit),
 			}
+		case len(inverseCommitID) > 0:
+			confirmationMsg = fmt.Sprintf("Are you sure you want to revert the changes of commit: %s", inverseCommitID)
+			revert = models.RevertCreation{
+				Commit: inverseCommitID,
+				Type:   swag.String(models.RevertCreationTypeInverseCommit),
+			}
 		case len(prefix) > 0:
 			confirmationMsg = fmt.Sprintf("Are you sure you want to revert all changes from path: %s to last commit", prefix)
 			revert = models.RevertCreation{
@@ -210,6 +240,7 @@ func init() {
 	rootCmd.AddCommand(branchCmd)
 	branchCmd.AddCommand(branchCreateCmd)
 	branchCmd.AddCommand(branchDeleteCmd)
+	branchCmd.AddCommand(branchRenameCmd)
 	branchCmd.AddCommand(branchListCmd)
 	branchCmd.AddCommand(branchShowCmd)
 	branchCmd.AddCommand(branchRevertCmd)
@@ -221,6 +252,7 @@ func init() {
 	_ = branchCreateCmd.MarkFlagRequired("source")
 
 	branchRevertCmd.Flags().String("commit", "", "commit ID to revert branch to")
+	branchRevertCmd.Flags().String("inverse-commit", "", "commit ID to revert its changes by creating an inverse commit")
 	branchRevertCmd.Flags().String("prefix", "", "prefix of the objects to be reverted")
 	branchRevertCmd.Flags().String("object", "", "path to object to be reverted")
 }
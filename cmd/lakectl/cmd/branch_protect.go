@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/uri"
+)
+
+// branchProtectCmd represents the branch-protect command
+var branchProtectCmd = &cobra.Command{
+	Use:   "branch-protect",
+	Short: "create and manage branch protection rules",
+	Long:  `Protect branches matching a name pattern from direct writes, commits and deletes - changes reach a protected branch only by merge`,
+}
+
+var branchProtectListTemplate = `{{.RuleTable | table -}}
+`
+
+var branchProtectListCmd = &cobra.Command{
+	Use:     "list <repository uri>",
+	Short:   "list branch protection rules of a repository",
+	Example: "lakectl branch-protect list lakefs://<repository>",
+	Args: ValidationChain(
+		HasNArgs(1),
+		IsRepoURI(0),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		u := uri.Must(uri.Parse(args[0]))
+		client := getClient()
+		rules, err := client.ListBranchProtectionRules(context.Background(), u.Repository)
+		if err != nil {
+			DieErr(err)
+		}
+
+		rows := make([][]interface{}, len(rules))
+		for i, rule := range rules {
+			ts := time.Unix(rule.CreationDate, 0).String()
+			rows[i] = []interface{}{rule.Pattern, ts}
+		}
+		Write(branchProtectListTemplate, struct {
+			RuleTable *Table
+		}{
+			RuleTable: &Table{
+				Headers: []interface{}{"Branch Pattern", "Creation Date"},
+				Rows:    rows,
+			},
+		})
+	},
+}
+
+var branchProtectAddCmd = &cobra.Command{
+	Use:     "add <repository uri> <pattern>",
+	Short:   "protect branches matching a name or glob pattern",
+	Example: "lakectl branch-protect add lakefs://<repository> 'release-*'",
+	Args: ValidationChain(
+		HasNArgs(2),
+		IsRepoURI(0),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		u := uri.Must(uri.Parse(args[0]))
+		client := getClient()
+		err := client.CreateBranchProtectionRule(context.Background(), u.Repository, args[1])
+		if err != nil {
+			DieErr(err)
+		}
+		Fmt("branches matching '%s' are protected\n", args[1])
+	},
+}
+
+var branchProtectDeleteCmd = &cobra.Command{
+	Use:     "delete <repository uri> <pattern>",
+	Short:   "delete a branch protection rule",
+	Example: "lakectl branch-protect delete lakefs://<repository> 'release-*'",
+	Args: ValidationChain(
+		HasNArgs(2),
+		IsRepoURI(0),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		confirmation, err := confirm(cmd.Flags(), "Are you sure you want to delete branch protection rule")
+		if err != nil || !confirmation {
+			Die("Delete branch protection rule aborted", 1)
+		}
+		u := uri.Must(uri.Parse(args[0]))
+		client := getClient()
+		err = client.DeleteBranchProtectionRule(context.Background(), u.Repository, args[1])
+		if err != nil {
+			DieErr(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(branchProtectCmd)
+	branchProtectCmd.AddCommand(branchProtectAddCmd)
+	branchProtectCmd.AddCommand(branchProtectDeleteCmd)
+	branchProtectCmd.AddCommand(branchProtectListCmd)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/api/gen/models"
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/uri"
+)
+
+// cherryPickCmd represents the cherry-pick command
+var cherryPickCmd = &cobra.Command{
+	Use:     "cherry-pick <commit ref> <destination branch>",
+	Short:   "apply the changes of a single commit on a branch",
+	Long:    "apply & commit the changes introduced by a commit, compared to its parent, on the destination branch",
+	Example: "lakectl cherry-pick lakefs://<repository>@<commit> lakefs://<repository>@<branch>",
+	Args: ValidationChain(
+		HasNArgs(2),
+		IsRefURI(0),
+		IsRefURI(1),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		commitURI := uri.Must(uri.Parse(args[0]))
+		branchURI := uri.Must(uri.Parse(args[1]))
+		if commitURI.Repository != branchURI.Repository {
+			Die("both references must belong to the same repository", 1)
+		}
+
+		client := getClient()
+		reference, result, err := client.CherryPick(context.Background(), branchURI.Repository, branchURI.Ref, commitURI.Ref)
+		if errors.Is(err, catalog.ErrConflictFound) {
+			_, _ = os.Stdout.WriteString("Conflicts:\n")
+			for _, line := range result {
+				if line.Type == models.DiffTypeConflict {
+					FmtMerge(line)
+				}
+			}
+			return
+		}
+		if err != nil {
+			DieErr(err)
+		}
+		var added, changed, removed int
+		for _, r := range result {
+			switch r.Type {
+			case models.DiffTypeAdded:
+				added++
+			case models.DiffTypeChanged:
+				changed++
+			case models.DiffTypeRemoved:
+				removed++
+			}
+		}
+		_, _ = os.Stdout.WriteString(fmt.Sprintf("new: %d modified: %d removed: %d\n", added, changed, removed))
+		Fmt("committed '%s'\n", reference)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cherryPickCmd)
+}
@@ -4,12 +4,21 @@ import (
 	"context"
 	"os"
 
+	"github.com/go-openapi/swag"
 	"github.com/jedib0t/go-pretty/text"
 	"github.com/spf13/cobra"
 	"github.com/treeverse/lakefs/api/gen/models"
 	"github.com/treeverse/lakefs/uri"
 )
 
+const diffDetailsTemplate = `{{ with .Left }}    left:  {{ .SizeBytes|human_bytes }} {{ .Checksum }} {{ .PhysicalAddress }} {{ .Mtime|date }}{{ range $key, $value := .Metadata }} {{ $key }}={{ $value }}{{ end }}
+{{ end }}{{ with .Right }}    right: {{ .SizeBytes|human_bytes }} {{ .Checksum }} {{ .PhysicalAddress }} {{ .Mtime|date }}{{ range $key, $value := .Metadata }} {{ $key }}={{ $value }}{{ end }}
+{{ end }}`
+
+const diffSummaryTemplate = `
+{{ .Files }} files, {{ .BytesAdded|human_bytes|green }} added, {{ .BytesRemoved|human_bytes|red }} removed
+`
+
 var diffCmd = &cobra.Command{
 	Use:   "diff <ref uri> [other ref uri]",
 	Short: "diff between commits/hashes",
@@ -19,11 +28,16 @@ var diffCmd = &cobra.Command{
 		IsRefURI(0),
 	),
 	Run: func(cmd *cobra.Command, args []string) {
+		amount, _ := cmd.Flags().GetInt("amount")
+		after, _ := cmd.Flags().GetString("after")
+		prefix, _ := cmd.Flags().GetString("prefix")
+		types, _ := cmd.Flags().GetStringSlice("type")
+		details, _ := cmd.Flags().GetBool("details")
 		client := getClient()
 
-		var diff []*models.Diff
-		var err error
-		if len(args) == 2 {
+		var diffPage func(after string) ([]*models.Diff, *models.DiffSummary, *models.Pagination, error)
+		withDirection := len(args) == 2
+		if withDirection {
 			if err := IsRefURI(1)(args); err != nil {
 				DieErr(err)
 			}
@@ -33,23 +47,50 @@ var diffCmd = &cobra.Command{
 			if leftRefURI.Repository != rightRefURI.Repository {
 				Die("both references must belong to the same repository", 1)
 			}
-
-			diff, err = client.DiffRefs(context.Background(), leftRefURI.Repository, leftRefURI.Ref, rightRefURI.Ref)
-			if err != nil {
-				DieErr(err)
-			}
-			for _, line := range diff {
-				FmtDiff(line, true)
+			diffPage = func(after string) ([]*models.Diff, *models.DiffSummary, *models.Pagination, error) {
+				return client.DiffRefs(context.Background(), leftRefURI.Repository, leftRefURI.Ref, rightRefURI.Ref, prefix, after, amount, types)
 			}
 		} else {
 			branchURI := uri.Must(uri.Parse(args[0]))
-			diff, err = client.DiffBranch(context.Background(), branchURI.Repository, branchURI.Ref)
+			diffPage = func(after string) ([]*models.Diff, *models.DiffSummary, *models.Pagination, error) {
+				return client.DiffBranch(context.Background(), branchURI.Repository, branchURI.Ref, prefix, after, amount, types)
+			}
+		}
+
+		// walk all pages unless a specific amount was requested.  the summary is returned with the first page
+		var summary *models.DiffSummary
+		for {
+			diff, pageSummary, pagination, err := diffPage(after)
 			if err != nil {
 				DieErr(err)
 			}
+			if pageSummary != nil {
+				summary = pageSummary
+			}
 			for _, line := range diff {
-				FmtDiff(line, false)
+				FmtDiff(line, withDirection)
+				if details {
+					Write(diffDetailsTemplate, line)
+				}
+			}
+			if pagination == nil || !swag.BoolValue(pagination.HasMore) {
+				if summary != nil {
+					Write(diffSummaryTemplate, summary)
+				}
+				return
+			}
+			if amount >= 0 {
+				if summary != nil {
+					Write(diffSummaryTemplate, summary)
+				}
+				Write("{{.|paginate}}", &Pagination{
+					Amount:  amount,
+					HasNext: true,
+					After:   pagination.NextOffset,
+				})
+				return
 			}
+			after = pagination.NextOffset
 		}
 	},
 }
@@ -88,4 +129,9 @@ func FmtDiff(diff *models.Diff, withDirection bool) {
 
 func init() {
 	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().Int("amount", -1, "how many results to return, or-1 for all results (used for pagination)")
+	diffCmd.Flags().String("after", "", "show results after this value (used for pagination)")
+	diffCmd.Flags().String("prefix", "", "show only changes of paths with this prefix")
+	diffCmd.Flags().StringSlice("type", nil, "show only changes of these types (added, removed, changed, conflict)")
+	diffCmd.Flags().Bool("details", false, "show size, checksum, physical address, modification time and metadata of both sides of each change")
 }
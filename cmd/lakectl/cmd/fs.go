@@ -6,7 +6,9 @@ import (
 	"os"
 	"strings"
 
+	"github.com/go-openapi/swag"
 	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/api/gen/models"
 	"github.com/treeverse/lakefs/uri"
 )
 
@@ -15,6 +17,12 @@ Modified Time: {{.Mtime|date}}
 Size: {{ .SizeBytes }} bytes
 Human Size: {{ .SizeBytes|human_bytes }}
 Checksum: {{.Checksum}}
+{{- range $key, $value := .Metadata }}
+{{ $key }}: {{ $value }}
+{{- end }}
+{{- range $key, $value := .Tags }}
+Tag: {{ $key }}={{ $value }}
+{{- end }}
 `
 
 var fsStatCmd = &cobra.Command{
@@ -62,6 +70,52 @@ var fsListCmd = &cobra.Command{
 	},
 }
 
+const fsHistoryTemplate = `{{ range $val := .Versions }}
+ID: {{ $val.CommitID|yellow }}
+Author: {{ $val.Committer }}
+Date: {{ $val.CommitDate|date }}
+Checksum: {{ $val.Checksum }}
+Size: {{ $val.SizeBytes|human_bytes }}
+Physical Address: {{ $val.PhysicalAddress }}
+
+	{{ $val.Message }}
+{{ end }}
+{{.Pagination | paginate }}
+`
+
+var fsHistoryCmd = &cobra.Command{
+	Use:   "history <path uri>",
+	Short: "show the commits that wrote each version of an object, newest first",
+	Args: ValidationChain(
+		HasNArgs(1),
+		IsPathURI(0),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		amount, _ := cmd.Flags().GetInt("amount")
+		after, _ := cmd.Flags().GetString("after")
+		pathURI := uri.Must(uri.Parse(args[0]))
+		client := getClient()
+		versions, pagination, err := client.GetObjectHistory(context.Background(), pathURI.Repository, pathURI.Ref, pathURI.Path, after, amount)
+		if err != nil {
+			DieErr(err)
+		}
+		ctx := struct {
+			Versions   []*models.ObjectVersion
+			Pagination *Pagination
+		}{
+			Versions: versions,
+		}
+		if pagination != nil && swag.BoolValue(pagination.HasMore) {
+			ctx.Pagination = &Pagination{
+				Amount:  amount,
+				HasNext: true,
+				After:   pagination.NextOffset,
+			}
+		}
+		Write(fsHistoryTemplate, ctx)
+	},
+}
+
 var fsCatCmd = &cobra.Command{
 	Use:   "cat <path uri>",
 	Short: "dump content of object to stdout",
@@ -131,6 +185,34 @@ var fsRmCmd = &cobra.Command{
 	},
 }
 
+var fsMvCmd = &cobra.Command{
+	Use:   "mv <source path uri> <destination path uri>",
+	Short: "move an object, or all objects under a prefix, to a new path on the same branch without copying data",
+	Args: ValidationChain(
+		HasNArgs(2),
+		IsPathURI(0),
+		IsPathURI(1),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		recursive, _ := cmd.Flags().GetBool("recursive")
+		sourceURI := uri.Must(uri.Parse(args[0]))
+		destinationURI := uri.Must(uri.Parse(args[1]))
+		if sourceURI.Repository != destinationURI.Repository || sourceURI.Ref != destinationURI.Ref {
+			Die("source and destination must be on the same branch", 1)
+		}
+		client := getClient()
+		moved, err := client.MoveObjects(context.Background(), sourceURI.Repository, sourceURI.Ref, &models.ObjectMove{
+			Source:      swag.String(sourceURI.Path),
+			Destination: swag.String(destinationURI.Path),
+			Prefix:      recursive,
+		})
+		if err != nil {
+			DieErr(err)
+		}
+		Fmt("moved %d objects\n", moved)
+	},
+}
+
 // fsCmd represents the fs command
 var fsCmd = &cobra.Command{
 	Use:   "fs",
@@ -144,7 +226,14 @@ func init() {
 	fsCmd.AddCommand(fsCatCmd)
 	fsCmd.AddCommand(fsUploadCmd)
 	fsCmd.AddCommand(fsRmCmd)
+	fsCmd.AddCommand(fsHistoryCmd)
+	fsCmd.AddCommand(fsMvCmd)
 
 	fsUploadCmd.Flags().StringP("source", "s", "", "local file to upload, or \"-\" for stdin")
 	_ = fsUploadCmd.MarkFlagRequired("source")
+
+	fsMvCmd.Flags().BoolP("recursive", "r", false, "move all objects with a path starting with the source path")
+
+	fsHistoryCmd.Flags().Int("amount", -1, "how many results to return, or-1 for all results (used for pagination)")
+	fsHistoryCmd.Flags().String("after", "", "show results after this commit ID (used for pagination)")
 }
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/swag"
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/api/gen/models"
+	"github.com/treeverse/lakefs/uri"
+)
+
+// hookCmd represents the hook command
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "create and manage repository hooks",
+	Long: `Create delete and list hooks within a lakeFS repository.
+A hook is an HTTP endpoint called before a commit or a merge, with the branch, the commit metadata and the diff.
+The operation is aborted when the hook responds with a non 2xx status code or doesn't respond in time.`,
+}
+
+var hookListTemplate = `{{.HookTable | table -}}
+`
+
+var hookListCmd = &cobra.Command{
+	Use:     "list <repository uri>",
+	Short:   "list hooks in a repository",
+	Example: "lakectl hook list lakefs://<repository>",
+	Args: ValidationChain(
+		HasNArgs(1),
+		IsRepoURI(0),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		u := uri.Must(uri.Parse(args[0]))
+		client := getClient()
+		hooks, err := client.ListHooks(context.Background(), u.Repository)
+		if err != nil {
+			DieErr(err)
+		}
+
+		rows := make([][]interface{}, len(hooks))
+		for i, hook := range hooks {
+			timeout := time.Duration(hook.Timeout) * time.Second
+			ts := time.Unix(hook.CreationDate, 0).String()
+			rows[i] = []interface{}{hook.Name, hook.Type, hook.URL, timeout.String(), ts}
+		}
+		Write(hookListTemplate, struct {
+			HookTable *Table
+		}{
+			HookTable: &Table{
+				Headers: []interface{}{"Name", "Type", "URL", "Timeout", "Creation Date"},
+				Rows:    rows,
+			},
+		})
+	},
+}
+
+var hookCreateCmd = &cobra.Command{
+	Use:     "create <repository uri> <hook name>",
+	Short:   "create a hook called before commit or merge",
+	Example: "lakectl hook create lakefs://<repository> schema-check --type pre-merge --url http://checks:8080/schema",
+	Args: ValidationChain(
+		HasNArgs(2),
+		IsRepoURI(0),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		hookType, _ := cmd.Flags().GetString("type")
+		hookURL, _ := cmd.Flags().GetString("url")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		u := uri.Must(uri.Parse(args[0]))
+		client := getClient()
+		hook, err := client.CreateHook(context.Background(), u.Repository, &models.HookCreation{
+			Name:    swag.String(args[1]),
+			Type:    swag.String(hookType),
+			URL:     swag.String(hookURL),
+			Timeout: int64(timeout / time.Second),
+		})
+		if err != nil {
+			DieErr(err)
+		}
+		Fmt("created %s hook '%s'\n", hook.Type, hook.Name)
+	},
+}
+
+var hookDeleteCmd = &cobra.Command{
+	Use:   "delete <repository uri> <hook name>",
+	Short: "delete a hook in a repository",
+	Args: ValidationChain(
+		HasNArgs(2),
+		IsRepoURI(0),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		confirmation, err := confirm(cmd.Flags(), "Are you sure you want to delete hook")
+		if err != nil || !confirmation {
+			Die("Delete hook aborted", 1)
+		}
+		u := uri.Must(uri.Parse(args[0]))
+		client := getClient()
+		err = client.DeleteHook(context.Background(), u.Repository, args[1])
+		if err != nil {
+			DieErr(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(hookCmd)
+	hookCmd.AddCommand(hookCreateCmd)
+	hookCmd.AddCommand(hookDeleteCmd)
+	hookCmd.AddCommand(hookListCmd)
+
+	hookCreateCmd.Flags().String("type", "", "hook type: pre-commit or pre-merge")
+	hookCreateCmd.Flags().String("url", "", "http(s) endpoint to call")
+	hookCreateCmd.Flags().Duration("timeout", 0, "time to wait for the hook response (default 30s)")
+	_ = hookCreateCmd.MarkFlagRequired("type")
+	_ = hookCreateCmd.MarkFlagRequired("url")
+}
@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/go-openapi/swag"
 	"github.com/spf13/cobra"
 	"github.com/treeverse/lakefs/api/gen/models"
+	"github.com/treeverse/lakefs/catalog"
 	"github.com/treeverse/lakefs/uri"
 )
 
@@ -43,9 +46,13 @@ var logCmd = &cobra.Command{
 		if err != nil {
 			DieErr(err)
 		}
+		filter, err := getLogFilter(cmd)
+		if err != nil {
+			DieErr(err)
+		}
 		client := getClient()
 		branchURI := uri.Must(uri.Parse(args[0]))
-		commits, pagination, err := client.GetCommitLog(context.Background(), branchURI.Repository, branchURI.Ref, after, amount)
+		commits, pagination, err := client.GetCommitLog(context.Background(), branchURI.Repository, branchURI.Ref, after, amount, filter)
 		ctx := struct {
 			Commits    []*models.Commit
 			Pagination *Pagination
@@ -66,8 +73,44 @@ var logCmd = &cobra.Command{
 	},
 }
 
+func getLogFilter(cmd *cobra.Command) (catalog.ListCommitsParams, error) {
+	var filter catalog.ListCommitsParams
+	var err error
+	filter.Prefix, _ = cmd.Flags().GetString("prefix")
+	filter.Committer, _ = cmd.Flags().GetString("committer")
+	since, _ := cmd.Flags().GetString("since")
+	if filter.Since, err = parseLogTime(since); err != nil {
+		return filter, fmt.Errorf("since: %w", err)
+	}
+	until, _ := cmd.Flags().GetString("until")
+	if filter.Until, err = parseLogTime(until); err != nil {
+		return filter, fmt.Errorf("until: %w", err)
+	}
+	filter.Metadata, err = getKV(cmd, "meta")
+	if err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// parseLogTime parses a RFC3339 timestamp or a date, an empty value returns the zero time
+func parseLogTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
+
 func init() {
 	rootCmd.AddCommand(logCmd)
 	logCmd.Flags().Int("amount", -1, "how many results to return, or-1 for all results (used for pagination)")
 	logCmd.Flags().String("after", "", "show results after this value (used for pagination)")
+	logCmd.Flags().String("prefix", "", "show only commits that changed paths with this prefix")
+	logCmd.Flags().String("committer", "", "show only commits made by this committer")
+	logCmd.Flags().String("since", "", "show only commits created at or after this time (RFC3339 or YYYY-MM-DD)")
+	logCmd.Flags().String("until", "", "show only commits created at or before this time (RFC3339 or YYYY-MM-DD)")
+	logCmd.Flags().StringSlice("meta", []string{}, "show only commits with this metadata key value pair in the form of key=value")
 }
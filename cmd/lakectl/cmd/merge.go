@@ -36,19 +36,28 @@ var mergeCmd = &cobra.Command{
 			Die("both references must belong to the same repository", 1)
 		}
 
-		result, err := client.Merge(context.Background(), leftRefURI.Repository, leftRefURI.Ref, rightRefURI.Ref)
+		strategy, _ := cmd.Flags().GetString("strategy")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		result, conflicts, err := client.Merge(context.Background(), leftRefURI.Repository, leftRefURI.Ref, rightRefURI.Ref, &models.Merge{
+			Strategy: strategy,
+			DryRun:   dryRun,
+		})
 		if errors.Is(err, catalog.ErrConflictFound) {
 			_, _ = os.Stdout.WriteString("Conflicts:\n")
-			for _, line := range result {
-				if line.Type == models.DiffTypeConflict {
-					FmtMerge(line)
-				}
+			for _, conflict := range conflicts {
+				FmtMergeConflict(conflict)
 			}
 			return
 		}
 		if err != nil {
 			DieErr(err)
 		}
+		if dryRun {
+			_, _ = os.Stdout.WriteString("Dry run, merge was not performed:\n")
+			for _, line := range result {
+				FmtMerge(line)
+			}
+		}
 		var added, changed, removed int
 		for _, r := range result {
 			switch r.Type {
@@ -89,16 +98,24 @@ func FmtMerge(diff *models.MergeResult) {
 	_, _ = os.Stdout.WriteString(color.Sprintf("    %s %s\n", action, diff.Path))
 }
 
-func init() {
-	rootCmd.AddCommand(mergeCmd)
+func FmtMergeConflict(conflict *models.MergeConflict) {
+	_, _ = os.Stdout.WriteString(text.FgHiYellow.Sprintf("    * conflict %s\n", conflict.Path))
+	fmtMergeConflictEntry("source", conflict.Source)
+	fmtMergeConflictEntry("destination", conflict.Destination)
+}
 
-	// Here you will define your flags and configuration settings.
+func fmtMergeConflictEntry(side string, entry *models.MergeConflictEntry) {
+	if entry == nil {
+		_, _ = os.Stdout.WriteString(fmt.Sprintf("        %-12s deleted\n", side+":"))
+		return
+	}
+	_, _ = os.Stdout.WriteString(fmt.Sprintf("        %-12s checksum: %s size: %d physical address: %s\n",
+		side+":", entry.Checksum, entry.SizeBytes, entry.PhysicalAddress))
+}
 
-	// Cobra supports Persistent Flags which will work for this command
-	// and all subcommands, e.g.:
-	// mergeCmd.PersistentFlags().String("foo", "", "A help for foo")
+func init() {
+	rootCmd.AddCommand(mergeCmd)
 
-	// Cobra supports local flags which will only run when this command
-	// is called directly, e.g.:
-	// mergeCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	mergeCmd.Flags().String("strategy", "fail", "conflict resolution strategy: source-wins, dest-wins or fail")
+	mergeCmd.Flags().Bool("dry-run", false, "show the merge results without performing the merge")
 }
@@ -103,6 +103,54 @@ var repoCreateCmd = &cobra.Command{
 	},
 }
 
+var repoUpdateTemplate = `Repository '{{.ID}}' updated:
+default branch: {{.DefaultBranch}}
+description: {{.Description}}
+{{- range $key, $value := .Metadata }}
+metadata: {{ $key }}={{ $value }}
+{{- end }}
+`
+
+// repoUpdateCmd represents the update repo command
+// lakectl repo update lakefs://myrepo --default-branch main --description "sales data" --meta owner=data-team
+var repoUpdateCmd = &cobra.Command{
+	Use:   "update <repository uri>",
+	Short: "update repository default branch, description or metadata",
+	Args: ValidationChain(
+		HasNArgs(1),
+		IsRepoURI(0),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		clt := getClient()
+		u := uri.Must(uri.Parse(args[0]))
+		defaultBranch, err := cmd.Flags().GetString("default-branch")
+		if err != nil {
+			DieErr(err)
+		}
+		update := &models.RepositoryUpdate{
+			DefaultBranch: defaultBranch,
+		}
+		if cmd.Flags().Changed("description") {
+			description, err := cmd.Flags().GetString("description")
+			if err != nil {
+				DieErr(err)
+			}
+			update.Description = swag.String(description)
+		}
+		if cmd.Flags().Changed("meta") {
+			update.Metadata, err = getKV(cmd, "meta")
+			if err != nil {
+				DieErr(err)
+			}
+		}
+		repo, err := clt.UpdateRepository(context.Background(), u.Repository, update)
+		if err != nil {
+			DieErr(err)
+		}
+		Write(repoUpdateTemplate, repo)
+	},
+}
+
 // repoDeleteCmd represents the delete repo command
 // lakectl delete lakefs://myrepo
 var repoDeleteCmd = &cobra.Command{
@@ -184,6 +232,7 @@ func init() {
 	rootCmd.AddCommand(repoCmd)
 	repoCmd.AddCommand(repoListCmd)
 	repoCmd.AddCommand(repoCreateCmd)
+	repoCmd.AddCommand(repoUpdateCmd)
 	repoCmd.AddCommand(repoDeleteCmd)
 	repoCmd.AddCommand(retentionCmd)
 
@@ -192,4 +241,8 @@ func init() {
 
 	repoCreateCmd.Flags().StringP("default-branch", "d", DefaultBranch, "the default branch of this repository")
 
+	repoUpdateCmd.Flags().StringP("default-branch", "d", "", "an existing branch to set as the default branch of this repository")
+	repoUpdateCmd.Flags().String("description", "", "the description of this repository")
+	repoUpdateCmd.Flags().StringSlice("meta", []string{}, "key value pair in the form of key=value, replaces the repository metadata")
+
 }
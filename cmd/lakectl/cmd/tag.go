@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/swag"
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/api/gen/models"
+	"github.com/treeverse/lakefs/uri"
+)
+
+// tagCmd represents the tag command
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "create and manage tags within a repository",
+	Long:  `Create delete and list tags within a lakeFS repository`,
+}
+
+var tagListTemplate = `{{.TagTable | table -}}
+{{.Pagination | paginate }}
+`
+
+var tagListCmd = &cobra.Command{
+	Use:     "list <repository uri>",
+	Short:   "list tags in a repository",
+	Example: "lakectl tag list lakefs://<repository>",
+	Args: ValidationChain(
+		HasNArgs(1),
+		IsRepoURI(0),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		amount, _ := cmd.Flags().GetInt("amount")
+		after, _ := cmd.Flags().GetString("after")
+
+		u := uri.Must(uri.Parse(args[0]))
+		client := getClient()
+		response, pagination, err := client.ListTags(context.Background(), u.Repository, after, amount)
+		if err != nil {
+			DieErr(err)
+		}
+
+		rows := make([][]interface{}, len(response))
+		for i, tag := range response {
+			ts := time.Unix(tag.CreationDate, 0).String()
+			rows[i] = []interface{}{tag.ID, tag.CommitID, ts}
+		}
+
+		ctx := struct {
+			TagTable   *Table
+			Pagination *Pagination
+		}{
+			TagTable: &Table{
+				Headers: []interface{}{"Tag", "Commit Reference", "Creation Date"},
+				Rows:    rows,
+			},
+		}
+		if pagination != nil && swag.BoolValue(pagination.HasMore) {
+			ctx.Pagination = &Pagination{
+				Amount:  amount,
+				HasNext: true,
+				After:   pagination.NextOffset,
+			}
+		}
+
+		Write(tagListTemplate, ctx)
+	},
+}
+
+var tagCreateCmd = &cobra.Command{
+	Use:     "create <tag uri> <ref uri>",
+	Short:   "create a new tag in a repository, pointing to a commit",
+	Example: "lakectl tag create lakefs://<repository>@<tag> lakefs://<repository>@<commit or branch>",
+	Args: ValidationChain(
+		HasNArgs(2),
+		IsRefURI(0),
+		IsRefURI(1),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		u := uri.Must(uri.Parse(args[0]))
+		refURI := uri.Must(uri.Parse(args[1]))
+		if refURI.Repository != u.Repository {
+			Die("tag reference must be in the same repository", 1)
+		}
+
+		client := getClient()
+		tag, err := client.CreateTag(context.Background(), u.Repository, &models.TagCreation{
+			ID:  swag.String(u.Ref),
+			Ref: swag.String(refURI.Ref),
+		})
+		if err != nil {
+			DieErr(err)
+		}
+
+		Fmt("created tag '%s' on '%s'\n", tag.ID, tag.CommitID)
+	},
+}
+
+var tagDeleteCmd = &cobra.Command{
+	Use:   "delete <tag uri>",
+	Short: "delete a tag in a repository",
+	Args: ValidationChain(
+		HasNArgs(1),
+		IsRefURI(0),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		confirmation, err := confirm(cmd.Flags(), "Are you sure you want to delete tag")
+		if err != nil || !confirmation {
+			Die("Delete tag aborted", 1)
+		}
+		client := getClient()
+		u := uri.Must(uri.Parse(args[0]))
+		err = client.DeleteTag(context.Background(), u.Repository, u.Ref)
+		if err != nil {
+			DieErr(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tagCmd)
+	tagCmd.AddCommand(tagCreateCmd)
+	tagCmd.AddCommand(tagDeleteCmd)
+	tagCmd.AddCommand(tagListCmd)
+
+	tagListCmd.Flags().Int("amount", -1, "how many results to return, or-1 for all results (used for pagination)")
+	tagListCmd.Flags().String("after", "", "show results after this value (used for pagination)")
+}
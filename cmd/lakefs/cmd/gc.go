@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/gc"
+	"github.com/treeverse/lakefs/logging"
+)
+
+// gcCmd implements the gc command
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove objects no longer referenced by any branch, tag, recent commit or active multipart upload",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		logger := logging.FromContext(ctx)
+		commitWindow, _ := cmd.Flags().GetDuration("commit-window")
+		minAge, _ := cmd.Flags().GetDuration("min-age")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		storageNamespaces, _ := cmd.Flags().GetStringSlice("storage-namespace")
+
+		adapter, err := cfg.BuildBlockAdapter()
+		if err != nil {
+			logger.WithError(err).Fatal("Failed to create block adapter")
+		}
+		dbPool := cfg.BuildDatabaseConnection()
+		defer func() {
+			_ = dbPool.Close()
+		}()
+		cataloger := catalog.NewCataloger(dbPool)
+		defer func() {
+			_ = cataloger.Close()
+		}()
+
+		report, err := gc.NewCollector(cataloger, adapter).Run(ctx, gc.Params{
+			CommitWindow:      commitWindow,
+			MinAge:            minAge,
+			DryRun:            dryRun,
+			StorageNamespaces: storageNamespaces,
+		})
+		if err != nil {
+			logger.WithError(err).Fatal("Garbage collection failed")
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "STORAGE NAMESPACE\tIDENTIFIER\tSIZE\tLAST MODIFIED\tERROR")
+		for _, obj := range report.Objects {
+			errMsg := ""
+			if obj.Err != nil {
+				errMsg = obj.Err.Error()
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", obj.StorageNamespace, obj.Identifier, obj.Size,
+				obj.LastModified.Format(time.RFC3339), errMsg)
+		}
+		_ = w.Flush()
+		if dryRun {
+			fmt.Printf("\n%d objects (%d bytes) would be removed\n", len(report.Objects), report.Size)
+			return
+		}
+		fmt.Printf("\n%d objects (%d bytes) found, %d removed, %d kept, %d failed\n",
+			len(report.Objects), report.Size, report.Removed, report.Kept, report.Failed)
+		if report.Failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gcCmd)
+	gcCmd.Flags().Duration("commit-window", gc.DefaultCommitWindow, "keep the objects of commits created within this duration")
+	gcCmd.Flags().Duration("min-age", gc.DefaultMinAge, "keep objects modified within this duration, they may belong to uploads in progress")
+	gcCmd.Flags().Bool("dry-run", false, "report the objects to remove without removing them")
+	gcCmd.Flags().StringSlice("storage-namespace", nil, "additional storage namespace to collect, such as the storage namespace of a deleted repository")
+}
@@ -22,9 +22,11 @@ import (
 	"github.com/treeverse/lakefs/dedup"
 	"github.com/treeverse/lakefs/gateway"
 	"github.com/treeverse/lakefs/gateway/simulator"
+	"github.com/treeverse/lakefs/hooks"
 	"github.com/treeverse/lakefs/httputil"
 	"github.com/treeverse/lakefs/logging"
 	"github.com/treeverse/lakefs/retention"
+	"github.com/treeverse/lakefs/webhooks"
 )
 
 const (
@@ -58,7 +60,10 @@ var runCmd = &cobra.Command{
 		migrator := db.NewDatabaseMigrator(dbConnString)
 
 		// init catalog
-		cataloger := catalog.NewCataloger(dbPool)
+		webhooksEndpoints := cfg.GetWebhooksEndpoints()
+		cataloger := catalog.NewCataloger(dbPool,
+			catalog.WithHookRunner(hooks.NewHTTPRunner(nil)),
+			catalog.WithEvents(len(webhooksEndpoints) > 0))
 
 		// init block store
 		blockStore, err := cfg.BuildBlockAdapter()
@@ -117,6 +122,9 @@ var runCmd = &cobra.Command{
 
 		ctx, cancelFn := context.WithCancel(context.Background())
 		go stats.Run(ctx)
+		if len(webhooksEndpoints) > 0 {
+			go webhooks.NewDeliverer(dbPool, webhooksEndpoints).Run(ctx)
+		}
 		stats.CollectEvent("global", "run")
 
 		// stagger a bit and update metadata
@@ -25,6 +25,7 @@ import (
 	"github.com/treeverse/lakefs/db"
 	"github.com/treeverse/lakefs/logging"
 	"github.com/treeverse/lakefs/stats"
+	"github.com/treeverse/lakefs/webhooks"
 )
 
 const (
@@ -269,6 +270,14 @@ func (c *Config) GetStatsFlushInterval() time.Duration {
 	return viper.GetDuration("stats.flush_interval")
 }
 
+func (c *Config) GetWebhooksEndpoints() []webhooks.Endpoint {
+	var endpoints []webhooks.Endpoint
+	if err := viper.UnmarshalKey("webhooks.endpoints", &endpoints); err != nil {
+		panic(fmt.Errorf("webhooks.endpoints: %w", err))
+	}
+	return endpoints
+}
+
 func (c *Config) BuildStats(installationID string) *stats.BufferedCollector {
 	sender := stats.NewDummySender()
 	if c.GetStatsEnabled() && Version != UnreleasedVersion {
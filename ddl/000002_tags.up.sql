@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS catalog_tags (
+    repository_id integer NOT NULL,
+    name character varying(64) NOT NULL,
+    branch_id bigint NOT NULL,
+    commit_id bigint NOT NULL,
+    creation_date timestamp with time zone DEFAULT now() NOT NULL,
+
+    PRIMARY KEY (repository_id, name)
+);
+
+CREATE INDEX catalog_tags_branch_commit_index ON catalog_tags USING btree (branch_id, commit_id);
+
+ALTER TABLE ONLY catalog_tags
+    ADD CONSTRAINT catalog_tags_repository_id_fk FOREIGN KEY (repository_id) REFERENCES catalog_repositories(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY catalog_tags
+    ADD CONSTRAINT catalog_tags_commits_fk FOREIGN KEY (branch_id, commit_id) REFERENCES catalog_commits(branch_id, commit_id) ON DELETE CASCADE;
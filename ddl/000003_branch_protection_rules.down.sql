@@ -0,0 +1 @@
+DROP TABLE IF EXISTS catalog_branch_protection_rules;
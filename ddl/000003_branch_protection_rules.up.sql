@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS catalog_branch_protection_rules (
+    repository_id integer NOT NULL,
+    pattern character varying(64) NOT NULL,
+    creation_date timestamp with time zone DEFAULT now() NOT NULL,
+
+    PRIMARY KEY (repository_id, pattern)
+);
+
+ALTER TABLE ONLY catalog_branch_protection_rules
+    ADD CONSTRAINT catalog_branch_protection_rules_repository_id_fk FOREIGN KEY (repository_id) REFERENCES catalog_repositories(id) ON DELETE CASCADE;
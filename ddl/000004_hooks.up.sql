@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS catalog_hooks (
+    repository_id integer NOT NULL,
+    name character varying(64) NOT NULL,
+    hook_type character varying(32) NOT NULL,
+    url text NOT NULL,
+    timeout_ms bigint NOT NULL,
+    creation_date timestamp with time zone DEFAULT now() NOT NULL,
+
+    PRIMARY KEY (repository_id, name)
+);
+
+ALTER TABLE ONLY catalog_hooks
+    ADD CONSTRAINT catalog_hooks_repository_id_fk FOREIGN KEY (repository_id) REFERENCES catalog_repositories(id) ON DELETE CASCADE;
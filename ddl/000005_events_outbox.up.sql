@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS catalog_events_outbox (
+    id bigserial NOT NULL PRIMARY KEY,
+    event_type character varying(64) NOT NULL,
+    payload jsonb NOT NULL,
+    creation_date timestamp with time zone DEFAULT now() NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    next_attempt_date timestamp with time zone DEFAULT now() NOT NULL,
+    delivered_endpoints jsonb DEFAULT '[]'::jsonb NOT NULL,
+    last_error text,
+    delivery_date timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS catalog_events_outbox_pending_idx
+    ON catalog_events_outbox USING btree (next_attempt_date) WHERE delivery_date IS NULL;
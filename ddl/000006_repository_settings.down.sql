@@ -0,0 +1,3 @@
+ALTER TABLE catalog_repositories
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS metadata;
@@ -0,0 +1,3 @@
+ALTER TABLE catalog_repositories
+    ADD COLUMN IF NOT EXISTS description text DEFAULT '' NOT NULL,
+    ADD COLUMN IF NOT EXISTS metadata jsonb DEFAULT '{}'::jsonb NOT NULL;
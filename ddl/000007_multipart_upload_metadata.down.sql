@@ -0,0 +1,2 @@
+ALTER TABLE catalog_multipart_uploads
+    DROP COLUMN IF EXISTS metadata;
@@ -0,0 +1,2 @@
+ALTER TABLE catalog_multipart_uploads
+    ADD COLUMN IF NOT EXISTS metadata jsonb DEFAULT '{}'::jsonb NOT NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS catalog_multipart_uploads_branch_path_index;
+
+ALTER TABLE catalog_multipart_uploads
+    DROP COLUMN IF EXISTS branch_id;
@@ -0,0 +1,8 @@
+ALTER TABLE catalog_multipart_uploads
+    ADD COLUMN IF NOT EXISTS branch_id integer;
+
+ALTER TABLE ONLY catalog_multipart_uploads
+    ADD CONSTRAINT catalog_multipart_uploads_branch_id_fk FOREIGN KEY (branch_id) REFERENCES catalog_branches(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS catalog_multipart_uploads_branch_path_index
+    ON catalog_multipart_uploads USING btree (branch_id, path, upload_id);
@@ -129,6 +129,8 @@ const (
 	ErrBadRequest
 	ErrKeyTooLongError
 	ErrInvalidAPIVersion
+	ErrInvalidTag
+	ErrInvalidTaggingDirective
 	// Add new error codes here.
 
 	// SSE-S3 related API errors
@@ -641,6 +643,16 @@ var Codes = errorCodeMap{
 		Description:    "Your metadata headers exceed the maximum allowed metadata size.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrInvalidTag: {
+		Code:           "InvalidTag",
+		Description:    "The tag provided was not a valid tag.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidTaggingDirective: {
+		Code:           "InvalidArgument",
+		Description:    "Unknown tagging directive.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrInvalidEncryptionMethod: {
 		Code:           "InvalidRequest",
 		Description:    "The encryption method specified is not supported",
@@ -14,7 +14,16 @@ import (
 
 type DeleteObject struct{}
 
-func (controller *DeleteObject) RequiredPermissions(_ *http.Request, repoID, _, path string) ([]permissions.Permission, error) {
+func (controller *DeleteObject) RequiredPermissions(request *http.Request, repoID, _, path string) ([]permissions.Permission, error) {
+	if _, exists := request.URL.Query()[QueryParamTagging]; exists {
+		// deleting the object tags updates the object
+		return []permissions.Permission{
+			{
+				Action:   permissions.WriteObjectAction,
+				Resource: permissions.ObjectArn(repoID, path),
+			},
+		}, nil
+	}
 	return []permissions.Permission{
 		{
 			Action:   permissions.DeleteObjectAction,
@@ -47,12 +56,17 @@ func (controller *DeleteObject) Handle(o *PathOperation) {
 		return
 	}
 
+	if _, exists := query[QueryParamTagging]; exists {
+		controller.HandleDeleteTagging(o)
+		return
+	}
+
 	o.Incr("delete_object")
 	lg := o.Log().WithField("key", o.Path)
 	err := o.Cataloger.DeleteEntry(o.Context(), o.Repository.Name, o.Reference, o.Path)
 	if err != nil && !errors.Is(err, db.ErrNotFound) {
 		lg.WithError(err).Error("could not delete object")
-		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(catalogErrorCode(err, gatewayerrors.ErrInternalError)))
 		return
 	} else if errors.Is(err, db.ErrNotFound) {
 		lg.WithError(err).Debug("could not delete object, it doesn't exist")
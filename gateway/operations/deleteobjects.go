@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/treeverse/lakefs/auth"
+	"github.com/treeverse/lakefs/catalog"
 	"github.com/treeverse/lakefs/db"
 	gerrors "github.com/treeverse/lakefs/gateway/errors"
 	"github.com/treeverse/lakefs/gateway/path"
@@ -59,6 +60,14 @@ func (controller *DeleteObjects) Handle(o *RepoOperation) {
 
 		lg := o.Log().WithField("key", obj.Key)
 		err = o.Cataloger.DeleteEntry(o.Context(), o.Repository.Name, resolvedPath.Ref, resolvedPath.Path)
+		if errors.Is(err, catalog.ErrBranchProtected) {
+			errs = append(errs, serde.DeleteError{
+				Code:    "AccessDenied",
+				Key:     obj.Key,
+				Message: fmt.Sprintf("error deleting object: %s", err),
+			})
+			continue
+		}
 		if err != nil && !errors.Is(err, db.ErrNotFound) {
 			lg.WithError(err).Error("failed deleting object")
 			errs = append(errs, serde.DeleteError{
@@ -17,6 +17,12 @@ import (
 	"github.com/treeverse/lakefs/permissions"
 )
 
+const (
+	// QueryParamVersionID selects an object version - the reference of the commit that wrote it
+	QueryParamVersionID = "versionId"
+	VersionIDHeader     = "x-amz-version-id"
+)
+
 type GetObject struct{}
 
 func (controller *GetObject) RequiredPermissions(_ *http.Request, repoID, _, path string) ([]permissions.Permission, error) {
@@ -32,17 +38,23 @@ func (controller *GetObject) Handle(o *PathOperation) {
 	o.Incr("get_object")
 	query := o.Request.URL.Query()
 	if _, exists := query["versioning"]; exists {
-		o.EncodeResponse(serde.VersioningConfiguration{}, http.StatusOK)
+		o.EncodeXMLBytes([]byte(serde.VersioningResponse), http.StatusOK)
+		return
+	}
+
+	if _, exists := query[QueryParamTagging]; exists {
+		controller.HandleGetTagging(o)
 		return
 	}
 
-	if _, exists := query["tagging"]; exists {
-		o.EncodeResponse(serde.Tagging{}, http.StatusOK)
+	if _, exists := query[QueryParamUploadID]; exists {
+		controller.HandleListParts(o)
 		return
 	}
 
+	reference := o.versionReference()
 	beforeMeta := time.Now()
-	entry, err := o.Cataloger.GetEntry(o.Context(), o.Repository.Name, o.Reference, o.Path, catalog.GetEntryParams{})
+	entry, err := o.Cataloger.GetEntry(o.Context(), o.Repository.Name, reference, o.Path, catalog.GetEntryParams{})
 	metaTook := time.Since(beforeMeta)
 	o.Log().
 		WithField("took", metaTook).
@@ -62,9 +74,16 @@ func (controller *GetObject) Handle(o *PathOperation) {
 		return
 	}
 
+	if reference != o.Reference {
+		o.SetHeader(VersionIDHeader, reference)
+	}
 	o.SetHeader("Last-Modified", httputil.HeaderTimestamp(entry.CreationDate))
 	o.SetHeader("ETag", httputil.ETag(entry.Checksum))
 	o.SetHeader("Accept-Ranges", "bytes")
+	o.setMetadataHeaders(entry.Metadata)
+	if !o.checkReadConditions(entry) {
+		return
+	}
 	// TODO: the rest of https://docs.aws.amazon.com/en_pv/AmazonS3/latest/API/API_GetObject.html
 
 	// range query
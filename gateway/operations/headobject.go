@@ -25,7 +25,8 @@ func (controller *HeadObject) RequiredPermissions(request *http.Request, repoId,
 
 func (controller *HeadObject) Handle(o *PathOperation) {
 	o.Incr("stat_object")
-	entry, err := o.Cataloger.GetEntry(o.Context(), o.Repository.Name, o.Reference, o.Path, catalog.GetEntryParams{ReturnExpired: true})
+	reference := o.versionReference()
+	entry, err := o.Cataloger.GetEntry(o.Context(), o.Repository.Name, reference, o.Path, catalog.GetEntryParams{ReturnExpired: true})
 	if errors.Is(err, db.ErrNotFound) {
 		// TODO: create distinction between missing repo & missing key
 		o.Log().Debug("path not found")
@@ -37,10 +38,17 @@ func (controller *HeadObject) Handle(o *PathOperation) {
 		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
 		return
 	}
+	if reference != o.Reference {
+		o.SetHeader(VersionIDHeader, reference)
+	}
 	o.SetHeader("Accept-Ranges", "bytes")
 	o.SetHeader("Last-Modified", httputil.HeaderTimestamp(entry.CreationDate))
 	o.SetHeader("ETag", httputil.ETag(entry.Checksum))
 	o.SetHeader("Content-Length", fmt.Sprintf("%d", entry.Size))
+	o.setMetadataHeaders(entry.Metadata)
+	if !o.checkReadConditions(entry) {
+		return
+	}
 	if entry.Expired {
 		o.Log().WithError(err).Info("querying expired object")
 		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchVersion))
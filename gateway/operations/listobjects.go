@@ -88,6 +88,27 @@ func (controller *ListObjects) serializeBranches(branches []*catalog.Branch) ([]
 	return dirs, lastKey
 }
 
+// listBranchNames returns, in name order, the names of the branches starting with prefix, from the branch named from
+func listBranchNames(o *RepoOperation, prefix, from string) ([]string, error) {
+	var names []string
+	var after string
+	for {
+		branches, hasMore, err := o.Cataloger.ListBranches(o.Context(), o.Repository.Name, prefix, -1, after)
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range branches {
+			if branch.Name >= from {
+				names = append(names, branch.Name)
+			}
+			after = branch.Name
+		}
+		if !hasMore {
+			return names, nil
+		}
+	}
+}
+
 func (controller *ListObjects) ListV2(o *RepoOperation) {
 	o.AddLogFields(logging.Fields{
 		"list_type": "v2",
@@ -364,6 +385,122 @@ func (controller *ListObjects) ListV1(o *RepoOperation) {
 	o.EncodeResponse(resp, http.StatusOK)
 }
 
+// ListVersions lists the committed versions of the objects under a branch prefix, or of every branch starting with
+// the prefix when it does not name a branch, using the commit references as version ids
+// (https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListObjectVersions.html)
+func (controller *ListObjects) ListVersions(o *RepoOperation) {
+	o.Incr("list_object_versions")
+	params := o.Request.URL.Query()
+	if params.Get("delimiter") != "" {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNotImplemented))
+		return
+	}
+	prefix, err := path.ResolvePath(params.Get("prefix"))
+	if err != nil {
+		o.Log().WithError(err).WithField("prefix", params.Get("prefix")).Debug("invalid prefix")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBadRequest))
+		return
+	}
+	keyMarker := params.Get("key-marker")
+	versionIDMarker := params.Get("version-id-marker")
+	var marker path.ResolvedPath
+	if len(keyMarker) > 0 {
+		marker, err = path.ResolvePath(keyMarker)
+		if err != nil || !markerInPrefix(marker, prefix) {
+			o.Log().WithError(err).WithField("key_marker", keyMarker).Debug("invalid key marker - doesnt start with branch name")
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBadRequest))
+			return
+		}
+	}
+	maxKeys := controller.getMaxKeys(o)
+	resp := serde.ListVersionsResult{
+		Name:            o.Repository.Name,
+		Prefix:          params.Get("prefix"),
+		KeyMarker:       keyMarker,
+		VersionIdMarker: versionIDMarker,
+		MaxKeys:         maxKeys,
+		Version:         make([]serde.Version, 0),
+		DeleteMarker:    make([]serde.DeleteMarkerEntry, 0),
+	}
+	if maxKeys == 0 {
+		o.EncodeResponse(resp, http.StatusOK)
+		return
+	}
+	branches := []string{prefix.Ref}
+	if !prefix.WithPath {
+		branches, err = listBranchNames(o, prefix.Ref, marker.Ref)
+		if err != nil {
+			o.Log().WithError(err).Error("could not list branches")
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+			return
+		}
+	}
+	// the newest version of each key is the latest, unless the listing continues the versions of the marker
+	lastKey := ""
+	if versionIDMarker != "" {
+		lastKey = path.WithRef(marker.Path, marker.Ref)
+	}
+	listed := 0
+	for _, branch := range branches {
+		if listed == maxKeys {
+			resp.IsTruncated = true
+			break
+		}
+		var afterPath, afterReference string
+		if strings.EqualFold(branch, marker.Ref) {
+			afterPath, afterReference = marker.Path, versionIDMarker
+		}
+		versions, hasMore, err := o.Cataloger.ListEntriesVersions(o.Context(), o.Repository.Name, branch, prefix.Path,
+			afterPath, afterReference, maxKeys-listed)
+		if errors.Is(err, db.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			o.Log().WithError(err).WithFields(logging.Fields{
+				"ref":  branch,
+				"path": prefix.Path,
+			}).Error("could not list object versions")
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBadRequest))
+			return
+		}
+		for _, v := range versions {
+			key := path.WithRef(v.Path, branch)
+			isLatest := key != lastKey
+			lastKey = key
+			resp.NextKeyMarker = key
+			resp.NextVersionIdMarker = v.Reference
+			if v.DeleteMarker {
+				resp.DeleteMarker = append(resp.DeleteMarker, serde.DeleteMarkerEntry{
+					Key:          key,
+					VersionId:    v.Reference,
+					IsLatest:     isLatest,
+					LastModified: serde.Timestamp(v.CommitDate),
+				})
+				continue
+			}
+			resp.Version = append(resp.Version, serde.Version{
+				Key:          key,
+				VersionId:    v.Reference,
+				IsLatest:     isLatest,
+				LastModified: serde.Timestamp(v.CreationDate),
+				ETag:         httputil.ETag(v.Checksum),
+				Size:         v.Size,
+				StorageClass: "STANDARD",
+			})
+		}
+		listed += len(versions)
+		if hasMore {
+			resp.IsTruncated = true
+			break
+		}
+	}
+	if !resp.IsTruncated {
+		resp.NextKeyMarker = ""
+		resp.NextVersionIdMarker = ""
+	}
+	o.EncodeResponse(resp, http.StatusOK)
+}
+
 func (controller *ListObjects) Handle(o *RepoOperation) {
 	o.Incr("list_objects")
 	// parse request parameters
@@ -376,6 +513,18 @@ func (controller *ListObjects) Handle(o *RepoOperation) {
 		return
 	}
 
+	// handle GET /?versions
+	if _, found := query["versions"]; found {
+		controller.ListVersions(o)
+		return
+	}
+
+	// handle GET /?uploads
+	if _, found := query[QueryParamMultipartUploads]; found {
+		controller.ListMultipartUploads(o)
+		return
+	}
+
 	// handle ListObjects versions
 	listType := query.Get("list-type")
 	switch listType {
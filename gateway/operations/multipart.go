@@ -0,0 +1,220 @@
+package operations
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/db"
+	gatewayerrors "github.com/treeverse/lakefs/gateway/errors"
+	"github.com/treeverse/lakefs/gateway/path"
+	"github.com/treeverse/lakefs/gateway/serde"
+	"github.com/treeverse/lakefs/logging"
+)
+
+const (
+	ListPartsMaxParts            = 1000
+	ListMultipartUploadsMaxKeys  = 1000
+	QueryParamMultipartUploads   = "uploads"
+	QueryParamMaxParts           = "max-parts"
+	QueryParamPartNumberMarker   = "part-number-marker"
+	QueryParamMaxUploads         = "max-uploads"
+	QueryParamUploadIDMarker     = "upload-id-marker"
+	multipartUploadsStorageClass = "STANDARD"
+)
+
+// parseMaxQueryParam returns the value of a max-* query parameter, capped by the default limit.  ok is false when
+// the value is not a non-negative integer.
+func parseMaxQueryParam(value string, limit int) (max int, ok bool) {
+	if value == "" {
+		return limit, true
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	if n < limit {
+		return n, true
+	}
+	return limit, true
+}
+
+// HandleListParts lists the parts uploaded to a multipart upload (https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListParts.html)
+func (controller *GetObject) HandleListParts(o *PathOperation) {
+	o.Incr("list_parts")
+	query := o.Request.URL.Query()
+	uploadID := query.Get(QueryParamUploadID)
+	o.AddLogFields(logging.Fields{"upload_id": uploadID})
+	maxParts, ok := parseMaxQueryParam(query.Get(QueryParamMaxParts), ListPartsMaxParts)
+	if !ok {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidMaxParts))
+		return
+	}
+	var partNumberMarker int64
+	if marker := query.Get(QueryParamPartNumberMarker); marker != "" {
+		var err error
+		partNumberMarker, err = strconv.ParseInt(marker, 10, 64)
+		if err != nil || partNumberMarker < 0 {
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidPartNumberMarker))
+			return
+		}
+	}
+
+	multiPart, err := o.Cataloger.GetMultipartUpload(o.Context(), o.Repository.Name, uploadID)
+	// uploads created before they were kept per branch have no branch
+	if errors.Is(err, db.ErrNotFound) ||
+		(err == nil && (multiPart.Path != o.Path || (multiPart.Branch != "" && multiPart.Branch != o.Reference))) {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchUpload))
+		return
+	}
+	if err != nil {
+		o.Log().WithError(err).Error("could not read multipart record")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	parts, err := o.BlockStore.ListParts(block.ObjectPointer{
+		StorageNamespace: o.Repository.StorageNamespace,
+		Identifier:       multiPart.PhysicalAddress,
+	}, uploadID)
+	if err != nil {
+		o.Log().WithError(err).Error("could not list multipart upload parts")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+
+	resp := serde.ListPartsResult{
+		Bucket:           o.Repository.Name,
+		Key:              path.WithRef(o.Path, o.Reference),
+		UploadId:         uploadID,
+		StorageClass:     multipartUploadsStorageClass,
+		PartNumberMarker: partNumberMarker,
+		MaxParts:         maxParts,
+		Part:             make([]serde.Part, 0),
+	}
+	for _, part := range parts {
+		if part.PartNumber <= partNumberMarker {
+			continue
+		}
+		if len(resp.Part) == maxParts {
+			resp.IsTruncated = true
+			break
+		}
+		resp.Part = append(resp.Part, serde.Part{
+			PartNumber:   part.PartNumber,
+			LastModified: serde.Timestamp(part.LastModified),
+			ETag:         part.ETag,
+			Size:         part.Size,
+		})
+		resp.NextPartNumberMarker = part.PartNumber
+	}
+	o.EncodeResponse(resp, http.StatusOK)
+}
+
+// ListMultipartUploads lists the in-progress multipart uploads under a branch prefix, or of every branch starting
+// with the prefix when it does not name a branch
+// (https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListMultipartUploads.html)
+func (controller *ListObjects) ListMultipartUploads(o *RepoOperation) {
+	o.Incr("list_multipart_uploads")
+	params := o.Request.URL.Query()
+	if params.Get("delimiter") != "" {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNotImplemented))
+		return
+	}
+	prefix, err := path.ResolvePath(params.Get("prefix"))
+	if err != nil {
+		o.Log().WithError(err).WithField("prefix", params.Get("prefix")).Debug("invalid prefix")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBadRequest))
+		return
+	}
+	maxUploads, ok := parseMaxQueryParam(params.Get(QueryParamMaxUploads), ListMultipartUploadsMaxKeys)
+	if !ok {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidMaxUploads))
+		return
+	}
+	keyMarker := params.Get("key-marker")
+	uploadIDMarker := params.Get(QueryParamUploadIDMarker)
+	var marker path.ResolvedPath
+	if len(keyMarker) > 0 {
+		marker, err = path.ResolvePath(keyMarker)
+		if err != nil || !markerInPrefix(marker, prefix) {
+			o.Log().WithError(err).WithField("key_marker", keyMarker).Debug("invalid key marker - doesnt start with branch name")
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBadRequest))
+			return
+		}
+	}
+
+	resp := serde.ListMultipartUploadsResult{
+		Bucket:         o.Repository.Name,
+		KeyMarker:      keyMarker,
+		UploadIdMarker: uploadIDMarker,
+		Prefix:         params.Get("prefix"),
+		MaxUploads:     maxUploads,
+		Upload:         make([]serde.Upload, 0),
+	}
+	if maxUploads == 0 {
+		o.EncodeResponse(resp, http.StatusOK)
+		return
+	}
+	branches := []string{prefix.Ref}
+	if !prefix.WithPath {
+		branches, err = listBranchNames(o, prefix.Ref, marker.Ref)
+		if err != nil {
+			o.Log().WithError(err).Error("could not list branches")
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+			return
+		}
+	}
+	for _, branch := range branches {
+		if len(resp.Upload) == maxUploads {
+			resp.IsTruncated = true
+			break
+		}
+		var afterPath, afterUploadID string
+		if strings.EqualFold(branch, marker.Ref) {
+			afterPath, afterUploadID = marker.Path, uploadIDMarker
+		}
+		uploads, hasMore, err := o.Cataloger.ListMultipartUploads(o.Context(), o.Repository.Name, branch, prefix.Path,
+			afterPath, afterUploadID, maxUploads-len(resp.Upload))
+		if errors.Is(err, db.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			o.Log().WithError(err).WithFields(logging.Fields{
+				"ref":  branch,
+				"path": prefix.Path,
+			}).Error("could not list multipart uploads")
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBadRequest))
+			return
+		}
+		for _, u := range uploads {
+			resp.Upload = append(resp.Upload, serde.Upload{
+				Key:          path.WithRef(u.Path, branch),
+				UploadId:     u.UploadID,
+				StorageClass: multipartUploadsStorageClass,
+				Initiated:    serde.Timestamp(u.CreationDate),
+			})
+			resp.NextKeyMarker = path.WithRef(u.Path, branch)
+			resp.NextUploadIdMarker = u.UploadID
+		}
+		if hasMore {
+			resp.IsTruncated = true
+			break
+		}
+	}
+	if !resp.IsTruncated {
+		resp.NextKeyMarker = ""
+		resp.NextUploadIdMarker = ""
+	}
+	o.EncodeResponse(resp, http.StatusOK)
+}
+
+// markerInPrefix reports whether a key marker is in the listing of prefix: on the branch of a branch prefix, or on
+// a branch starting with a prefix that does not name a branch
+func markerInPrefix(marker, prefix path.ResolvedPath) bool {
+	if prefix.WithPath {
+		return strings.EqualFold(marker.Ref, prefix.Ref)
+	}
+	return strings.HasPrefix(marker.Ref, prefix.Ref)
+}
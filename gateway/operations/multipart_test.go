@@ -0,0 +1,30 @@
+package operations
+
+import "testing"
+
+func TestParseMaxQueryParam(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   int
+		wantOK bool
+	}{
+		{name: "missing", value: "", want: 1000, wantOK: true},
+		{name: "below limit", value: "10", want: 10, wantOK: true},
+		{name: "zero", value: "0", want: 0, wantOK: true},
+		{name: "above limit", value: "5000", want: 1000, wantOK: true},
+		{name: "negative", value: "-1", wantOK: false},
+		{name: "not a number", value: "ten", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseMaxQueryParam(tt.value, 1000)
+			if ok != tt.wantOK {
+				t.Fatalf("parseMaxQueryParam() ok = %t, want %t", ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Errorf("parseMaxQueryParam() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
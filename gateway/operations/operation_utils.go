@@ -1,31 +1,211 @@
 package operations
 
 import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/db"
+	gatewayerrors "github.com/treeverse/lakefs/gateway/errors"
+	"github.com/treeverse/lakefs/httputil"
 	"github.com/treeverse/lakefs/logging"
 )
 
-func (o *PathOperation) finishUpload(storageNamespace, checksum, physicalAddress string, size int64) error {
+const (
+	// UserMetadataHeaderPrefix is the prefix of the headers holding the object user metadata
+	UserMetadataHeaderPrefix = "x-amz-meta-"
+
+	// maxUserMetadataSize is the S3 limit on the size of the user metadata keys and values
+	maxUserMetadataSize = 2 * 1024
+)
+
+// objectMetadataHeaders are the standard headers stored as object metadata and returned on read
+var objectMetadataHeaders = []string{
+	"Content-Type",
+	"Content-Encoding",
+	"Content-Disposition",
+	"Cache-Control",
+}
+
+var (
+	errMetadataTooLarge          = errors.New("metadata too large")
+	errUnsupportedWriteCondition = errors.New("unsupported write condition")
+)
+
+// catalogErrorCode returns the S3 error code matching a cataloger error, or defaultCode when there is none
+func catalogErrorCode(err error, defaultCode gatewayerrors.APIErrorCode) gatewayerrors.APIErrorCode {
+	if errors.Is(err, catalog.ErrBranchProtected) {
+		return gatewayerrors.ErrAccessDenied
+	}
+	if errors.Is(err, catalog.ErrPreconditionFailed) {
+		return gatewayerrors.ErrPreconditionFailed
+	}
+	return defaultCode
+}
+
+// metadataFromHeader returns the object metadata of a request: the user metadata, keyed by its lower case
+// header name, and the standard object headers.  Fails with errMetadataTooLarge when the user metadata
+// exceeds the S3 limit.
+func metadataFromHeader(header http.Header) (catalog.Metadata, error) {
+	metadata := make(catalog.Metadata)
+	userMetadataSize := 0
+	for name, values := range header {
+		name = strings.ToLower(name)
+		if !strings.HasPrefix(name, UserMetadataHeaderPrefix) || len(values) == 0 {
+			continue
+		}
+		value := strings.Join(values, ",")
+		metadata[name] = value
+		userMetadataSize += len(name) - len(UserMetadataHeaderPrefix) + len(value)
+	}
+	if userMetadataSize > maxUserMetadataSize {
+		return nil, errMetadataTooLarge
+	}
+	for _, name := range objectMetadataHeaders {
+		if value := header.Get(name); value != "" {
+			metadata[name] = value
+		}
+	}
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	return metadata, nil
+}
+
+// metadataAndTagsFromHeader returns the object metadata of a request with the object tags of its x-amz-tagging
+// header.  On failure the error is encoded to the response and ok is false.
+func (o *PathOperation) metadataAndTagsFromHeader() (metadata catalog.Metadata, ok bool) {
+	metadata, err := metadataFromHeader(o.Request.Header)
+	if err != nil {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrMetadataTooLarge))
+		return nil, false
+	}
+	tags, err := tagsFromHeader(o.Request.Header)
+	if err != nil {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidTag))
+		return nil, false
+	}
+	return metadata.WithTags(tags), true
+}
+
+// setMetadataHeaders sets the response headers of the object metadata stored by metadataFromHeader, and the
+// number of object tags.  Other metadata, such as metadata set by the API, is not returned.
+func (o *PathOperation) setMetadataHeaders(metadata catalog.Metadata) {
+	for _, name := range objectMetadataHeaders {
+		if value, ok := metadata[name]; ok {
+			o.SetHeader(name, value)
+		}
+	}
+	for name, value := range metadata {
+		if strings.HasPrefix(name, UserMetadataHeaderPrefix) {
+			o.SetHeader(name, value)
+		}
+	}
+	if count := len(metadata.Tags()); count > 0 {
+		o.SetHeader(TaggingCountHeader, strconv.Itoa(count))
+	}
+}
+
+// writeConditionsFromHeader returns the preconditions of a conditional write: "If-None-Match: *" writes the
+// object only when it does not exist, and "If-Match: <etag>" only when it has that etag.  Like S3, other
+// forms of these headers fail with errUnsupportedWriteCondition.
+func writeConditionsFromHeader(header http.Header) (catalog.CreateEntryParams, error) {
+	var params catalog.CreateEntryParams
+	if ifNoneMatch := header.Get("If-None-Match"); ifNoneMatch != "" {
+		if strings.TrimSpace(ifNoneMatch) != "*" {
+			return params, errUnsupportedWriteCondition
+		}
+		params.IfAbsent = true
+	}
+	if ifMatch := header.Get("If-Match"); ifMatch != "" {
+		etag := strings.Trim(strings.TrimSpace(ifMatch), `"`)
+		if etag == "" || etag == "*" || strings.Contains(etag, ",") {
+			return params, errUnsupportedWriteCondition
+		}
+		params.IfChecksum = etag
+	}
+	return params, nil
+}
+
+// writeConditions returns the preconditions of a conditional write request.  On failure the error is encoded
+// to the response and ok is false.
+func (o *PathOperation) writeConditions() (params catalog.CreateEntryParams, ok bool) {
+	params, err := writeConditionsFromHeader(o.Request.Header)
+	if err != nil {
+		o.Log().WithError(err).Debug("unsupported write condition")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNotImplemented))
+		return params, false
+	}
+	return params, true
+}
+
+// checkWriteConditions evaluates the preconditions of a conditional write against the current entry, to fail
+// before the object data is uploaded.  The catalog checks them again when it writes the entry.  When a
+// precondition fails, the error is encoded to the response and false is returned.
+func (o *PathOperation) checkWriteConditions(params catalog.CreateEntryParams) bool {
+	if !params.IfAbsent && params.IfChecksum == "" {
+		return true
+	}
+	entry, err := o.Cataloger.GetEntry(o.Context(), o.Repository.Name, o.Reference, o.Path, catalog.GetEntryParams{})
+	if errors.Is(err, db.ErrNotFound) {
+		entry = nil
+	} else if err != nil && !errors.Is(err, catalog.ErrExpired) {
+		o.Log().WithError(err).Error("could not read object for write conditions")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return false
+	}
+	if (params.IfAbsent && entry != nil) || (params.IfChecksum != "" && (entry == nil || entry.Checksum != params.IfChecksum)) {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrPreconditionFailed))
+		return false
+	}
+	return true
+}
+
+// checkReadConditions evaluates the conditional headers of a read against the entry.  When the object should
+// not be served, a 304 Not Modified or 412 Precondition Failed response is written and false is returned.
+func (o *PathOperation) checkReadConditions(entry *catalog.Entry) bool {
+	switch httputil.ConditionsFromHeader(o.Request.Header).Evaluate(entry.Checksum, entry.CreationDate) {
+	case httputil.ConditionNotModified:
+		o.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return false
+	case httputil.ConditionFailed:
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrPreconditionFailed))
+		return false
+	}
+	return true
+}
+
+// versionReference returns the reference to read the object from: the requested version, or the operation
+// reference when no version is requested
+func (o *PathOperation) versionReference() string {
+	if versionID := o.Request.URL.Query().Get(QueryParamVersionID); versionID != "" {
+		return versionID
+	}
+	return o.Reference
+}
+
+// finishUpload writes the entry of an uploaded object.  params holds the preconditions of the write, its dedup
+// params are set by finishUpload.
+func (o *PathOperation) finishUpload(storageNamespace, checksum, physicalAddress string, size int64, metadata catalog.Metadata, params catalog.CreateEntryParams) error {
 	// write metadata
 	writeTime := time.Now()
 	entry := catalog.Entry{
 		Path:            o.Path,
 		PhysicalAddress: physicalAddress,
 		Checksum:        checksum,
-		Metadata:        nil, // TODO: Read whatever metadata came from the request headers/params and add here
+		Metadata:        metadata,
 		Size:            size,
 		CreationDate:    writeTime,
 	}
 
-	err := o.Cataloger.CreateEntry(o.Context(), o.Repository.Name, o.Reference, entry,
-		catalog.CreateEntryParams{
-			Dedup: catalog.DedupParams{
-				ID:               checksum,
-				StorageNamespace: storageNamespace,
-			},
-		})
+	params.Dedup = catalog.DedupParams{
+		ID:               checksum,
+		StorageNamespace: storageNamespace,
+	}
+	err := o.Cataloger.CreateEntry(o.Context(), o.Repository.Name, o.Reference, entry, params)
 	if err != nil {
 		o.Log().WithError(err).Error("could not update metadata")
 		return err
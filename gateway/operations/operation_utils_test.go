@@ -0,0 +1,102 @@
+package operations
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/treeverse/lakefs/catalog"
+)
+
+func TestMetadataFromHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  http.Header
+		want    catalog.Metadata
+		wantErr error
+	}{
+		{
+			name:   "none",
+			header: http.Header{"Authorization": []string{"AWS4-HMAC-SHA256"}},
+			want:   nil,
+		},
+		{
+			name: "user metadata and standard headers",
+			header: http.Header{
+				"X-Amz-Meta-Owner":    []string{"data-team"},
+				"Content-Type":        []string{"text/csv"},
+				"Content-Encoding":    []string{"gzip"},
+				"X-Amz-Storage-Class": []string{"STANDARD"},
+			},
+			want: catalog.Metadata{
+				"x-amz-meta-owner": "data-team",
+				"Content-Type":     "text/csv",
+				"Content-Encoding": "gzip",
+			},
+		},
+		{
+			name:    "too large",
+			header:  http.Header{"X-Amz-Meta-Data": []string{strings.Repeat("a", maxUserMetadataSize)}},
+			wantErr: errMetadataTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := metadataFromHeader(tt.header)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("metadataFromHeader() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("metadataFromHeader() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteConditionsFromHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  http.Header
+		want    catalog.CreateEntryParams
+		wantErr error
+	}{
+		{
+			name:   "none",
+			header: http.Header{},
+			want:   catalog.CreateEntryParams{},
+		},
+		{
+			name:   "if none match any",
+			header: http.Header{"If-None-Match": []string{"*"}},
+			want:   catalog.CreateEntryParams{IfAbsent: true},
+		},
+		{
+			name:   "if match etag",
+			header: http.Header{"If-Match": []string{`"d41d8cd98f00b204e9800998ecf8427e"`}},
+			want:   catalog.CreateEntryParams{IfChecksum: "d41d8cd98f00b204e9800998ecf8427e"},
+		},
+		{
+			name:    "if none match etag",
+			header:  http.Header{"If-None-Match": []string{`"d41d8cd98f00b204e9800998ecf8427e"`}},
+			wantErr: errUnsupportedWriteCondition,
+		},
+		{
+			name:    "if match list",
+			header:  http.Header{"If-Match": []string{`"abc", "def"`}},
+			wantErr: errUnsupportedWriteCondition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := writeConditionsFromHeader(tt.header)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("writeConditionsFromHeader() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("writeConditionsFromHeader() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -37,6 +37,10 @@ func (controller *PostObject) RequiredPermissions(_ *http.Request, repoId, _, pa
 func (controller *PostObject) HandleCreateMultipartUpload(o *PathOperation) {
 	//var err error
 	o.Incr("create_mpu")
+	metadata, ok := o.metadataAndTagsFromHeader()
+	if !ok {
+		return
+	}
 	uuidBytes := [16]byte(uuid.New())
 	objName := hex.EncodeToString(uuidBytes[:])
 	storageClass := StorageClassFromHeader(o.Request.Header)
@@ -47,7 +51,7 @@ func (controller *PostObject) HandleCreateMultipartUpload(o *PathOperation) {
 		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
 		return
 	}
-	err = o.Cataloger.CreateMultipartUpload(o.Context(), o.Repository.Name, uploadId, o.Path, objName, time.Now())
+	err = o.Cataloger.CreateMultipartUpload(o.Context(), o.Repository.Name, o.Reference, uploadId, o.Path, objName, time.Now(), metadata)
 	if err != nil {
 		o.Log().WithError(err).Error("could not write multipart upload to DB")
 		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
@@ -75,6 +79,10 @@ func (controller *PostObject) HandleCompleteMultipartUpload(o *PathOperation) {
 	o.Incr("complete_mpu")
 	uploadID := o.Request.URL.Query().Get(CompleteMultipartUploadQueryParam)
 	o.AddLogFields(logging.Fields{"upload_id": uploadID})
+	params, ok := o.writeConditions()
+	if !ok || !o.checkWriteConditions(params) {
+		return
+	}
 	multiPart, err := o.Cataloger.GetMultipartUpload(o.Context(), o.Repository.Name, uploadID)
 	if err != nil {
 		o.Log().WithError(err).Error("could not read multipart record")
@@ -104,9 +112,9 @@ func (controller *PostObject) HandleCompleteMultipartUpload(o *PathOperation) {
 	}
 	ch := trimQuotes(*etag)
 	checksum := strings.Split(ch, "-")[0]
-	err = o.finishUpload(o.Repository.StorageNamespace, checksum, objName, size)
+	err = o.finishUpload(o.Repository.StorageNamespace, checksum, objName, size, multiPart.Metadata, params)
 	if err != nil {
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+		o.EncodeError(errors.Codes.ToAPIErr(catalogErrorCode(err, errors.ErrInternalError)))
 		return
 	}
 	err = o.Cataloger.DeleteMultipartUpload(o.Context(), o.Repository.Name, uploadID)
@@ -1,7 +1,8 @@
 package operations
 
 import (
-	"fmt"
+	"errors"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -10,7 +11,9 @@ import (
 
 	"github.com/treeverse/lakefs/block"
 	"github.com/treeverse/lakefs/catalog"
-	"github.com/treeverse/lakefs/gateway/errors"
+	"github.com/treeverse/lakefs/db"
+	gatewayerrors "github.com/treeverse/lakefs/gateway/errors"
+	ghttp "github.com/treeverse/lakefs/gateway/http"
 	"github.com/treeverse/lakefs/gateway/path"
 	"github.com/treeverse/lakefs/gateway/serde"
 	"github.com/treeverse/lakefs/httputil"
@@ -20,98 +23,328 @@ import (
 )
 
 const (
-	CopySourceHeader     = "x-amz-copy-source"
+	CopySourceHeader                  = "x-amz-copy-source"
+	CopySourceRangeHeader             = "x-amz-copy-source-range"
+	CopySourceIfMatchHeader           = "x-amz-copy-source-if-match"
+	CopySourceIfNoneMatchHeader       = "x-amz-copy-source-if-none-match"
+	CopySourceIfModifiedSinceHeader   = "x-amz-copy-source-if-modified-since"
+	CopySourceIfUnmodifiedSinceHeader = "x-amz-copy-source-if-unmodified-since"
+	MetadataDirectiveHeader           = "x-amz-metadata-directive"
+
+	MetadataDirectiveCopy    = "COPY"
+	MetadataDirectiveReplace = "REPLACE"
+
 	QueryParamUploadID   = "uploadId"
 	QueryParamPartNumber = "partNumber"
 )
 
 type PutObject struct{}
 
-func (controller *PutObject) RequiredPermissions(_ *http.Request, repoID, _, path string) ([]permissions.Permission, error) {
-	return []permissions.Permission{
+func (controller *PutObject) RequiredPermissions(request *http.Request, repoID, _, path string) ([]permissions.Permission, error) {
+	perms := []permissions.Permission{
 		{
 			Action:   permissions.WriteObjectAction,
 			Resource: permissions.ObjectArn(repoID, path),
 		},
-	}, nil
+	}
+	// copy requires reading the source - an invalid copy source is reported when handling the request
+	copySource := request.Header.Get(CopySourceHeader)
+	if copySource == "" {
+		return perms, nil
+	}
+	if p, err := resolveCopySource(copySource); err == nil {
+		perms = append(perms, permissions.Permission{
+			Action:   permissions.ReadObjectAction,
+			Resource: permissions.ObjectArn(p.Repo, p.Path),
+		})
+	}
+	return perms, nil
 }
 
-func (controller *PutObject) HandleCopy(o *PathOperation, copySource string) {
-	o.Incr("copy_object")
-	// resolve source branch and source path
+// resolveCopySource parses a copy source: repository/reference/path, optionally followed by "?versionId=" and the
+// reference of the version to copy
+func resolveCopySource(copySource string) (path.ResolvedAbsolutePath, error) {
+	var versionID string
+	versionQuery := "?" + QueryParamVersionID + "="
+	if i := strings.LastIndex(copySource, versionQuery); i >= 0 {
+		versionID = copySource[i+len(versionQuery):]
+		if decoded, err := url.QueryUnescape(versionID); err == nil {
+			versionID = decoded
+		}
+		copySource = copySource[:i]
+	}
 	copySourceDecoded, err := url.QueryUnescape(copySource)
 	if err != nil {
 		copySourceDecoded = copySource
 	}
 	p, err := path.ResolveAbsolutePath(copySourceDecoded)
 	if err != nil {
-		o.Log().WithError(err).Error("could not parse copy source path")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInvalidCopySource))
-		return
+		return p, err
+	}
+	if versionID != "" {
+		p.Reference = versionID
 	}
+	return p, nil
+}
+
+// copySourceObject is the object read by a copy
+type copySourceObject struct {
+	Repository *catalog.Repository
+	Reference  string
+	Entry      *catalog.Entry
+}
 
-	// validate src and dst are in the same repository
+// resolveCopySourceObject reads the copy source entry and checks the copy source conditions.  On failure the
+// error is encoded to the response and nil is returned.
+func resolveCopySourceObject(o *PathOperation, copySource string) *copySourceObject {
+	p, err := resolveCopySource(copySource)
+	if err != nil {
+		o.Log().WithError(err).Error("could not parse copy source path")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidCopySource))
+		return nil
+	}
+	repo := o.Repository
 	if !strings.EqualFold(o.Repository.Name, p.Repo) {
-		o.Log().WithError(err).Error("cannot copy objects across repos")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInvalidCopySource))
-		return
+		repo, err = o.Cataloger.GetRepository(o.Context(), p.Repo)
+		if errors.Is(err, db.ErrNotFound) {
+			o.Log().WithField("copy_source_repository", p.Repo).Debug("copy source repository not found")
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchBucket))
+			return nil
+		}
+		if err != nil {
+			o.Log().WithError(err).Error("could not read copy source repository")
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+			return nil
+		}
+	}
+	ent, err := o.Cataloger.GetEntry(o.Context(), repo.Name, p.Reference, p.Path, catalog.GetEntryParams{})
+	if errors.Is(err, db.ErrNotFound) {
+		o.Log().WithError(err).Debug("copy source not found")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchKey))
+		return nil
 	}
-
-	// update metadata to refer to the source hash in the destination workspace
-	ent, err := o.Cataloger.GetEntry(o.Context(), o.Repository.Name, p.Reference, p.Path, catalog.GetEntryParams{})
 	if err != nil {
 		o.Log().WithError(err).Error("could not read copy source")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInvalidCopySource))
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidCopySource))
+		return nil
+	}
+	conditions := httputil.Conditions{
+		IfMatch:           o.Request.Header.Get(CopySourceIfMatchHeader),
+		IfNoneMatch:       o.Request.Header.Get(CopySourceIfNoneMatchHeader),
+		IfModifiedSince:   o.Request.Header.Get(CopySourceIfModifiedSinceHeader),
+		IfUnmodifiedSince: o.Request.Header.Get(CopySourceIfUnmodifiedSinceHeader),
+	}
+	// unlike reads, a copy fails with precondition failed when the source was not modified
+	if conditions.Evaluate(ent.Checksum, ent.CreationDate) != httputil.ConditionPassed {
+		o.Log().Debug("copy source precondition failed")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrPreconditionFailed))
+		return nil
+	}
+	return &copySourceObject{
+		Repository: repo,
+		Reference:  p.Reference,
+		Entry:      ent,
+	}
+}
+
+// readCopySource reads the source data in the range [start, end], or all of it when start is negative
+func readCopySource(o *PathOperation, src *copySourceObject, start, end int64) (io.ReadCloser, error) {
+	pointer := block.ObjectPointer{
+		StorageNamespace: src.Repository.StorageNamespace,
+		Identifier:       src.Entry.PhysicalAddress,
+	}
+	if start < 0 {
+		return o.BlockStore.Get(pointer, src.Entry.Size)
+	}
+	return o.BlockStore.GetRange(pointer, start, end)
+}
+
+func (controller *PutObject) HandleCopy(o *PathOperation, copySource string, opts block.PutOpts) {
+	o.Incr("copy_object")
+	directive := strings.ToUpper(o.Request.Header.Get(MetadataDirectiveHeader))
+	if directive == "" {
+		directive = MetadataDirectiveCopy
+	}
+	if directive != MetadataDirectiveCopy && directive != MetadataDirectiveReplace {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidMetadataDirective))
 		return
 	}
-	// write this object to workspace
-	// TODO: move this logic into the Index impl.
-	ent.CreationDate = time.Now()
+	taggingDirective := strings.ToUpper(o.Request.Header.Get(TaggingDirectiveHeader))
+	if taggingDirective == "" {
+		taggingDirective = TaggingDirectiveCopy
+	}
+	if taggingDirective != TaggingDirectiveCopy && taggingDirective != TaggingDirectiveReplace {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidTaggingDirective))
+		return
+	}
+	params, ok := o.writeConditions()
+	if !ok {
+		return
+	}
+	src := resolveCopySourceObject(o, copySource)
+	if src == nil {
+		return
+	}
+	if src.Repository.Name == o.Repository.Name && src.Reference == o.Reference && src.Entry.Path == o.Path &&
+		directive != MetadataDirectiveReplace && taggingDirective != TaggingDirectiveReplace {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidCopyDest))
+		return
+	}
+
+	ent := *src.Entry
 	ent.Path = o.Path
-	err = o.Cataloger.CreateEntry(o.Context(), o.Repository.Name, o.Reference, *ent, catalog.CreateEntryParams{})
+	ent.Expired = false
+	if directive == MetadataDirectiveReplace {
+		metadata, err := metadataFromHeader(o.Request.Header)
+		if err != nil {
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrMetadataTooLarge))
+			return
+		}
+		// tags are copied unless replaced by the tagging directive
+		ent.Metadata = metadata.WithTags(src.Entry.Metadata.Tags())
+	}
+	if taggingDirective == TaggingDirectiveReplace {
+		tags, err := tagsFromHeader(o.Request.Header)
+		if err != nil {
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidTag))
+			return
+		}
+		ent.Metadata = ent.Metadata.WithTags(tags)
+	}
+	if src.Repository.StorageNamespace != o.Repository.StorageNamespace {
+		// physical addresses are relative to the storage namespace - copy the data to the destination
+		if !o.checkWriteConditions(params) {
+			return
+		}
+		data, err := readCopySource(o, src, -1, -1)
+		if err != nil {
+			o.Log().WithError(err).Error("could not read copy source data")
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+			return
+		}
+		blob, err := upload.WriteBlob(o.BlockStore, o.Repository.StorageNamespace, data, src.Entry.Size, opts)
+		_ = data.Close()
+		if err != nil {
+			o.Log().WithError(err).Error("could not write copy destination data")
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+			return
+		}
+		ent.PhysicalAddress = blob.PhysicalAddress
+		ent.Checksum = blob.Checksum
+		ent.Size = blob.Size
+		params.Dedup = catalog.DedupParams{
+			ID:               blob.Checksum,
+			StorageNamespace: o.Repository.StorageNamespace,
+		}
+	}
+	ent.CreationDate = time.Now()
+	err := o.Cataloger.CreateEntry(o.Context(), o.Repository.Name, o.Reference, ent, params)
 	if err != nil {
 		o.Log().WithError(err).Error("could not write copy destination")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInvalidCopyDest))
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(catalogErrorCode(err, gatewayerrors.ErrInvalidCopyDest)))
 		return
 	}
 
 	o.EncodeResponse(&serde.CopyObjectResult{
 		LastModified: serde.Timestamp(ent.CreationDate),
-		ETag:         fmt.Sprintf("\"%s\"", ent.Checksum),
+		ETag:         httputil.ETag(ent.Checksum),
 	}, http.StatusOK)
 }
 
-func (controller *PutObject) HandleUploadPart(o *PathOperation) {
-	o.Incr("put_mpu_part")
+// parseUploadPart returns the upload id and part number of an upload part request.  On failure the error is
+// encoded to the response and ok is false.
+func parseUploadPart(o *PathOperation) (uploadID string, partNumber int64, ok bool) {
 	query := o.Request.URL.Query()
-	uploadID := query.Get(QueryParamUploadID)
-	partNumberStr := query.Get(QueryParamPartNumber)
-
-	partNumber, err := strconv.ParseInt(partNumberStr, 10, 64)
+	uploadID = query.Get(QueryParamUploadID)
+	partNumber, err := strconv.ParseInt(query.Get(QueryParamPartNumber), 10, 64)
 	if err != nil {
 		o.Log().WithError(err).Error("invalid part number")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInvalidPartNumberMarker))
-		return
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidPartNumberMarker))
+		return "", 0, false
 	}
-
 	o.AddLogFields(logging.Fields{
 		"part_number": partNumber,
 		"upload_id":   uploadID,
 	})
+	return uploadID, partNumber, true
+}
+
+// HandleUploadPartCopy uploads a part of a multipart upload from an existing object, or a range of it
+// (https://docs.aws.amazon.com/AmazonS3/latest/API/API_UploadPartCopy.html)
+func (controller *PutObject) HandleUploadPartCopy(o *PathOperation, copySource string) {
+	o.Incr("put_mpu_part_copy")
+	uploadID, partNumber, ok := parseUploadPart(o)
+	if !ok {
+		return
+	}
+	multiPart, err := o.Cataloger.GetMultipartUpload(o.Context(), o.Repository.Name, uploadID)
+	if errors.Is(err, db.ErrNotFound) {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchUpload))
+		return
+	}
+	if err != nil {
+		o.Log().WithError(err).Error("could not read multipart record")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	src := resolveCopySourceObject(o, copySource)
+	if src == nil {
+		return
+	}
+
+	size := src.Entry.Size
+	rng := ghttp.Range{StartOffset: -1, EndOffset: -1}
+	if rangeSpec := o.Request.Header.Get(CopySourceRangeHeader); rangeSpec != "" {
+		rng, err = ghttp.ParseRange(rangeSpec, src.Entry.Size)
+		if err != nil {
+			o.Log().WithError(err).WithField("range", rangeSpec).Debug("invalid copy source range")
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidCopyPartRangeSource))
+			return
+		}
+		size = rng.EndOffset - rng.StartOffset + 1 // both range ends are inclusive
+	}
+	data, err := readCopySource(o, src, rng.StartOffset, rng.EndOffset)
+	if err != nil {
+		o.Log().WithError(err).Error("could not read copy source data")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	defer func() {
+		_ = data.Close()
+	}()
+	ETag, err := o.BlockStore.UploadPart(block.ObjectPointer{StorageNamespace: o.Repository.StorageNamespace, Identifier: multiPart.PhysicalAddress},
+		size, data, uploadID, partNumber)
+	if err != nil {
+		o.Log().WithError(err).Error("part copy upload failed")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	o.EncodeResponse(&serde.CopyPartResult{
+		LastModified: serde.Timestamp(time.Now()),
+		ETag:         ETag,
+	}, http.StatusOK)
+}
+
+func (controller *PutObject) HandleUploadPart(o *PathOperation) {
+	o.Incr("put_mpu_part")
+	uploadID, partNumber, ok := parseUploadPart(o)
+	if !ok {
+		return
+	}
 
 	// handle the upload itself
 	multiPart, err := o.Cataloger.GetMultipartUpload(o.Context(), o.Repository.Name, uploadID)
 	if err != nil {
 		o.Log().WithError(err).Error("could not read  multipart record")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
 		return
 	}
 	byteSize := o.Request.ContentLength
 	ETag, err := o.BlockStore.UploadPart(block.ObjectPointer{StorageNamespace: o.Repository.StorageNamespace, Identifier: multiPart.PhysicalAddress},
 		byteSize, o.Request.Body, uploadID, partNumber)
 	if err != nil {
-		o.Log().WithError(err).Error("part " + partNumberStr + " upload failed")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+		o.Log().WithError(err).Error("part upload failed")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
 		return
 	}
 	o.SetHeader("ETag", ETag)
@@ -123,12 +356,18 @@ func (controller *PutObject) Handle(o *PathOperation) {
 	branchExists, err := o.Cataloger.BranchExists(o.Context(), o.Repository.Name, o.Reference)
 	if err != nil {
 		o.Log().WithError(err).Error("could not check if branch exists")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
 		return
 	}
 	if !branchExists {
 		o.Log().Debug("branch not found")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrNoSuchBucket))
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchBucket))
+		return
+	}
+	// a protected branch rejects the write - fail before the data is uploaded
+	if err := o.Cataloger.CheckBranchProtection(o.Context(), o.Repository.Name, o.Reference); err != nil {
+		o.Log().WithError(err).Debug("branch protection rejected the write")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(catalogErrorCode(err, gatewayerrors.ErrInternalError)))
 		return
 	}
 
@@ -137,39 +376,55 @@ func (controller *PutObject) Handle(o *PathOperation) {
 	storageClass := StorageClassFromHeader(o.Request.Header)
 	opts := block.PutOpts{StorageClass: storageClass}
 
+	query := o.Request.URL.Query()
+	if _, exists := query[QueryParamTagging]; exists {
+		controller.HandlePutTagging(o)
+		return
+	}
 	copySource := o.Request.Header.Get(CopySourceHeader)
+
+	// check if this is a multipart upload part, uploaded from the request body or copied from an existing object
+	_, hasUploadID := query[QueryParamUploadID]
+	if hasUploadID {
+		if len(copySource) > 0 {
+			controller.HandleUploadPartCopy(o, copySource)
+		} else {
+			controller.HandleUploadPart(o)
+		}
+		return
+	}
+
 	if len(copySource) > 0 {
 		// The *first* PUT operation sets PutOpts such as
 		// storage class, subsequent PUT operations of the
 		// same file continue to use that storage class.
 
 		// TODO(ariels): Add a counter for how often a copy has different options
-		controller.HandleCopy(o, copySource)
+		controller.HandleCopy(o, copySource, opts)
 		return
 	}
 
-	query := o.Request.URL.Query()
-
-	// check if this is a multipart upload creation call
-	_, hasUploadID := query[QueryParamUploadID]
-	if hasUploadID {
-		controller.HandleUploadPart(o)
+	o.Incr("put_object")
+	metadata, ok := o.metadataAndTagsFromHeader()
+	if !ok {
+		return
+	}
+	params, ok := o.writeConditions()
+	if !ok || !o.checkWriteConditions(params) {
 		return
 	}
-
-	o.Incr("put_object")
 	// handle the upload itself
 	blob, err := upload.WriteBlob(o.BlockStore, o.Repository.StorageNamespace, o.Request.Body, o.Request.ContentLength, opts)
 	if err != nil {
 		o.Log().WithError(err).Error("could not write request body to block adapter")
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
 		return
 	}
 
 	// write metadata
-	err = o.finishUpload(o.Repository.StorageNamespace, blob.Checksum, blob.PhysicalAddress, blob.Size)
+	err = o.finishUpload(o.Repository.StorageNamespace, blob.Checksum, blob.PhysicalAddress, blob.Size, metadata, params)
 	if err != nil {
-		o.EncodeError(errors.Codes.ToAPIErr(errors.ErrInternalError))
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(catalogErrorCode(err, gatewayerrors.ErrInternalError)))
 		return
 	}
 	o.SetHeader("ETag", httputil.ETag(blob.Checksum))
@@ -84,10 +84,18 @@ func (s *mockAdapter) CompleteMultiPartUpload(_ block.ObjectPointer, uploadId st
 	panic("try to complete multipart in mock adaptor ")
 }
 
+func (s *mockAdapter) ListParts(_ block.ObjectPointer, _ string) ([]block.PartInfo, error) {
+	panic("try to list parts in mock adaptor ")
+}
+
 func (s *mockAdapter) ValidateConfiguration(_ string) error {
 	return nil
 }
 
+func (s *mockAdapter) Walk(_ string, _ block.WalkFunc) error {
+	return nil
+}
+
 func (s *mockAdapter) GenerateInventory(_ logging.Logger, _ string) (block.Inventory, error) {
 	return nil, nil
 }
@@ -0,0 +1,153 @@
+package operations
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/db"
+	gatewayerrors "github.com/treeverse/lakefs/gateway/errors"
+	"github.com/treeverse/lakefs/gateway/serde"
+)
+
+const (
+	TaggingHeader          = "x-amz-tagging"
+	TaggingDirectiveHeader = "x-amz-tagging-directive"
+	TaggingCountHeader     = "x-amz-tagging-count"
+	QueryParamTagging      = "tagging"
+
+	TaggingDirectiveCopy    = "COPY"
+	TaggingDirectiveReplace = "REPLACE"
+
+	// S3 limits on object tags
+	maxTags           = 10
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+var errInvalidTag = errors.New("invalid tag")
+
+func validateTags(tags map[string]string) error {
+	if len(tags) > maxTags {
+		return errInvalidTag
+	}
+	for k, v := range tags {
+		if k == "" || utf8.RuneCountInString(k) > maxTagKeyLength || utf8.RuneCountInString(v) > maxTagValueLength {
+			return errInvalidTag
+		}
+	}
+	return nil
+}
+
+// tagsFromHeader returns the object tags of the x-amz-tagging header, URL query encoded (key1=value1&key2=value2)
+func tagsFromHeader(header http.Header) (map[string]string, error) {
+	tagging := header.Get(TaggingHeader)
+	if tagging == "" {
+		return nil, nil
+	}
+	values, err := url.ParseQuery(tagging)
+	if err != nil {
+		return nil, errInvalidTag
+	}
+	tags := make(map[string]string, len(values))
+	for k, v := range values {
+		if len(v) != 1 {
+			return nil, errInvalidTag
+		}
+		tags[k] = v[0]
+	}
+	if err := validateTags(tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// getEntry returns the operation entry.  On failure the error is encoded to the response and nil is returned.
+func (o *PathOperation) getEntry() *catalog.Entry {
+	entry, err := o.Cataloger.GetEntry(o.Context(), o.Repository.Name, o.Reference, o.Path, catalog.GetEntryParams{})
+	if errors.Is(err, db.ErrNotFound) {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchKey))
+		return nil
+	}
+	if err != nil {
+		o.Log().WithError(err).Error("could not read object")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return nil
+	}
+	return entry
+}
+
+// setTags replaces the tags of the operation entry, keeping the entry object and the rest of its metadata.  On
+// failure the error is encoded to the response and false is returned.
+func (o *PathOperation) setTags(tags map[string]string) bool {
+	err := o.Cataloger.SetEntryTags(o.Context(), o.Repository.Name, o.Reference, o.Path, tags)
+	if errors.Is(err, db.ErrNotFound) {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchKey))
+		return false
+	}
+	if err != nil {
+		o.Log().WithError(err).Error("could not update object tags")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(catalogErrorCode(err, gatewayerrors.ErrInternalError)))
+		return false
+	}
+	return true
+}
+
+// HandleGetTagging returns the object tags (https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectTagging.html)
+func (controller *GetObject) HandleGetTagging(o *PathOperation) {
+	o.Incr("get_object_tagging")
+	entry := o.getEntry()
+	if entry == nil {
+		return
+	}
+	tags := entry.Metadata.Tags()
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	tagging := serde.Tagging{}
+	for _, k := range keys {
+		tagging.TagSet.Tag = append(tagging.TagSet.Tag, serde.Tag{Key: k, Value: tags[k]})
+	}
+	o.EncodeResponse(tagging, http.StatusOK)
+}
+
+// HandlePutTagging replaces the object tags (https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectTagging.html)
+func (controller *PutObject) HandlePutTagging(o *PathOperation) {
+	o.Incr("put_object_tagging")
+	var tagging serde.Tagging
+	if err := DecodeXMLBody(o.Request.Body, &tagging); err != nil {
+		o.Log().WithError(err).Debug("could not parse tagging body")
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrMalformedXML))
+		return
+	}
+	tags := make(map[string]string, len(tagging.TagSet.Tag))
+	for _, tag := range tagging.TagSet.Tag {
+		if _, ok := tags[tag.Key]; ok {
+			o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidTag))
+			return
+		}
+		tags[tag.Key] = tag.Value
+	}
+	if err := validateTags(tags); err != nil {
+		o.EncodeError(gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidTag))
+		return
+	}
+	if !o.setTags(tags) {
+		return
+	}
+	o.ResponseWriter.WriteHeader(http.StatusOK)
+}
+
+// HandleDeleteTagging removes the object tags (https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteObjectTagging.html)
+func (controller *DeleteObject) HandleDeleteTagging(o *PathOperation) {
+	o.Incr("delete_object_tagging")
+	if !o.setTags(nil) {
+		return
+	}
+	o.ResponseWriter.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,40 @@
+package operations
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTagsFromHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		tagging string
+		want    map[string]string
+		wantErr error
+	}{
+		{name: "none", tagging: "", want: nil},
+		{name: "tags", tagging: "team=data&stage=raw%20data", want: map[string]string{"team": "data", "stage": "raw data"}},
+		{name: "empty value", tagging: "archived=", want: map[string]string{"archived": ""}},
+		{name: "duplicate key", tagging: "team=data&team=ops", wantErr: errInvalidTag},
+		{name: "key too long", tagging: strings.Repeat("k", maxTagKeyLength+1) + "=v", wantErr: errInvalidTag},
+		{name: "too many", tagging: "a=1&b=2&c=3&d=4&e=5&f=6&g=7&h=8&i=9&j=10&k=11", wantErr: errInvalidTag},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.tagging != "" {
+				header.Set(TaggingHeader, tt.tagging)
+			}
+			got, err := tagsFromHeader(header)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("tagsFromHeader() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tagsFromHeader() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package gc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/logging"
+)
+
+const (
+	DefaultCommitWindow = 7 * 24 * time.Hour
+	DefaultMinAge       = 6 * time.Hour
+)
+
+type Params struct {
+	// CommitWindow keeps the objects of commits created within this duration
+	CommitWindow time.Duration
+	// MinAge keeps objects modified within this duration, they may belong to uploads in progress
+	MinAge time.Duration
+	// DryRun reports the objects to remove without removing them
+	DryRun bool
+	// StorageNamespaces to collect in addition to the storage namespaces of the repositories - such as the
+	// storage namespace of a deleted repository
+	StorageNamespaces []string
+}
+
+// Object is an object not referenced by the catalog
+type Object struct {
+	StorageNamespace string
+	Identifier       string
+	Size             int64
+	LastModified     time.Time
+	// Err is set when removing the object failed
+	Err error
+}
+
+type Report struct {
+	Objects []*Object
+	// Size is the total size of the objects
+	Size    int64
+	Removed int
+	Failed  int
+	// Kept is the number of objects referenced again before they were removed
+	Kept int
+}
+
+// Collector removes the objects of the storage namespaces no longer referenced by the catalog: objects not
+// reachable from any branch head, tag, commit within the commit window or active multipart upload.
+type Collector struct {
+	cataloger catalog.Cataloger
+	adapter   block.Adapter
+	log       logging.Logger
+}
+
+func NewCollector(cataloger catalog.Cataloger, adapter block.Adapter) *Collector {
+	return &Collector{
+		cataloger: cataloger,
+		adapter:   adapter,
+		log:       logging.Default().WithField("service_name", "gc"),
+	}
+}
+
+type references struct {
+	keys      map[string]struct{}
+	uploadIDs []string
+}
+
+func (r *references) contains(key, identifier string) bool {
+	if _, ok := r.keys[key]; ok {
+		return true
+	}
+	// parts of a multipart upload in progress
+	for _, uploadID := range r.uploadIDs {
+		if strings.HasPrefix(identifier, uploadID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Collector) Run(ctx context.Context, params Params) (*Report, error) {
+	start := time.Now()
+	since := start.Add(-params.CommitWindow)
+	// read references before walking the storage - objects created later are newer than the min age
+	refs, err := c.references(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := c.storageNamespaces(ctx, params.StorageNamespaces)
+	if err != nil {
+		return nil, err
+	}
+	modifiedBefore := start.Add(-params.MinAge)
+	adapter := c.adapter.WithContext(ctx)
+	report := &Report{}
+	found := make(map[string]struct{})
+	for _, storageNamespace := range namespaces {
+		log := c.log.WithField("storage_namespace", storageNamespace)
+		err := adapter.Walk(storageNamespace, func(info block.ObjectInfo) error {
+			key, err := objectKey(storageNamespace, info.Identifier)
+			if err != nil {
+				log.WithError(err).WithField("identifier", info.Identifier).Warn("skip object")
+				return nil
+			}
+			if _, ok := found[key]; ok || refs.contains(key, info.Identifier) || info.LastModified.After(modifiedBefore) {
+				return nil
+			}
+			found[key] = struct{}{}
+			report.Objects = append(report.Objects, &Object{
+				StorageNamespace: storageNamespace,
+				Identifier:       info.Identifier,
+				Size:             info.Size,
+				LastModified:     info.LastModified,
+			})
+			report.Size += info.Size
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walk %s: %w", storageNamespace, err)
+		}
+		log.Info("storage namespace walked")
+	}
+	if params.DryRun {
+		return report, nil
+	}
+	if err := c.remove(ctx, adapter, report, since); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// remove the objects still unreferenced after their dedup records are deleted.  Objects found by the walk may be
+// referenced again since the references were read - an upload deduplicated to one of them - and are kept.
+func (c *Collector) remove(ctx context.Context, adapter block.Adapter, report *Report, since time.Time) error {
+	addresses := make([]string, len(report.Objects))
+	for i, obj := range report.Objects {
+		addresses[i] = obj.Identifier
+	}
+	unreferenced, err := c.cataloger.DeleteUnreferencedAddresses(ctx, since, addresses)
+	if err != nil {
+		return fmt.Errorf("delete unreferenced addresses: %w", err)
+	}
+	remove := make(map[string]struct{}, len(unreferenced))
+	for _, address := range unreferenced {
+		remove[address] = struct{}{}
+	}
+	for _, obj := range report.Objects {
+		if _, ok := remove[obj.Identifier]; !ok {
+			c.log.WithFields(logging.Fields{
+				"storage_namespace": obj.StorageNamespace,
+				"identifier":        obj.Identifier,
+			}).Info("object referenced again, kept")
+			report.Kept++
+			continue
+		}
+		obj.Err = adapter.Remove(block.ObjectPointer{
+			StorageNamespace: obj.StorageNamespace,
+			Identifier:       obj.Identifier,
+		})
+		if obj.Err != nil {
+			c.log.WithError(obj.Err).WithFields(logging.Fields{
+				"storage_namespace": obj.StorageNamespace,
+				"identifier":        obj.Identifier,
+			}).Error("failed to remove object")
+			report.Failed++
+		} else {
+			report.Removed++
+		}
+	}
+	return nil
+}
+
+func (c *Collector) references(ctx context.Context, since time.Time) (*references, error) {
+	rows, err := c.cataloger.QueryReferencedAddresses(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("query referenced addresses: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	refs := &references{
+		keys: make(map[string]struct{}),
+	}
+	for rows.Next() {
+		address, err := rows.Read()
+		if err != nil {
+			return nil, fmt.Errorf("read referenced address: %w", err)
+		}
+		if address.UploadID != "" {
+			refs.uploadIDs = append(refs.uploadIDs, address.UploadID)
+		}
+		if address.PhysicalAddress == "" {
+			continue
+		}
+		key, err := objectKey(address.StorageNamespace, address.PhysicalAddress)
+		if err != nil {
+			// objects of the storage namespace can't be resolved either, so none of them is collected
+			c.log.WithError(err).WithField("physical_address", address.PhysicalAddress).Warn("skip reference")
+			continue
+		}
+		refs.keys[key] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("referenced addresses: %w", err)
+	}
+	return refs, nil
+}
+
+func (c *Collector) storageNamespaces(ctx context.Context, additional []string) ([]string, error) {
+	set := make(map[string]struct{})
+	for _, storageNamespace := range additional {
+		set[storageNamespace] = struct{}{}
+	}
+	after := ""
+	for {
+		repos, hasMore, err := c.cataloger.ListRepositories(ctx, -1, after)
+		if err != nil {
+			return nil, fmt.Errorf("list repositories: %w", err)
+		}
+		for _, repo := range repos {
+			set[repo.StorageNamespace] = struct{}{}
+		}
+		if !hasMore || len(repos) == 0 {
+			break
+		}
+		after = repos[len(repos)-1].Name
+	}
+	namespaces := make([]string, 0, len(set))
+	for storageNamespace := range set {
+		namespaces = append(namespaces, storageNamespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// objectKey returns the location of an object in the storage, to match objects found by walking a storage
+// namespace with the physical addresses of the catalog.  The local adapter stores the objects of all storage
+// namespaces in the same directory by their identifier.
+func objectKey(storageNamespace, address string) (string, error) {
+	qualifiedKey, err := block.ResolveNamespace(storageNamespace, address)
+	if err != nil {
+		return "", err
+	}
+	if qualifiedKey.StorageType == block.StorageTypeLocal {
+		return fmt.Sprintf("%d:%s", qualifiedKey.StorageType, address), nil
+	}
+	return fmt.Sprintf("%d:%s/%s", qualifiedKey.StorageType, qualifiedKey.StorageNamespace, qualifiedKey.Key), nil
+}
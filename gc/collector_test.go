@@ -0,0 +1,187 @@
+package gc_test
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/block"
+	"github.com/treeverse/lakefs/block/mem"
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/gc"
+	"github.com/treeverse/lakefs/testutil"
+	"github.com/treeverse/lakefs/upload"
+)
+
+const testStorageNamespace = "mem://bucket"
+
+func testWriteObject(t *testing.T, adapter block.Adapter, data string) string {
+	t.Helper()
+	blob, err := upload.WriteBlob(adapter, testStorageNamespace, bytes.NewReader([]byte(data)), int64(len(data)), block.PutOpts{})
+	testutil.MustDo(t, "write blob", err)
+	return blob.PhysicalAddress
+}
+
+func testCreateEntry(t *testing.T, ctx context.Context, c catalog.Cataloger, adapter block.Adapter, branch, path string) string {
+	t.Helper()
+	address := testWriteObject(t, adapter, path)
+	err := c.CreateEntry(ctx, "repo1", branch, catalog.Entry{
+		Path:            path,
+		PhysicalAddress: address,
+		Checksum:        path,
+		Size:            int64(len(path)),
+	}, catalog.CreateEntryParams{})
+	testutil.MustDo(t, "create entry "+path, err)
+	return address
+}
+
+func testReportIdentifiers(report *gc.Report) []string {
+	var identifiers []string
+	for _, obj := range report.Objects {
+		identifiers = append(identifiers, obj.Identifier)
+	}
+	sort.Strings(identifiers)
+	return identifiers
+}
+
+func TestCollector_Run(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := testutil.GetDB(t, databaseURI)
+	c := catalog.NewCataloger(conn)
+	defer func() { _ = c.Close() }()
+	adapter := mem.New()
+	testutil.MustDo(t, "create repository", c.CreateRepository(ctx, "repo1", testStorageNamespace, "master"))
+
+	// committed entry
+	committed := testCreateEntry(t, ctx, c, adapter, "master", "file1")
+	_, err := c.Commit(ctx, "repo1", "master", "add file1", "tester", nil)
+	testutil.MustDo(t, "commit", err)
+	// entry of a deleted branch
+	_, err = c.CreateBranch(ctx, "repo1", "branch1", "master")
+	testutil.MustDo(t, "create branch", err)
+	deletedBranch := testCreateEntry(t, ctx, c, adapter, "branch1", "file2")
+	testutil.MustDo(t, "delete branch", c.DeleteBranch(ctx, "repo1", "branch1"))
+	// deleted uncommitted entry
+	deletedEntry := testCreateEntry(t, ctx, c, adapter, "master", "file3")
+	testutil.MustDo(t, "delete entry", c.DeleteEntry(ctx, "repo1", "master", "file3"))
+	// object never added to the catalog
+	unreferenced := testWriteObject(t, adapter, "data")
+	// active multipart upload
+	uploadAddress := testWriteObject(t, adapter, "upload")
+	err = c.CreateMultipartUpload(ctx, "repo1", "master", "upload1", "file4", uploadAddress, time.Now(), nil)
+	testutil.MustDo(t, "create multipart upload", err)
+
+	collector := gc.NewCollector(c, adapter)
+	expected := []string{deletedBranch, deletedEntry, unreferenced}
+	sort.Strings(expected)
+
+	// objects modified within min age are kept
+	report, err := collector.Run(ctx, gc.Params{MinAge: time.Hour, DryRun: true})
+	testutil.MustDo(t, "run with min age", err)
+	if len(report.Objects) != 0 {
+		t.Fatalf("report objects %v, expected none within min age", testReportIdentifiers(report))
+	}
+
+	// dry run reports without removing
+	report, err = collector.Run(ctx, gc.Params{DryRun: true})
+	testutil.MustDo(t, "dry run", err)
+	if diff := deep.Equal(testReportIdentifiers(report), expected); diff != nil {
+		t.Fatal("dry run report", diff)
+	}
+	if report.Removed != 0 {
+		t.Fatalf("dry run removed %d objects", report.Removed)
+	}
+	for _, identifier := range expected {
+		if _, err := adapter.Get(block.ObjectPointer{StorageNamespace: testStorageNamespace, Identifier: identifier}, -1); err != nil {
+			t.Fatalf("object %s removed by dry run: %s", identifier, err)
+		}
+	}
+
+	report, err = collector.Run(ctx, gc.Params{})
+	testutil.MustDo(t, "run", err)
+	if diff := deep.Equal(testReportIdentifiers(report), expected); diff != nil {
+		t.Fatal("run report", diff)
+	}
+	if report.Removed != len(expected) || report.Failed != 0 {
+		t.Fatalf("removed %d, failed %d, expected %d removed", report.Removed, report.Failed, len(expected))
+	}
+	for _, identifier := range expected {
+		if _, err := adapter.Get(block.ObjectPointer{StorageNamespace: testStorageNamespace, Identifier: identifier}, -1); err == nil {
+			t.Fatalf("object %s expected to be removed", identifier)
+		}
+	}
+	for _, identifier := range []string{committed, uploadAddress} {
+		if _, err := adapter.Get(block.ObjectPointer{StorageNamespace: testStorageNamespace, Identifier: identifier}, -1); err != nil {
+			t.Fatalf("referenced object %s removed: %s", identifier, err)
+		}
+	}
+}
+
+// dedupCataloger deduplicates an upload to a removal candidate before its references are checked again
+type dedupCataloger struct {
+	catalog.Cataloger
+	dedup func()
+}
+
+func (c *dedupCataloger) DeleteUnreferencedAddresses(ctx context.Context, since time.Time, addresses []string) ([]string, error) {
+	c.dedup()
+	return c.Cataloger.DeleteUnreferencedAddresses(ctx, since, addresses)
+}
+
+func TestCollector_Run_DedupBeforeRemove(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := testutil.GetDB(t, databaseURI)
+	c := catalog.NewCataloger(conn)
+	defer func() { _ = c.Close() }()
+	adapter := mem.New()
+	testutil.MustDo(t, "create repository", c.CreateRepository(ctx, "repo1", testStorageNamespace, "master"))
+
+	createDedupEntry := func(path string) string {
+		address := testWriteObject(t, adapter, "data")
+		err := c.CreateEntry(ctx, "repo1", "master", catalog.Entry{
+			Path:            path,
+			PhysicalAddress: address,
+			Checksum:        "dedup1",
+			Size:            int64(len("data")),
+		}, catalog.CreateEntryParams{Dedup: catalog.DedupParams{ID: "dedup1", StorageNamespace: testStorageNamespace}})
+		testutil.MustDo(t, "create entry "+path, err)
+		return address
+	}
+	// the object of a deleted entry is a removal candidate, its dedup record is kept
+	candidate := createDedupEntry("file1")
+	testutil.MustDo(t, "delete entry", c.DeleteEntry(ctx, "repo1", "master", "file1"))
+
+	collector := gc.NewCollector(&dedupCataloger{
+		Cataloger: c,
+		dedup: func() {
+			createDedupEntry("file2")
+			select {
+			case report := <-c.DedupReportChannel():
+				if report.NewPhysicalAddress != candidate {
+					t.Fatalf("dedup to %s, expected %s", report.NewPhysicalAddress, candidate)
+				}
+			case <-time.After(3 * time.Second):
+				t.Fatal("timeout waiting for dedup report")
+			}
+		},
+	}, adapter)
+	report, err := collector.Run(ctx, gc.Params{})
+	testutil.MustDo(t, "run", err)
+	if diff := deep.Equal(testReportIdentifiers(report), []string{candidate}); diff != nil {
+		t.Fatal("run report", diff)
+	}
+	if report.Kept != 1 || report.Removed != 0 {
+		t.Fatalf("kept %d, removed %d, expected the deduplicated object kept", report.Kept, report.Removed)
+	}
+	if _, err := adapter.Get(block.ObjectPointer{StorageNamespace: testStorageNamespace, Identifier: candidate}, -1); err != nil {
+		t.Fatalf("deduplicated object %s removed: %s", candidate, err)
+	}
+	entry, err := c.GetEntry(ctx, "repo1", "master", "file2", catalog.GetEntryParams{})
+	testutil.MustDo(t, "get entry", err)
+	if entry.PhysicalAddress != candidate {
+		t.Fatalf("entry address %s, expected %s", entry.PhysicalAddress, candidate)
+	}
+}
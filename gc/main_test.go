@@ -0,0 +1,37 @@
+package gc_test
+
+import (
+	"flag"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/ory/dockertest/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/treeverse/lakefs/testutil"
+)
+
+var (
+	pool        *dockertest.Pool
+	databaseURI string
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if !testing.Verbose() {
+		// keep the log level calm
+		logrus.SetLevel(logrus.PanicLevel)
+	}
+
+	// postgres container
+	var err error
+	pool, err = dockertest.NewPool("")
+	if err != nil {
+		log.Fatalf("Could not connect to Docker: %s", err)
+	}
+	var closer func()
+	databaseURI, closer = testutil.GetDBInstance(pool)
+	code := m.Run()
+	closer() // cleanup
+	os.Exit(code)
+}
@@ -0,0 +1,139 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/logging"
+)
+
+// maxRejectMessageLength limits the part of a rejecting hook response body reported back to the caller
+const maxRejectMessageLength = 1024
+
+// Event is the JSON body posted to each hook endpoint
+type Event struct {
+	EventType    string            `json:"event_type"`
+	HookName     string            `json:"hook_name"`
+	Repository   string            `json:"repository"`
+	Branch       string            `json:"branch"`
+	SourceBranch string            `json:"source_branch,omitempty"`
+	Committer    string            `json:"committer"`
+	Message      string            `json:"message"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	Differences  []Difference      `json:"differences"`
+}
+
+type Difference struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+}
+
+// HTTPRunner posts the event to each hook URL, in order.  A hook accepts the event by responding with a 2xx
+// status code - any other response, or no response within the hook timeout, rejects it.
+type HTTPRunner struct {
+	client *http.Client
+	log    logging.Logger
+}
+
+func NewHTTPRunner(client *http.Client) *HTTPRunner {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPRunner{
+		client: client,
+		log:    logging.Default().WithField("service_name", "hooks"),
+	}
+}
+
+func (r *HTTPRunner) Run(ctx context.Context, hooks []*catalog.Hook, event *catalog.HookEvent) error {
+	for _, hook := range hooks {
+		if err := r.call(ctx, hook, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *HTTPRunner) call(ctx context.Context, hook *catalog.Hook, event *catalog.HookEvent) error {
+	body, err := json.Marshal(NewEvent(hook, event))
+	if err != nil {
+		return fmt.Errorf("hook '%s' event: %w", hook.Name, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, hook.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("hook '%s' request: %w", hook.Name, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	log := r.log.WithFields(logging.Fields{
+		"hook":       hook.Name,
+		"hook_type":  hook.Type,
+		"repository": event.Repository,
+		"branch":     event.Branch,
+	})
+	res, err := r.client.Do(req)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		log.Warn("hook timed out")
+		return fmt.Errorf("%w: hook '%s' timed out after %s", catalog.ErrHookRejected, hook.Name, hook.Timeout)
+	}
+	if err != nil {
+		log.WithError(err).Warn("hook call failed")
+		return fmt.Errorf("%w: hook '%s' call failed: %s", catalog.ErrHookRejected, hook.Name, err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices {
+		log.WithField("status_code", res.StatusCode).Debug("hook accepted")
+		return nil
+	}
+	msg, _ := ioutil.ReadAll(io.LimitReader(res.Body, maxRejectMessageLength))
+	log.WithField("status_code", res.StatusCode).Info("hook rejected")
+	return fmt.Errorf("%w: hook '%s' status %d: %s", catalog.ErrHookRejected, hook.Name, res.StatusCode,
+		strings.TrimSpace(string(msg)))
+}
+
+func NewEvent(hook *catalog.Hook, event *catalog.HookEvent) *Event {
+	differences := make([]Difference, len(event.Differences))
+	for i, d := range event.Differences {
+		differences[i] = Difference{
+			Type: differenceTypeName(d.Type),
+			Path: d.Path,
+		}
+	}
+	return &Event{
+		EventType:    string(event.Type),
+		HookName:     hook.Name,
+		Repository:   event.Repository,
+		Branch:       event.Branch,
+		SourceBranch: event.SourceBranch,
+		Committer:    event.Committer,
+		Message:      event.Message,
+		Metadata:     event.Metadata,
+		Differences:  differences,
+	}
+}
+
+func differenceTypeName(t catalog.DifferenceType) string {
+	switch t {
+	case catalog.DifferenceTypeAdded:
+		return "added"
+	case catalog.DifferenceTypeRemoved:
+		return "removed"
+	case catalog.DifferenceTypeChanged:
+		return "changed"
+	case catalog.DifferenceTypeConflict:
+		return "conflict"
+	default:
+		return ""
+	}
+}
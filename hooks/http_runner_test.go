@@ -0,0 +1,115 @@
+package hooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/hooks"
+)
+
+func TestHTTPRunner_Run(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []*hooks.Event
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev hooks.Event
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		received = append(received, &ev)
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/accept":
+			w.WriteHeader(http.StatusOK)
+		case "/reject":
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte("row count too low\n"))
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	event := &catalog.HookEvent{
+		Type:       catalog.HookTypePreCommit,
+		Repository: "repo1",
+		Branch:     "master",
+		Committer:  "tester",
+		Message:    "message",
+		Metadata:   catalog.Metadata{"key": "value"},
+		Differences: catalog.Differences{
+			{Type: catalog.DifferenceTypeAdded, Path: "file1"},
+			{Type: catalog.DifferenceTypeRemoved, Path: "file2"},
+		},
+	}
+	hook := func(name, path string) *catalog.Hook {
+		return &catalog.Hook{
+			Name:    name,
+			Type:    catalog.HookTypePreCommit,
+			URL:     server.URL + path,
+			Timeout: 50 * time.Millisecond,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		hooks        []*catalog.Hook
+		wantErr      error
+		wantReceived []string
+	}{
+		{
+			name:         "accept",
+			hooks:        []*catalog.Hook{hook("a", "/accept"), hook("b", "/accept")},
+			wantReceived: []string{"a", "b"},
+		},
+		{
+			name:         "reject stops",
+			hooks:        []*catalog.Hook{hook("a", "/reject"), hook("b", "/accept")},
+			wantErr:      catalog.ErrHookRejected,
+			wantReceived: []string{"a"},
+		},
+		{
+			name:         "timeout",
+			hooks:        []*catalog.Hook{hook("a", "/slow")},
+			wantErr:      catalog.ErrHookRejected,
+			wantReceived: []string{"a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			received = nil
+			mu.Unlock()
+			runner := hooks.NewHTTPRunner(nil)
+			err := runner.Run(context.Background(), tt.hooks, event)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Run() err = %v, expected %v", err, tt.wantErr)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			names := make([]string, len(received))
+			for i, ev := range received {
+				names[i] = ev.HookName
+				expected := hooks.NewEvent(tt.hooks[i], event)
+				if diff := deep.Equal(ev, expected); diff != nil {
+					t.Error("hook event", diff)
+				}
+			}
+			if diff := deep.Equal(names, tt.wantReceived); diff != nil {
+				t.Fatal("Run() called hooks", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package httputil
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ConditionResult is the outcome of evaluating the conditional headers of a request
+type ConditionResult int
+
+const (
+	// ConditionPassed - the request should be served
+	ConditionPassed ConditionResult = iota
+	// ConditionNotModified - the resource did not change, a read should be answered with 304 Not Modified
+	ConditionNotModified
+	// ConditionFailed - a precondition does not hold, the request should be answered with 412 Precondition Failed
+	ConditionFailed
+)
+
+// Conditions holds the values of the conditional headers of a request (RFC 7232)
+type Conditions struct {
+	IfMatch           string
+	IfNoneMatch       string
+	IfModifiedSince   string
+	IfUnmodifiedSince string
+}
+
+// ConditionsFromHeader returns the conditions of the request header
+func ConditionsFromHeader(header http.Header) Conditions {
+	return Conditions{
+		IfMatch:           header.Get("If-Match"),
+		IfNoneMatch:       header.Get("If-None-Match"),
+		IfModifiedSince:   header.Get("If-Modified-Since"),
+		IfUnmodifiedSince: header.Get("If-Unmodified-Since"),
+	}
+}
+
+// Evaluate the conditions against the resource etag (checksum, without quotes) and last modified time.
+// Like S3, If-Unmodified-Since is ignored when If-Match is set, and If-Modified-Since is ignored when
+// If-None-Match is set.  Unparsable dates are ignored.
+func (c Conditions) Evaluate(etag string, lastModified time.Time) ConditionResult {
+	lastModified = lastModified.Truncate(time.Second)
+	if c.IfMatch != "" {
+		if !ETagMatches(c.IfMatch, etag) {
+			return ConditionFailed
+		}
+	} else if t, err := http.ParseTime(c.IfUnmodifiedSince); err == nil && lastModified.After(t) {
+		return ConditionFailed
+	}
+	if c.IfNoneMatch != "" {
+		if ETagMatches(c.IfNoneMatch, etag) {
+			return ConditionNotModified
+		}
+	} else if t, err := http.ParseTime(c.IfModifiedSince); err == nil && !lastModified.After(t) {
+		return ConditionNotModified
+	}
+	return ConditionPassed
+}
+
+// ETagMatches reports whether a comma separated list of etags, as found in an If-Match or If-None-Match
+// header, holds etag or "*"
+func ETagMatches(list, etag string) bool {
+	for _, value := range strings.Split(list, ",") {
+		value = strings.TrimSpace(value)
+		if value == "*" {
+			return true
+		}
+		value = strings.TrimPrefix(value, "W/")
+		if strings.Trim(value, "\"") == etag {
+			return true
+		}
+	}
+	return false
+}
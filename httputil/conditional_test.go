@@ -0,0 +1,43 @@
+package httputil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConditions_Evaluate(t *testing.T) {
+	const etag = "d41d8cd98f00b204e9800998ecf8427e"
+	lastModified := time.Date(2020, 10, 1, 12, 0, 0, 500, time.UTC)
+	before := HeaderTimestamp(lastModified.Add(-time.Hour))
+	at := HeaderTimestamp(lastModified)
+	after := HeaderTimestamp(lastModified.Add(time.Hour))
+	tests := []struct {
+		name       string
+		conditions Conditions
+		want       ConditionResult
+	}{
+		{name: "none", conditions: Conditions{}, want: ConditionPassed},
+		{name: "if match", conditions: Conditions{IfMatch: `"` + etag + `"`}, want: ConditionPassed},
+		{name: "if match list", conditions: Conditions{IfMatch: `"abc", "` + etag + `"`}, want: ConditionPassed},
+		{name: "if match any", conditions: Conditions{IfMatch: "*"}, want: ConditionPassed},
+		{name: "if match other", conditions: Conditions{IfMatch: `"abc"`}, want: ConditionFailed},
+		{name: "if none match", conditions: Conditions{IfNoneMatch: `"` + etag + `"`}, want: ConditionNotModified},
+		{name: "if none match other", conditions: Conditions{IfNoneMatch: `"abc"`}, want: ConditionPassed},
+		{name: "if modified since before", conditions: Conditions{IfModifiedSince: before}, want: ConditionPassed},
+		{name: "if modified since at", conditions: Conditions{IfModifiedSince: at}, want: ConditionNotModified},
+		{name: "if modified since after", conditions: Conditions{IfModifiedSince: after}, want: ConditionNotModified},
+		{name: "if unmodified since before", conditions: Conditions{IfUnmodifiedSince: before}, want: ConditionFailed},
+		{name: "if unmodified since at", conditions: Conditions{IfUnmodifiedSince: at}, want: ConditionPassed},
+		{name: "if unmodified since invalid", conditions: Conditions{IfUnmodifiedSince: "yesterday"}, want: ConditionPassed},
+		{name: "if match overrides if unmodified since", conditions: Conditions{IfMatch: etag, IfUnmodifiedSince: before}, want: ConditionPassed},
+		{name: "if none match overrides if modified since", conditions: Conditions{IfNoneMatch: `"abc"`, IfModifiedSince: after}, want: ConditionPassed},
+		{name: "if none match and if modified since", conditions: Conditions{IfNoneMatch: etag, IfModifiedSince: before}, want: ConditionNotModified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.conditions.Evaluate(etag, lastModified); got != tt.want {
+				t.Errorf("Evaluate() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -102,7 +102,8 @@ func (c *CatalogRepoActions) GetPreviousCommit(ctx context.Context) (commit *cat
 }
 
 func (c *CatalogRepoActions) Commit(ctx context.Context, commitMsg string, metadata catalog.Metadata) error {
-	_, err := c.cataloger.Commit(ctx, c.repository, DefaultBranchName,
+	// the import commit is reported as a commit event and as an import event, both written with the commit
+	_, err := c.cataloger.CommitImport(ctx, c.repository, DefaultBranchName,
 		commitMsg,
 		c.committer,
 		metadata)
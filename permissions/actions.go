@@ -14,6 +14,7 @@ var (
 const (
 	ReadRepositoryAction   = "fs:ReadRepository"
 	CreateRepositoryAction = "fs:CreateRepository"
+	UpdateRepositoryAction = "fs:UpdateRepository"
 	DeleteRepositoryAction = "fs:DeleteRepository"
 	ListRepositoriesAction = "fs:ListRepositories"
 	ReadObjectAction       = "fs:ReadObject"
@@ -27,6 +28,18 @@ const (
 	ReadBranchAction       = "fs:ReadBranch"
 	RevertBranchAction     = "fs:RevertBranch"
 	ListBranchesAction     = "fs:ListBranches"
+	CreateTagAction        = "fs:CreateTag"
+	DeleteTagAction        = "fs:DeleteTag"
+	ReadTagAction          = "fs:ReadTag"
+	ListTagsAction         = "fs:ListTags"
+
+	CreateBranchProtectionRuleAction = "fs:CreateBranchProtectionRule"
+	DeleteBranchProtectionRuleAction = "fs:DeleteBranchProtectionRule"
+	ListBranchProtectionRulesAction  = "fs:ListBranchProtectionRules"
+
+	CreateHookAction = "fs:CreateHook"
+	DeleteHookAction = "fs:DeleteHook"
+	ListHooksAction  = "fs:ListHooks"
 
 	RetentionReadPolicyAction  = "retention:GetPolicy"
 	RetentionWritePolicyAction = "retention:WritePolicy"
@@ -47,6 +47,18 @@ func TestParse(t *testing.T) {
 			Repository: "foo",
 			Ref:        "bar",
 		}},
+		{"lakefs://foo@bar~3/baz", nil, &uri.URI{
+			Protocol:   "lakefs",
+			Repository: "foo",
+			Ref:        "bar~3",
+			Path:       "baz",
+		}},
+		{"lakefs://foo@bar@{2020-10-01T00:00:00Z}^2/baz", nil, &uri.URI{
+			Protocol:   "lakefs",
+			Repository: "foo",
+			Ref:        "bar@{2020-10-01T00:00:00Z}^2",
+			Path:       "baz",
+		}},
 		{"lakefssss://foo@bar/baz", uri.ErrMalformedURI, nil},
 		{"lakefs:/foo@bar/baz", uri.ErrMalformedURI, nil},
 		{"lakefs//foo@bar/baz", uri.ErrMalformedURI, nil},
@@ -0,0 +1,288 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/logging"
+)
+
+const (
+	DefaultPollInterval   = 5 * time.Second
+	DefaultMaxAttempts    = 10
+	DefaultInitialBackoff = 10 * time.Second
+	DefaultMaxBackoff     = time.Hour
+	DefaultBatchSize      = 100
+	DefaultRequestTimeout = 10 * time.Second
+	// DefaultClaimLease is the time a deliverer holds the events it claimed before other deliverers may claim them
+	DefaultClaimLease = 30 * time.Minute
+
+	EventHeader     = "X-LakeFS-Event"
+	EventIDHeader   = "X-LakeFS-Event-ID"
+	SignatureHeader = "X-LakeFS-Signature"
+
+	signaturePrefix = "sha256="
+
+	// maxErrorMessageLength limits the part of a failed delivery response body kept as the event last error
+	maxErrorMessageLength = 1024
+)
+
+// Endpoint is a webhook receiver.  When Secret is set, each delivery is signed with it.
+type Endpoint struct {
+	URL    string `mapstructure:"url"`
+	Secret string `mapstructure:"secret"`
+}
+
+type outboxEvent struct {
+	ID                 int64  `db:"id"`
+	EventType          string `db:"event_type"`
+	Payload            []byte `db:"payload"`
+	Attempts           int    `db:"attempts"`
+	DeliveredEndpoints []byte `db:"delivered_endpoints"`
+}
+
+// Deliverer posts the events written to the catalog events outbox to the webhook endpoints.  An event is
+// delivered to each endpoint at least once - failed deliveries are retried with exponential backoff until
+// every endpoint accepts the event or the max attempts are used.
+type Deliverer struct {
+	db             db.Database
+	endpoints      []Endpoint
+	client         *http.Client
+	pollInterval   time.Duration
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	batchSize      int
+	claimLease     time.Duration
+	log            logging.Logger
+}
+
+type DelivererOption func(*Deliverer)
+
+func WithHTTPClient(client *http.Client) DelivererOption {
+	return func(d *Deliverer) {
+		d.client = client
+	}
+}
+
+func WithPollInterval(interval time.Duration) DelivererOption {
+	return func(d *Deliverer) {
+		d.pollInterval = interval
+	}
+}
+
+func WithMaxAttempts(attempts int) DelivererOption {
+	return func(d *Deliverer) {
+		d.maxAttempts = attempts
+	}
+}
+
+func WithBackoff(initial, max time.Duration) DelivererOption {
+	return func(d *Deliverer) {
+		d.initialBackoff = initial
+		d.maxBackoff = max
+	}
+}
+
+func NewDeliverer(database db.Database, endpoints []Endpoint, opts ...DelivererOption) *Deliverer {
+	d := &Deliverer{
+		db:             database,
+		endpoints:      endpoints,
+		client:         &http.Client{Timeout: DefaultRequestTimeout},
+		pollInterval:   DefaultPollInterval,
+		maxAttempts:    DefaultMaxAttempts,
+		initialBackoff: DefaultInitialBackoff,
+		maxBackoff:     DefaultMaxBackoff,
+		batchSize:      DefaultBatchSize,
+		claimLease:     DefaultClaimLease,
+		log:            logging.Default().WithField("service_name", "webhooks"),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Run delivers pending events every poll interval, until the context is done
+func (d *Deliverer) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+	for {
+		for {
+			// keep going while full batches are delivered
+			n, err := d.DeliverPending(ctx)
+			if err != nil {
+				d.log.WithError(err).Error("failed to deliver events")
+			}
+			if err != nil || n < d.batchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverPending makes a single delivery attempt of the events due for delivery, and returns the number of
+// events attempted.  Events are claimed for the claim lease before they are delivered, so a number of lakeFS
+// instances can share the outbox without holding database locks while posting to the endpoints.
+func (d *Deliverer) DeliverPending(ctx context.Context) (int, error) {
+	leaseUntil := time.Now().Add(d.claimLease).UTC().Truncate(time.Microsecond)
+	events, err := d.claim(ctx, leaseUntil)
+	if err != nil {
+		return 0, err
+	}
+	for i := range events {
+		if err := d.deliver(ctx, &events[i], leaseUntil); err != nil {
+			return i, err
+		}
+	}
+	return len(events), nil
+}
+
+// claim selects the events due for delivery and postpones their next attempt to leaseUntil, so other
+// deliverers skip them while they are delivered
+func (d *Deliverer) claim(ctx context.Context, leaseUntil time.Time) ([]outboxEvent, error) {
+	res, err := d.db.Transact(func(tx db.Tx) (interface{}, error) {
+		var events []outboxEvent
+		err := tx.Select(&events, `UPDATE catalog_events_outbox SET next_attempt_date = $3
+			WHERE id IN (SELECT id FROM catalog_events_outbox
+				WHERE delivery_date IS NULL AND attempts < $1 AND next_attempt_date <= now()
+				ORDER BY id
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED)
+			RETURNING id, event_type, payload, attempts, delivered_endpoints`, d.maxAttempts, d.batchSize, leaseUntil)
+		if err != nil {
+			return nil, fmt.Errorf("claim events: %w", err)
+		}
+		return events, nil
+	}, db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	events := res.([]outboxEvent)
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ID < events[j].ID
+	})
+	return events, nil
+}
+
+// deliver posts the event to the endpoints that didn't accept it yet, and records the attempt.  The attempt is
+// recorded only while the claim is held - an event whose lease expired was claimed again by another deliverer.
+func (d *Deliverer) deliver(ctx context.Context, event *outboxEvent, leaseUntil time.Time) error {
+	var delivered []string
+	if err := json.Unmarshal(event.DeliveredEndpoints, &delivered); err != nil {
+		return fmt.Errorf("event %d delivered endpoints: %w", event.ID, err)
+	}
+	deliveredSet := make(map[string]bool, len(delivered))
+	for _, u := range delivered {
+		deliveredSet[u] = true
+	}
+	var lastErr error
+	for _, endpoint := range d.endpoints {
+		if deliveredSet[endpoint.URL] {
+			continue
+		}
+		err := d.post(ctx, endpoint, event)
+		if err != nil {
+			d.log.WithError(err).WithFields(logging.Fields{
+				"event_id":   event.ID,
+				"event_type": event.EventType,
+				"endpoint":   endpoint.URL,
+				"attempt":    event.Attempts + 1,
+			}).Warn("event delivery failed")
+			lastErr = err
+			continue
+		}
+		deliveredSet[endpoint.URL] = true
+		delivered = append(delivered, endpoint.URL)
+	}
+	deliveredEndpoints, err := json.Marshal(delivered)
+	if err != nil {
+		return fmt.Errorf("event %d delivered endpoints: %w", event.ID, err)
+	}
+	attempts := event.Attempts + 1
+	res, err := d.db.Transact(func(tx db.Tx) (interface{}, error) {
+		if lastErr == nil {
+			return tx.Exec(`UPDATE catalog_events_outbox
+				SET attempts = $2, delivered_endpoints = $3, last_error = NULL, delivery_date = now()
+				WHERE id = $1 AND next_attempt_date = $4`, event.ID, attempts, deliveredEndpoints, leaseUntil)
+		}
+		nextAttempt := time.Now().Add(Backoff(attempts, d.initialBackoff, d.maxBackoff))
+		return tx.Exec(`UPDATE catalog_events_outbox
+			SET attempts = $2, delivered_endpoints = $3, last_error = $4, next_attempt_date = $5
+			WHERE id = $1 AND next_attempt_date = $6`, event.ID, attempts, deliveredEndpoints, lastErr.Error(), nextAttempt, leaseUntil)
+	}, db.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("update event %d: %w", event.ID, err)
+	}
+	if affected, err := res.(sql.Result).RowsAffected(); err == nil && affected == 0 {
+		d.log.WithField("event_id", event.ID).Warn("event claim expired before the delivery was recorded")
+	}
+	return nil
+}
+
+func (d *Deliverer) post(ctx context.Context, endpoint Endpoint, event *outboxEvent) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(event.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event.EventType)
+	req.Header.Set(EventIDHeader, strconv.FormatInt(event.ID, 10))
+	if endpoint.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign([]byte(endpoint.Secret), event.Payload))
+	}
+	res, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+	msg, _ := ioutil.ReadAll(io.LimitReader(res.Body, maxErrorMessageLength))
+	return fmt.Errorf("%s status %d: %s", endpoint.URL, res.StatusCode, bytes.TrimSpace(msg))
+}
+
+// Sign returns the signature header value of body: the hex encoded HMAC-SHA256 of body keyed by secret,
+// prefixed by "sha256=".  Receivers verify a delivery by computing the same value over the raw request body.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns the delay before the next delivery attempt, after the given number of failed attempts.
+// The delay doubles on each attempt, starting from initial and limited by max.
+func Backoff(attempts int, initial, max time.Duration) time.Duration {
+	delay := initial
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
@@ -0,0 +1,207 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/treeverse/lakefs/catalog"
+	"github.com/treeverse/lakefs/db"
+	"github.com/treeverse/lakefs/testutil"
+	"github.com/treeverse/lakefs/webhooks"
+)
+
+type testReceiver struct {
+	mu       sync.Mutex
+	secret   string
+	failures int
+	events   []catalog.Event
+	// onRequest is called when a request is received, before it is handled
+	onRequest func()
+}
+
+func (r *testReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if r.onRequest != nil {
+		r.onRequest()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if r.secret != "" && req.Header.Get(webhooks.SignatureHeader) != webhooks.Sign([]byte(r.secret), body) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	var ev catalog.Event
+	if err := json.Unmarshal(body, &ev); err != nil || req.Header.Get(webhooks.EventHeader) != string(ev.Type) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.events = append(r.events, ev)
+}
+
+func (r *testReceiver) eventTypes() []catalog.EventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	types := make([]catalog.EventType, len(r.events))
+	for i, ev := range r.events {
+		types[i] = ev.Type
+	}
+	return types
+}
+
+func TestSign(t *testing.T) {
+	const expected = "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+	if sig := webhooks.Sign([]byte("key"), []byte("The quick brown fox jumps over the lazy dog")); sig != expected {
+		t.Fatalf("Sign() = %s, expected %s", sig, expected)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	cases := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 1, expected: time.Second},
+		{attempts: 2, expected: 2 * time.Second},
+		{attempts: 4, expected: 8 * time.Second},
+		{attempts: 5, expected: 10 * time.Second},
+		{attempts: 100, expected: 10 * time.Second},
+	}
+	for _, tt := range cases {
+		if delay := webhooks.Backoff(tt.attempts, time.Second, 10*time.Second); delay != tt.expected {
+			t.Errorf("Backoff(%d) = %s, expected %s", tt.attempts, delay, tt.expected)
+		}
+	}
+}
+
+func TestDeliverer_DeliverPending(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := testutil.GetDB(t, databaseURI)
+	c := catalog.NewCataloger(conn, catalog.WithEvents(true))
+
+	signed := &testReceiver{secret: "secret"}
+	signedServer := httptest.NewServer(signed)
+	defer signedServer.Close()
+	failing := &testReceiver{failures: 1}
+	failingServer := httptest.NewServer(failing)
+	defer failingServer.Close()
+
+	deliverer := webhooks.NewDeliverer(conn, []webhooks.Endpoint{
+		{URL: signedServer.URL, Secret: "secret"},
+		{URL: failingServer.URL},
+	}, webhooks.WithBackoff(0, 0))
+
+	testutil.MustDo(t, "create repository", c.CreateRepository(ctx, "repo1", "s3://bucket", "master"))
+	_, err := c.CreateBranch(ctx, "repo1", "branch1", "master")
+	testutil.MustDo(t, "create branch", err)
+
+	// first attempt - failing endpoint rejects the first event
+	n, err := deliverer.DeliverPending(ctx)
+	testutil.MustDo(t, "first delivery", err)
+	if n != 2 {
+		t.Fatalf("first delivery attempted %d events, expected 2", n)
+	}
+	expected := []catalog.EventType{catalog.EventTypeRepositoryCreate, catalog.EventTypeBranchCreate}
+	if types := signed.eventTypes(); len(types) != 2 || types[0] != expected[0] || types[1] != expected[1] {
+		t.Fatalf("signed endpoint events %v, expected %v", types, expected)
+	}
+	if types := failing.eventTypes(); len(types) != 1 || types[0] != catalog.EventTypeBranchCreate {
+		t.Fatalf("failing endpoint events %v, expected [%s]", types, catalog.EventTypeBranchCreate)
+	}
+
+	// retry - only the endpoint that failed gets the event again
+	n, err = deliverer.DeliverPending(ctx)
+	testutil.MustDo(t, "retry delivery", err)
+	if n != 1 {
+		t.Fatalf("retry attempted %d events, expected 1", n)
+	}
+	if types := signed.eventTypes(); len(types) != 2 {
+		t.Fatalf("signed endpoint events %v, expected no redelivery", types)
+	}
+	if types := failing.eventTypes(); len(types) != 2 || types[1] != catalog.EventTypeRepositoryCreate {
+		t.Fatalf("failing endpoint events %v, expected %s retried", types, catalog.EventTypeRepositoryCreate)
+	}
+
+	// nothing left to deliver
+	n, err = deliverer.DeliverPending(ctx)
+	testutil.MustDo(t, "last delivery", err)
+	if n != 0 {
+		t.Fatalf("last delivery attempted %d events, expected none", n)
+	}
+}
+
+func TestDeliverer_MaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := testutil.GetDB(t, databaseURI)
+	c := catalog.NewCataloger(conn, catalog.WithEvents(true))
+
+	receiver := &testReceiver{failures: 10}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	deliverer := webhooks.NewDeliverer(conn, []webhooks.Endpoint{{URL: server.URL}},
+		webhooks.WithBackoff(0, 0), webhooks.WithMaxAttempts(2))
+
+	testutil.MustDo(t, "create repository", c.CreateRepository(ctx, "repo1", "s3://bucket", "master"))
+	for i, expected := range []int{1, 1, 0} {
+		n, err := deliverer.DeliverPending(ctx)
+		testutil.MustDo(t, "deliver", err)
+		if n != expected {
+			t.Fatalf("delivery %d attempted %d events, expected %d", i, n, expected)
+		}
+	}
+	if types := receiver.eventTypes(); len(types) != 0 {
+		t.Fatalf("events %v, expected none delivered", types)
+	}
+}
+
+func TestDeliverer_DeliverOutsideTransaction(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := testutil.GetDB(t, databaseURI)
+	c := catalog.NewCataloger(conn, catalog.WithEvents(true))
+
+	receiver := &testReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	deliverer := webhooks.NewDeliverer(conn, []webhooks.Endpoint{{URL: server.URL}}, webhooks.WithBackoff(0, 0))
+	other := webhooks.NewDeliverer(conn, []webhooks.Endpoint{{URL: server.URL}}, webhooks.WithBackoff(0, 0))
+
+	testutil.MustDo(t, "create repository", c.CreateRepository(ctx, "repo1", "s3://bucket", "master"))
+	var requests int
+	receiver.onRequest = func() {
+		requests++
+		// events are not locked while delivered
+		_, err := conn.Transact(func(tx db.Tx) (interface{}, error) {
+			return tx.Exec(`SELECT id FROM catalog_events_outbox FOR UPDATE NOWAIT`)
+		})
+		if err != nil {
+			t.Errorf("lock events while delivered: %s", err)
+		}
+		// claimed events are not delivered by another deliverer
+		n, err := other.DeliverPending(ctx)
+		if err != nil || n != 0 {
+			t.Errorf("other deliverer attempted %d events (err %v), expected none", n, err)
+		}
+	}
+	n, err := deliverer.DeliverPending(ctx)
+	testutil.MustDo(t, "deliver", err)
+	if n != 1 || requests != 1 {
+		t.Fatalf("delivery attempted %d events with %d requests, expected one", n, requests)
+	}
+	if types := receiver.eventTypes(); len(types) != 1 || types[0] != catalog.EventTypeRepositoryCreate {
+		t.Fatalf("events %v, expected [%s]", types, catalog.EventTypeRepositoryCreate)
+	}
+}